@@ -0,0 +1,437 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Command domdistiller extracts the main content from a web page. The page can be
+// read from a file, downloaded from URL or read from stdin, and the result can be
+// printed as HTML, plain text, Markdown or JSON. If the input is a directory, every
+// HTML file inside it is distilled and saved into the output directory.
+//
+// Usage:
+//
+//	domdistiller [flags] [file | url | directory | -]
+//
+// Run "domdistiller -h" to see the available flags.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	nurl "net/url"
+	"os"
+	fp "path/filepath"
+	"strings"
+	"time"
+
+	distiller "github.com/markusmobius/go-domdistiller"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/sanitize"
+	"github.com/markusmobius/go-domdistiller/siterule"
+	"github.com/markusmobius/go-domdistiller/srcset"
+)
+
+// config is the parsed command line flags.
+type config struct {
+	format         string
+	output         string
+	originalURL    string
+	logFlags       string
+	skipPagination bool
+	paginationAlgo string
+	timeout        time.Duration
+	userAgent      string
+	contentTypes   string
+	maxSize        int64
+	multiPage      bool
+	maxPages       int
+	siteRules      string
+	language       string
+	markupOrder    string
+	imageWidth     int
+	imageAvoid     string
+	imageBlocklist string
+	sanitize       string
+	pageKeywords   string
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command with the specified arguments, and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cfg, input, err := parseFlags(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	} else if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 2
+	}
+
+	opts, err := createOptions(cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 2
+	}
+
+	// Distill every HTML files if input is a directory
+	if info, err := os.Stat(input); err == nil && info.IsDir() {
+		if cfg.output == "" {
+			fmt.Fprintln(stderr, "error: output directory (-o) is required in batch mode")
+			return 2
+		}
+
+		if err = runBatch(cfg, opts, input, stderr); err != nil {
+			fmt.Fprintln(stderr, "error:", err)
+			return 1
+		}
+		return 0
+	}
+
+	result, pages, err := distill(cfg, opts, input, stdin, stderr)
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+
+	// Write the result
+	var w io.Writer = stdout
+	if cfg.output != "" {
+		f, err := os.Create(cfg.output)
+		if err != nil {
+			fmt.Fprintln(stderr, "error:", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err = writeResult(w, cfg.format, result, pages); err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+func parseFlags(args []string, stderr io.Writer) (config, string, error) {
+	var cfg config
+	fs := flag.NewFlagSet("domdistiller", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: domdistiller [flags] [file | url | directory | -]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Extract the main content from a web page. If no input specified or the input")
+		fmt.Fprintln(stderr, "is \"-\", the page is read from stdin. If the input is a directory, every HTML")
+		fmt.Fprintln(stderr, "files inside it will be distilled and saved into the output directory.")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		fs.PrintDefaults()
+	}
+
+	fs.StringVar(&cfg.format, "format", "html", "output format: html, text, markdown or json")
+	fs.StringVar(&cfg.output, "o", "", "output file, or output directory in batch mode (default stdout)")
+	fs.StringVar(&cfg.originalURL, "url", "", "original URL of the page when reading from file or stdin, used for resolving links and site rules")
+	fs.StringVar(&cfg.logFlags, "log", "", "comma separated logs to print: extraction, visibility, pagination, timing or all")
+	fs.BoolVar(&cfg.skipPagination, "skip-pagination", false, "skip finding the pagination links")
+	fs.StringVar(&cfg.paginationAlgo, "pagination-algo", "prevnext", "algorithm for finding pagination links: prevnext or pagenumber")
+	fs.DurationVar(&cfg.timeout, "timeout", time.Minute, "timeout for downloading the page")
+	fs.StringVar(&cfg.userAgent, "user-agent", "", "User-Agent header for downloading the page")
+	fs.StringVar(&cfg.contentTypes, "content-types", "", "comma separated accepted content types of the page (default \"text/html,application/xhtml+xml\")")
+	fs.Int64Var(&cfg.maxSize, "max-size", 0, "maximum size in bytes of the downloaded page, 0 means no limit")
+	fs.BoolVar(&cfg.multiPage, "multipage", false, "follow the next page links and merge the content of all pages")
+	fs.IntVar(&cfg.maxPages, "max-pages", distiller.DefaultMaxPages, "maximum number of pages followed in multipage mode")
+	fs.StringVar(&cfg.siteRules, "site-rules", "", "path to YAML or JSON file that contains the site rules")
+	fs.StringVar(&cfg.language, "lang", "", "BCP-47 language tag of the page, e.g. \"en\" or \"pt-BR\" (default detected from the page)")
+	fs.StringVar(&cfg.markupOrder, "markup-precedence", "", "comma separated metadata sources by precedence: opengraph, schema.org, json-ld, twitter-card, dublin-core, highwire or ie-reader")
+	fs.IntVar(&cfg.imageWidth, "image-width", 0, "target width in pixels for picking the image from srcset and <picture>, 0 keeps the original src")
+	fs.StringVar(&cfg.imageAvoid, "image-avoid-types", "", "comma separated image MIME types that never picked from srcset and <picture>, e.g. \"image/webp,image/avif\"")
+	fs.StringVar(&cfg.imageBlocklist, "image-blocklist", "", "comma separated strings that remove the image from the content when found in its URL, e.g. \"ads.example.com,/promo/\"")
+	fs.StringVar(&cfg.sanitize, "sanitize", "strict", "sanitizer policy for the distilled content: strict or none")
+	fs.StringVar(&cfg.pageKeywords, "pagination-keywords", "", "comma separated extra pagination keywords as kind:term, where kind is next, prev, extraneous or firstlast, e.g. \"next:suite,prev:retour\"")
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, "", err
+	}
+
+	switch cfg.format {
+	case "html", "text", "markdown", "json":
+	default:
+		return cfg, "", fmt.Errorf("unknown format %q", cfg.format)
+	}
+
+	switch fs.NArg() {
+	case 0:
+		return cfg, "-", nil
+	case 1:
+		return cfg, fs.Arg(0), nil
+	default:
+		return cfg, "", fmt.Errorf("expected one input, got %d", fs.NArg())
+	}
+}
+
+func createOptions(cfg config) (*distiller.Options, error) {
+	opts := &distiller.Options{
+		SkipPagination:  cfg.skipPagination,
+		MaxResponseSize: cfg.maxSize,
+		MaxPages:        cfg.maxPages,
+	}
+
+	// Parse log flags
+	for _, name := range strings.Split(cfg.logFlags, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "extraction":
+			opts.LogFlags |= distiller.LogExtraction
+		case "visibility":
+			opts.LogFlags |= distiller.LogVisibility
+		case "pagination":
+			opts.LogFlags |= distiller.LogPagination
+		case "timing":
+			opts.LogFlags |= distiller.LogTiming
+		case "all":
+			opts.LogFlags |= distiller.LogEverything
+		default:
+			return nil, fmt.Errorf("unknown log flag %q", name)
+		}
+	}
+
+	switch cfg.paginationAlgo {
+	case "prevnext":
+		opts.PaginationAlgo = distiller.PrevNext
+	case "pagenumber":
+		opts.PaginationAlgo = distiller.PageNumber
+	default:
+		return nil, fmt.Errorf("unknown pagination algorithm %q", cfg.paginationAlgo)
+	}
+
+	if cfg.originalURL != "" {
+		parsedURL, err := nurl.ParseRequestURI(cfg.originalURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid original URL: %w", err)
+		}
+		opts.OriginalURL = parsedURL
+	}
+
+	for _, contentType := range strings.Split(cfg.contentTypes, ",") {
+		if contentType = strings.TrimSpace(contentType); contentType != "" {
+			opts.AcceptedContentTypes = append(opts.AcceptedContentTypes, contentType)
+		}
+	}
+
+	if cfg.siteRules != "" {
+		rules, err := siterule.Load(cfg.siteRules)
+		if err != nil {
+			return nil, err
+		}
+		opts.SiteRules = rules
+	}
+
+	opts.Language = cfg.language
+
+	for _, name := range strings.Split(cfg.markupOrder, ",") {
+		source := data.MarkupSource(strings.TrimSpace(name))
+		switch source {
+		case "":
+		case data.MarkupFromOpenGraph, data.MarkupFromSchemaOrg, data.MarkupFromJSONLD,
+			data.MarkupFromTwitterCard, data.MarkupFromDublinCore, data.MarkupFromHighwire,
+			data.MarkupFromIEReader:
+			opts.MarkupPrecedence = append(opts.MarkupPrecedence, source)
+		default:
+			return nil, fmt.Errorf("unknown markup source %q", name)
+		}
+	}
+
+	if cfg.imageWidth < 0 {
+		return nil, fmt.Errorf("invalid image width %d", cfg.imageWidth)
+	}
+
+	var avoidTypes []string
+	for _, imageType := range strings.Split(cfg.imageAvoid, ",") {
+		if imageType = strings.TrimSpace(imageType); imageType != "" {
+			avoidTypes = append(avoidTypes, imageType)
+		}
+	}
+
+	if cfg.imageWidth > 0 || len(avoidTypes) > 0 {
+		opts.ImageResolver = &srcset.Resolver{
+			TargetWidth: cfg.imageWidth,
+			AvoidTypes:  avoidTypes,
+		}
+	}
+
+	for _, entry := range strings.Split(cfg.imageBlocklist, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			opts.ImageBlocklist = append(opts.ImageBlocklist, entry)
+		}
+	}
+
+	switch cfg.sanitize {
+	case "strict":
+		opts.Sanitizer = sanitize.Strict()
+	case "none":
+		opts.SkipSanitize = true
+	default:
+		return nil, fmt.Errorf("unknown sanitizer policy %q", cfg.sanitize)
+	}
+
+	for _, entry := range strings.Split(cfg.pageKeywords, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		kind, term, _ := strings.Cut(entry, ":")
+		if term = strings.TrimSpace(term); term == "" {
+			return nil, fmt.Errorf("invalid pagination keyword %q", entry)
+		}
+
+		switch strings.TrimSpace(kind) {
+		case "next":
+			opts.PaginationKeywords.Next = append(opts.PaginationKeywords.Next, term)
+		case "prev":
+			opts.PaginationKeywords.Prev = append(opts.PaginationKeywords.Prev, term)
+		case "extraneous":
+			opts.PaginationKeywords.Extraneous = append(opts.PaginationKeywords.Extraneous, term)
+		case "firstlast":
+			opts.PaginationKeywords.FirstLast = append(opts.PaginationKeywords.FirstLast, term)
+		default:
+			return nil, fmt.Errorf("unknown pagination keyword kind %q", kind)
+		}
+	}
+
+	opts.Fetcher = &distiller.HTTPFetcher{
+		Client: &http.Client{Timeout: cfg.timeout},
+		RequestHook: func(req *http.Request) error {
+			if cfg.userAgent != "" {
+				req.Header.Set("User-Agent", cfg.userAgent)
+			}
+			return nil
+		},
+	}
+
+	return opts, nil
+}
+
+// distill runs distiller for the input, which can be URL, file path or "-" for stdin. In
+// multipage mode, the result of each page is returned as well, and failure in the next
+// pages is reported as warning in stderr while the content of the previous pages is
+// still returned.
+func distill(cfg config, opts *distiller.Options, input string, stdin io.Reader, stderr io.Writer) (*distiller.Result, []*distiller.Result, error) {
+	// Copy the options since the distiller may modify it, e.g. OriginalURL.
+	optsCopy := *opts
+	ctx := context.Background()
+
+	switch {
+	case input == "-":
+		result, err := distiller.ApplyForReaderContext(ctx, stdin, &optsCopy)
+		return result, nil, err
+
+	case isURL(input):
+		if !cfg.multiPage {
+			result, err := distiller.ApplyForURLContext(ctx, input, &optsCopy)
+			return result, nil, err
+		}
+
+		result, err := distiller.ApplyForURLMultiPageContext(ctx, input, &optsCopy)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if result.PageError != nil {
+			fmt.Fprintf(stderr, "warning: only %d page(s) distilled: %v\n", len(result.Pages), result.PageError)
+		}
+		return &result.Result, result.Pages, nil
+
+	default:
+		result, err := distiller.ApplyForFileContext(ctx, input, &optsCopy)
+		return result, nil, err
+	}
+}
+
+// runBatch distills every HTML file inside the input directory and saves the result into
+// the output directory, keeping the relative path of each file. Failure in a file doesn't
+// stop the process, but it's reported in the returned error.
+func runBatch(cfg config, opts *distiller.Options, inputDir string, stderr io.Writer) error {
+	var nFailed int
+	err := fp.Walk(inputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		ext := strings.ToLower(fp.Ext(path))
+		if info.IsDir() || (ext != ".html" && ext != ".htm") {
+			return nil
+		}
+
+		if err := distillFile(cfg, opts, inputDir, path, stderr); err != nil {
+			fmt.Fprintf(stderr, "failed to distill %s: %v\n", path, err)
+			nFailed++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if nFailed > 0 {
+		return fmt.Errorf("failed to distill %d file(s)", nFailed)
+	}
+
+	return nil
+}
+
+func distillFile(cfg config, opts *distiller.Options, inputDir, path string, stderr io.Writer) error {
+	result, pages, err := distill(cfg, opts, path, nil, stderr)
+	if err != nil {
+		return err
+	}
+
+	relPath, err := fp.Rel(inputDir, path)
+	if err != nil {
+		return err
+	}
+
+	outputPath := fp.Join(cfg.output, strings.TrimSuffix(relPath, fp.Ext(relPath))+formatExtensions[cfg.format])
+	if err = os.MkdirAll(fp.Dir(outputPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return writeResult(f, cfg.format, result, pages)
+}
+
+func isURL(input string) bool {
+	parsedURL, err := nurl.ParseRequestURI(input)
+	if err != nil {
+		return false
+	}
+
+	return parsedURL.Scheme == "http" || parsedURL.Scheme == "https"
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	fp "path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPage = `<html><head><title>Test page</title></head><body>` +
+	strings.Repeat(`<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod `+
+		`tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis `+
+		`nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>`, 5) +
+	`<img src="image.jpg"></body></html>`
+
+func Test_Cmd_Stdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"-format", "json", "-skip-pagination", "-url", "http://example.com/article/"}
+	exitCode := run(args, strings.NewReader(testPage), &stdout, &stderr)
+	assert.Equal(t, 0, exitCode, stderr.String())
+
+	var result jsonResult
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Equal(t, "http://example.com/article/", result.URL)
+	assert.Equal(t, "Test page", result.Title)
+	assert.Equal(t, []string{"http://example.com/article/image.jpg"}, result.ContentImages)
+	assert.Contains(t, result.Text, "Lorem ipsum")
+	assert.Contains(t, result.HTML, "<p>Lorem ipsum")
+
+	// Keys are in camelCase, including the ones in nested objects
+	var raw map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &raw))
+	assert.Contains(t, raw, "title")
+	assert.Contains(t, raw, "markupInfo")
+	assert.NotContains(t, raw, "Title")
+	assert.Contains(t, string(raw["language"]), `"source"`)
+	assert.Contains(t, string(raw["timingInfo"]), `"totalTime"`)
+}
+
+func Test_Cmd_File(t *testing.T) {
+	dir := t.TempDir()
+	input := fp.Join(dir, "page.html")
+	output := fp.Join(dir, "page.txt")
+	assert.NoError(t, os.WriteFile(input, []byte(testPage), os.ModePerm))
+
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{"-format", "text", "-o", output, input}, nil, &stdout, &stderr)
+	assert.Equal(t, 0, exitCode, stderr.String())
+	assert.Empty(t, stdout.String())
+
+	text, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(text), "Lorem ipsum"))
+}
+
+func Test_Cmd_Batch(t *testing.T) {
+	inputDir := t.TempDir()
+	outputDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(fp.Join(inputDir, "sub"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(fp.Join(inputDir, "a.html"), []byte(testPage), os.ModePerm))
+	assert.NoError(t, os.WriteFile(fp.Join(inputDir, "sub", "b.htm"), []byte(testPage), os.ModePerm))
+	assert.NoError(t, os.WriteFile(fp.Join(inputDir, "notes.txt"), []byte("not html"), os.ModePerm))
+
+	// Output directory is required
+	var stdout, stderr bytes.Buffer
+	exitCode := run([]string{inputDir}, nil, &stdout, &stderr)
+	assert.Equal(t, 2, exitCode)
+
+	exitCode = run([]string{"-format", "markdown", "-o", outputDir, inputDir}, nil, &stdout, &stderr)
+	assert.Equal(t, 0, exitCode, stderr.String())
+	assert.FileExists(t, fp.Join(outputDir, "a.md"))
+	assert.FileExists(t, fp.Join(outputDir, "sub", "b.md"))
+	assert.NoFileExists(t, fp.Join(outputDir, "notes.md"))
+}
+
+func Test_Cmd_MultiPageError(t *testing.T) {
+	// The second page is missing
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(strings.Replace(testPage, "</body>", `<a href="/article/2">Next page</a></body>`, 1)))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-format", "json", "-multipage", server.URL + "/article/1"}
+	exitCode := run(args, nil, &stdout, &stderr)
+	assert.Equal(t, 0, exitCode, stderr.String())
+	assert.Contains(t, stderr.String(), "warning: only 1 page(s) distilled")
+	assert.Contains(t, stderr.String(), server.URL+"/article/2")
+
+	// Content of the first page is still printed
+	var result jsonResult
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	assert.Contains(t, result.Text, "Lorem ipsum")
+	assert.Contains(t, result.Markdown, "Lorem ipsum")
+	assert.NotEmpty(t, result.ContentBlocks)
+
+	// Each page is listed along with its pagination
+	assert.Len(t, result.Pages, 1)
+	assert.Equal(t, server.URL+"/article/1", result.Pages[0].URL)
+	assert.Equal(t, server.URL+"/article/2", result.Pages[0].PaginationInfo.NextPage)
+}
+
+func Test_Cmd_Options(t *testing.T) {
+	cfg, _, err := parseFlags([]string{
+		"-sanitize", "none",
+		"-pagination-keywords", "next:suite, prev:retour,extraneous:imprimer",
+	}, &bytes.Buffer{})
+	assert.NoError(t, err)
+
+	opts, err := createOptions(cfg)
+	assert.NoError(t, err)
+	assert.True(t, opts.SkipSanitize)
+	assert.Equal(t, []string{"suite"}, opts.PaginationKeywords.Next)
+	assert.Equal(t, []string{"retour"}, opts.PaginationKeywords.Prev)
+	assert.Equal(t, []string{"imprimer"}, opts.PaginationKeywords.Extraneous)
+
+	// Strict sanitizer is used by default
+	cfg, _, err = parseFlags(nil, &bytes.Buffer{})
+	assert.NoError(t, err)
+
+	opts, err = createOptions(cfg)
+	assert.NoError(t, err)
+	assert.False(t, opts.SkipSanitize)
+	assert.NotNil(t, opts.Sanitizer)
+}
+
+func Test_Cmd_InvalidFlags(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run([]string{"-format", "pdf"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"-log", "everything"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"-pagination-algo", "magic"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"-markup-precedence", "rdfa"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"-image-width", "-1"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"-sanitize", "relaxed"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"-pagination-keywords", "suite"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"-pagination-keywords", "after:suite"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"a.html", "b.html"}, nil, &stdout, &stderr))
+	assert.Equal(t, 0, run([]string{"-h"}, nil, &stdout, &stderr))
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-shiori/dom"
+	distiller "github.com/markusmobius/go-domdistiller"
+	"github.com/markusmobius/go-domdistiller/data"
+)
+
+var formatExtensions = map[string]string{
+	"html":     ".html",
+	"text":     ".txt",
+	"markdown": ".md",
+	"json":     ".json",
+}
+
+// jsonResult is the JSON representation of distiller.Result. It's needed
+// because the distilled HTML node can't be encoded directly.
+type jsonResult struct {
+	URL            string              `json:"url"`
+	Title          string              `json:"title"`
+	Authors        []data.Author       `json:"authors,omitempty"`
+	PublishedTime  *data.ExtractedDate `json:"publishedTime,omitempty"`
+	ModifiedTime   *data.ExtractedDate `json:"modifiedTime,omitempty"`
+	MarkupInfo     data.MarkupInfo     `json:"markupInfo"`
+	StructuredData data.StructuredData `json:"structuredData"`
+	PaginationInfo data.PaginationInfo `json:"paginationInfo"`
+	WordCount      int                 `json:"wordCount"`
+	ContentImages  []string            `json:"contentImages,omitempty"`
+	Images         []data.ArticleImage `json:"images,omitempty"`
+	LeadImage      *data.LeadImage     `json:"leadImage,omitempty"`
+	TimingInfo     data.TimingInfo     `json:"timingInfo"`
+	Language       data.Language       `json:"language"`
+	Encoding       string              `json:"encoding,omitempty"`
+	HTML           string              `json:"html"`
+	Text           string              `json:"text"`
+	Markdown       string              `json:"markdown"`
+	ContentBlocks  []data.ContentBlock `json:"contentBlocks,omitempty"`
+
+	// Pages is the summary of each page in multipage mode.
+	Pages []jsonPage `json:"pages,omitempty"`
+}
+
+// jsonPage is the summary of a page that merged in multipage mode.
+type jsonPage struct {
+	URL            string              `json:"url"`
+	Title          string              `json:"title"`
+	WordCount      int                 `json:"wordCount"`
+	PaginationInfo data.PaginationInfo `json:"paginationInfo"`
+	TimingInfo     data.TimingInfo     `json:"timingInfo"`
+}
+
+// writeResult writes the result in the specified format. The pages are the result of
+// each page in multipage mode, which only written in JSON format.
+func writeResult(w io.Writer, format string, result *distiller.Result, pages []*distiller.Result) error {
+	var err error
+	switch format {
+	case "text":
+		_, err = fmt.Fprintln(w, result.Text)
+
+	case "markdown":
+		_, err = fmt.Fprintln(w, result.Markdown)
+
+	case "json":
+		var jsonPages []jsonPage
+		for _, page := range pages {
+			jsonPages = append(jsonPages, jsonPage{
+				URL:            page.URL,
+				Title:          page.Title,
+				WordCount:      page.WordCount,
+				PaginationInfo: page.PaginationInfo,
+				TimingInfo:     page.TimingInfo,
+			})
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(jsonResult{
+			URL:            result.URL,
+			Title:          result.Title,
+			Authors:        result.Authors,
+			PublishedTime:  result.PublishedTime,
+			ModifiedTime:   result.ModifiedTime,
+			MarkupInfo:     result.MarkupInfo,
+			StructuredData: result.StructuredData,
+			PaginationInfo: result.PaginationInfo,
+			WordCount:      result.WordCount,
+			ContentImages:  result.ContentImages,
+			Images:         result.Images,
+			LeadImage:      result.LeadImage,
+			TimingInfo:     result.TimingInfo,
+			Language:       result.Language,
+			Encoding:       result.Encoding,
+			HTML:           renderHTML(result),
+			Text:           result.Text,
+			Markdown:       result.Markdown,
+			ContentBlocks:  result.ContentBlocks,
+			Pages:          jsonPages,
+		})
+
+	default:
+		_, err = fmt.Fprintln(w, renderHTML(result))
+	}
+
+	return err
+}
+
+func renderHTML(result *distiller.Result) string {
+	if result.Node == nil {
+		return ""
+	}
+
+	return dom.InnerHTML(result.Node)
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package data
+
+// BlockType is the type of ContentBlock.
+type BlockType string
+
+const (
+	// ParagraphBlock is a block of text. Uses Text and HTML.
+	ParagraphBlock BlockType = "paragraph"
+
+	// HeadingBlock is a heading. Uses Text, HTML and Level (1-6).
+	HeadingBlock BlockType = "heading"
+
+	// ListBlock is an ordered or unordered list. Uses Ordered and Items.
+	ListBlock BlockType = "list"
+
+	// QuoteBlock is a block quotation. Uses Blocks.
+	QuoteBlock BlockType = "quote"
+
+	// CodeBlock is a preformatted text. Uses Text, which whitespaces are preserved.
+	CodeBlock BlockType = "code"
+
+	// TableBlock is a data table. Uses Rows.
+	TableBlock BlockType = "table"
+
+	// ImageBlock is a standalone image. Uses Image.
+	ImageBlock BlockType = "image"
+
+	// FigureBlock is an image with caption. Uses Image and Caption.
+	FigureBlock BlockType = "figure"
+
+	// EmbedBlock is an embedded content from other site, e.g. YouTube
+	// or Twitter. Uses Embed.
+	EmbedBlock BlockType = "embed"
+
+	// VideoBlock is a HTML5 video. Uses Video.
+	VideoBlock BlockType = "video"
+)
+
+// ContentBlock is a typed block of the distilled content. Which fields are
+// used depends on the block type, the unused fields are omitted in JSON.
+type ContentBlock struct {
+	Type BlockType `json:"type"`
+
+	// Text is the plain text of paragraph, heading and code block.
+	Text string `json:"text,omitempty"`
+
+	// HTML is the inner HTML of paragraph and heading, which keeps the inline
+	// formatting (e.g. links and emphasis) of the text.
+	HTML string `json:"html,omitempty"`
+
+	// Level is the level of heading, i.e. 1 for <h1> until 6 for <h6>.
+	Level int `json:"level,omitempty"`
+
+	// Ordered is true if the list is an ordered list.
+	Ordered bool `json:"ordered,omitempty"`
+
+	// Items is the items of the list.
+	Items []ListItem `json:"items,omitempty"`
+
+	// Blocks is the content of the quote.
+	Blocks []ContentBlock `json:"blocks,omitempty"`
+
+	// Rows is the rows of the table.
+	Rows []TableRow `json:"rows,omitempty"`
+
+	// Image is the image of image and figure block.
+	Image *ContentImage `json:"image,omitempty"`
+
+	// Caption is the caption of figure.
+	Caption string `json:"caption,omitempty"`
+
+	// Embed is the embedded content of embed block.
+	Embed *ContentEmbed `json:"embed,omitempty"`
+
+	// Video is the video of video block.
+	Video *ContentVideo `json:"video,omitempty"`
+}
+
+// ListItem is an item in list. Since list can be nested, each item
+// contains blocks which might be another list.
+type ListItem struct {
+	Blocks []ContentBlock `json:"blocks"`
+}
+
+// TableRow is a row in table.
+type TableRow struct {
+	Cells []TableCell `json:"cells"`
+}
+
+// TableCell is a cell in table row.
+type TableCell struct {
+	Text   string `json:"text"`
+	Header bool   `json:"header,omitempty"`
+}
+
+// ContentImage is an image in the distilled content.
+type ContentImage struct {
+	URL    string `json:"url"`
+	SrcSet string `json:"srcset,omitempty"`
+	Alt    string `json:"alt,omitempty"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// ImageCandidate is an image candidate that listed in srcset.
+type ImageCandidate struct {
+	URL string `json:"url"`
+
+	// Width is the width descriptor (e.g. "800w") of the candidate.
+	Width int `json:"width,omitempty"`
+
+	// Density is the pixel density descriptor (e.g. "2x") of the candidate.
+	Density float64 `json:"density,omitempty"`
+}
+
+// ArticleImage is an image in the distilled content, along with its metadata.
+type ArticleImage struct {
+	ContentImage
+
+	// Caption is the caption of the image if it's inside a figure.
+	Caption string `json:"caption,omitempty"`
+
+	// Candidates is the parsed srcset of the image.
+	Candidates []ImageCandidate `json:"candidates,omitempty"`
+
+	// OriginalURL is the original src of the image when URL is the candidate
+	// that picked by the responsive image resolver. Empty if not replaced.
+	OriginalURL string `json:"originalUrl,omitempty"`
+
+	// Position is the order of the image in the distilled content, starting from 0.
+	Position int `json:"position"`
+}
+
+// LeadImageSource is where the lead image is found.
+type LeadImageSource string
+
+const (
+	// LeadImageFromContent means the lead image is found in the page content.
+	LeadImageFromContent LeadImageSource = "content"
+
+	// LeadImageFromMarkup means the page content has no suitable image, so
+	// the lead image is taken from the metadata, e.g. og:image.
+	LeadImageFromMarkup LeadImageSource = "markup"
+)
+
+// LeadImage is the main image of an article, e.g. to be used as hero image.
+type LeadImage struct {
+	ContentImage
+
+	// Source is where the lead image is found.
+	Source LeadImageSource `json:"source"`
+
+	// MarkupSource is the metadata that contains the lead image. Only
+	// used when Source is LeadImageFromMarkup.
+	MarkupSource MarkupSource `json:"markupSource,omitempty"`
+
+	// Score is the total score of the image in lead image heuristics.
+	Score int `json:"score"`
+
+	// Scores is the score given by each heuristic, e.g. "dom-distance"
+	// and "has-figure". Empty if the image is taken from metadata.
+	Scores map[string]int `json:"scores,omitempty"`
+}
+
+// ContentEmbed is an embedded content from other site.
+type ContentEmbed struct {
+	Type   string            `json:"type"`
+	ID     string            `json:"id"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// ContentVideo is a HTML5 video in the distilled content.
+type ContentVideo struct {
+	Sources []VideoSource `json:"sources"`
+	Poster  string        `json:"poster,omitempty"`
+	Width   int           `json:"width,omitempty"`
+	Height  int           `json:"height,omitempty"`
+}
+
+// VideoSource is a source of video.
+type VideoSource struct {
+	URL  string `json:"url"`
+	Type string `json:"type,omitempty"`
+}
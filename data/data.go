@@ -22,39 +22,240 @@
 
 package data
 
+import "time"
+
 type PaginationInfo struct {
-	NextPage string
-	PrevPage string
+	NextPage string `json:"nextPage,omitempty"`
+	PrevPage string `json:"prevPage,omitempty"`
 }
 
 // MarkupArticle is object to contains the properties of an article document.
+// The times and authors are kept as they are written in the markup, while their
+// parsed and normalized version are put in the other fields.
 type MarkupArticle struct {
-	PublishedTime  string
-	ModifiedTime   string
-	ExpirationTime string
-	Section        string
-	Authors        []string
+	PublishedTime  string   `json:"publishedTime,omitempty"`
+	ModifiedTime   string   `json:"modifiedTime,omitempty"`
+	ExpirationTime string   `json:"expirationTime,omitempty"`
+	Section        string   `json:"section,omitempty"`
+	Authors        []string `json:"authors,omitempty"`
+
+	// Published, Modified and Expiration are the parsed PublishedTime, ModifiedTime
+	// and ExpirationTime. The time zone is kept as written, or UTC if it's not specified.
+	// Zero if the raw string is empty or can't be parsed.
+	Published  time.Time `json:"published"`
+	Modified   time.Time `json:"modified"`
+	Expiration time.Time `json:"expiration"`
+
+	// AuthorNames is the normalized Authors: the "By" prefix is removed, the entry with
+	// several names (e.g. "Jane Doe and John Smith") is splitted, the duplicates are
+	// removed, and so are the URLs or emails that used in place of the name.
+	AuthorNames []string `json:"authorNames,omitempty"`
 }
 
 // MarkupImage is used to contains the properties of an image in the document.
 type MarkupImage struct {
-	Root      string
-	URL       string
-	SecureURL string
-	Type      string
-	Caption   string
-	Width     int
-	Height    int
+	Root      string `json:"root,omitempty"`
+	URL       string `json:"url"`
+	SecureURL string `json:"secureUrl,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Caption   string `json:"caption,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+
+	// Source is the markup specification where the image is found.
+	Source MarkupSource `json:"source,omitempty"`
+}
+
+// MarkupTwitterCard is the properties of Twitter Card, i.e. the twitter:* meta tags.
+type MarkupTwitterCard struct {
+	Card        string `json:"card,omitempty"`
+	Site        string `json:"site,omitempty"`
+	Creator     string `json:"creator,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+	ImageAlt    string `json:"imageAlt,omitempty"`
+}
+
+// MarkupDublinCore is the properties of Dublin Core metadata, i.e. the DC.* and dcterms.*
+// meta tags. The dates are kept as it is, which usually in ISO 8601 format.
+type MarkupDublinCore struct {
+	Title        string   `json:"title,omitempty"`
+	Creators     []string `json:"creators,omitempty"`
+	Contributors []string `json:"contributors,omitempty"`
+	Subject      string   `json:"subject,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Publisher    string   `json:"publisher,omitempty"`
+	Date         string   `json:"date,omitempty"`
+	Modified     string   `json:"modified,omitempty"`
+	Type         string   `json:"type,omitempty"`
+	Format       string   `json:"format,omitempty"`
+	Identifier   string   `json:"identifier,omitempty"`
+	Source       string   `json:"source,omitempty"`
+	Language     string   `json:"language,omitempty"`
+	Rights       string   `json:"rights,omitempty"`
+}
+
+// MarkupCitation is the bibliographic properties of scholarly article from the Highwire
+// Press citation_* meta tags, which also used by Google Scholar.
+type MarkupCitation struct {
+	Title           string   `json:"title,omitempty"`
+	Authors         []string `json:"authors,omitempty"`
+	JournalTitle    string   `json:"journalTitle,omitempty"`
+	Publisher       string   `json:"publisher,omitempty"`
+	PublicationDate string   `json:"publicationDate,omitempty"`
+	OnlineDate      string   `json:"onlineDate,omitempty"`
+	DOI             string   `json:"doi,omitempty"`
+	PDFURL          string   `json:"pdfUrl,omitempty"`
+	AbstractURL     string   `json:"abstractUrl,omitempty"`
+	Volume          string   `json:"volume,omitempty"`
+	Issue           string   `json:"issue,omitempty"`
+	FirstPage       string   `json:"firstPage,omitempty"`
+	LastPage        string   `json:"lastPage,omitempty"`
+	ISSN            string   `json:"issn,omitempty"`
+	ISBN            string   `json:"isbn,omitempty"`
+	Keywords        []string `json:"keywords,omitempty"`
+	Language        string   `json:"language,omitempty"`
 }
 
 type MarkupInfo struct {
-	Title       string
-	Type        string
-	URL         string
-	Description string
-	Publisher   string
-	Copyright   string
-	Author      string
-	Article     MarkupArticle
-	Images      []MarkupImage
+	Title       string            `json:"title,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Publisher   string            `json:"publisher,omitempty"`
+	Copyright   string            `json:"copyright,omitempty"`
+	Author      string            `json:"author,omitempty"`
+	Article     MarkupArticle     `json:"article"`
+	Images      []MarkupImage     `json:"images,omitempty"`
+	TwitterCard MarkupTwitterCard `json:"twitterCard"`
+	DublinCore  MarkupDublinCore  `json:"dublinCore"`
+	Citation    MarkupCitation    `json:"citation"`
+
+	// Sources is the markup specification that supplied each non-empty field.
+	Sources map[MarkupField]MarkupSource `json:"sources,omitempty"`
+
+	// Candidates is all non-empty values that found for each field, ordered by the
+	// precedence of their source. The first candidate is the one that used in the
+	// field. Article authors are not included since they are a list.
+	Candidates map[MarkupField][]MarkupCandidate `json:"candidates,omitempty"`
+}
+
+// MarkupSource is the markup specification where a metadata is found.
+type MarkupSource string
+
+const (
+	MarkupFromOpenGraph   MarkupSource = "opengraph"
+	MarkupFromSchemaOrg   MarkupSource = "schema.org"
+	MarkupFromJSONLD      MarkupSource = "json-ld"
+	MarkupFromTwitterCard MarkupSource = "twitter-card"
+	MarkupFromDublinCore  MarkupSource = "dublin-core"
+	MarkupFromHighwire    MarkupSource = "highwire"
+	MarkupFromIEReader    MarkupSource = "ie-reader"
+
+	// MarkupFromSiteRule means the value is taken from the site rule's selector,
+	// which overrides the value from the markup.
+	MarkupFromSiteRule MarkupSource = "site-rule"
+)
+
+// MarkupField is the name of field in MarkupInfo, used as key in its Sources and Candidates.
+type MarkupField string
+
+const (
+	MarkupFieldTitle          MarkupField = "title"
+	MarkupFieldType           MarkupField = "type"
+	MarkupFieldURL            MarkupField = "url"
+	MarkupFieldDescription    MarkupField = "description"
+	MarkupFieldPublisher      MarkupField = "publisher"
+	MarkupFieldCopyright      MarkupField = "copyright"
+	MarkupFieldAuthor         MarkupField = "author"
+	MarkupFieldPublishedTime  MarkupField = "article.published_time"
+	MarkupFieldModifiedTime   MarkupField = "article.modified_time"
+	MarkupFieldExpirationTime MarkupField = "article.expiration_time"
+	MarkupFieldSection        MarkupField = "article.section"
+	MarkupFieldAuthors        MarkupField = "article.authors"
+)
+
+// MarkupCandidate is a value of MarkupInfo's field along with its source.
+type MarkupCandidate struct {
+	Value  string       `json:"value"`
+	Source MarkupSource `json:"source"`
+}
+
+// Author is the author of the document.
+type Author struct {
+	// Name is the normalized name of the author.
+	Name string `json:"name"`
+
+	// URL is the absolute URL of author's profile page. Might be empty.
+	URL string `json:"url,omitempty"`
+}
+
+// DateSource is the source where a date is found.
+type DateSource string
+
+const (
+	// DateFromSiteRule means the date is taken from the site rule's date selector.
+	DateFromSiteRule DateSource = "site-rule"
+
+	// DateFromMarkup means the date is taken from the metadata, i.e. OpenGraph,
+	// schema.org microdata, JSON-LD, Dublin Core, Highwire citation or IE reader.
+	DateFromMarkup DateSource = "markup"
+
+	// DateFromMeta means the date is taken from other <meta> tags, e.g. "pubdate".
+	DateFromMeta DateSource = "meta"
+
+	// DateFromTimeElement means the date is taken from <time datetime="..."> element.
+	DateFromTimeElement DateSource = "time-element"
+
+	// DateFromDateline means the date is found in the text of a dateline element.
+	DateFromDateline DateSource = "dateline"
+
+	// DateFromURL means the date is taken from the URL path, e.g. "/2023/05/14/slug".
+	DateFromURL DateSource = "url"
+)
+
+// ExtractedDate is a date that extracted from the document.
+type ExtractedDate struct {
+	// Time is the parsed date. If the source doesn't specify time zone, it's in UTC.
+	Time time.Time `json:"time"`
+
+	// Source is where the date is found.
+	Source DateSource `json:"source"`
+
+	// Raw is the original string where the date is parsed from.
+	Raw string `json:"raw,omitempty"`
+}
+
+// LanguageSource is the source where the language of a document is found.
+type LanguageSource string
+
+const (
+	// LanguageFromOption means the language is specified by user in the options.
+	LanguageFromOption LanguageSource = "option"
+
+	// LanguageFromHTML means the language is taken from lang attribute in <html> element.
+	LanguageFromHTML LanguageSource = "html-lang"
+
+	// LanguageFromHeader means the language is taken from Content-Language, either
+	// from the HTTP header or from <meta http-equiv="Content-Language">.
+	LanguageFromHeader LanguageSource = "content-language"
+
+	// LanguageFromOpenGraph means the language is taken from og:locale meta tag.
+	LanguageFromOpenGraph LanguageSource = "og-locale"
+
+	// LanguageFromText means the language is detected from the text of the document.
+	LanguageFromText LanguageSource = "text"
+)
+
+// Language is the language of a document.
+type Language struct {
+	// Tag is the language as BCP-47 tag, e.g. "en" or "pt-BR".
+	Tag string `json:"tag"`
+
+	// Direction is the text direction of the language, either "ltr" or "rtl".
+	Direction string `json:"direction"`
+
+	// Source is where the language is found.
+	Source LanguageSource `json:"source"`
 }
@@ -0,0 +1,178 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package data
+
+// StructuredData is the typed schema.org items found in the page, either declared using
+// microdata or JSON-LD. Only the top-level items are listed here, e.g. the offers of a
+// product are put inside the product instead of in their own list.
+type StructuredData struct {
+	Videos   []VideoObject `json:"videos,omitempty"`
+	Audios   []AudioObject `json:"audios,omitempty"`
+	Recipes  []Recipe      `json:"recipes,omitempty"`
+	Events   []Event       `json:"events,omitempty"`
+	Products []Product     `json:"products,omitempty"`
+	Reviews  []Review      `json:"reviews,omitempty"`
+	FAQs     []FAQPage     `json:"faqs,omitempty"`
+	HowTos   []HowTo       `json:"howTos,omitempty"`
+}
+
+// IsEmpty returns true if there are no structured items.
+func (sd StructuredData) IsEmpty() bool {
+	return len(sd.Videos) == 0 && len(sd.Audios) == 0 && len(sd.Recipes) == 0 &&
+		len(sd.Events) == 0 && len(sd.Products) == 0 && len(sd.Reviews) == 0 &&
+		len(sd.FAQs) == 0 && len(sd.HowTos) == 0
+}
+
+// VideoObject is a schema.org VideoObject. Duration is in ISO 8601 format, e.g. "PT1M33S".
+type VideoObject struct {
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+	ContentURL   string `json:"contentUrl,omitempty"`
+	EmbedURL     string `json:"embedUrl,omitempty"`
+	UploadDate   string `json:"uploadDate,omitempty"`
+	Duration     string `json:"duration,omitempty"`
+}
+
+// AudioObject is a schema.org AudioObject, e.g. podcast episode.
+type AudioObject struct {
+	Name           string `json:"name,omitempty"`
+	Description    string `json:"description,omitempty"`
+	ContentURL     string `json:"contentUrl,omitempty"`
+	EmbedURL       string `json:"embedUrl,omitempty"`
+	EncodingFormat string `json:"encodingFormat,omitempty"`
+	UploadDate     string `json:"uploadDate,omitempty"`
+	Duration       string `json:"duration,omitempty"`
+}
+
+// Recipe is a schema.org Recipe. The times are ISO 8601 durations, e.g. "PT30M".
+type Recipe struct {
+	Name         string   `json:"name,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Image        string   `json:"image,omitempty"`
+	Author       string   `json:"author,omitempty"`
+	PublishedAt  string   `json:"datePublished,omitempty"`
+	PrepTime     string   `json:"prepTime,omitempty"`
+	CookTime     string   `json:"cookTime,omitempty"`
+	TotalTime    string   `json:"totalTime,omitempty"`
+	Yield        string   `json:"recipeYield,omitempty"`
+	Category     string   `json:"recipeCategory,omitempty"`
+	Cuisine      string   `json:"recipeCuisine,omitempty"`
+	Calories     string   `json:"calories,omitempty"`
+	Ingredients  []string `json:"recipeIngredient,omitempty"`
+	Instructions []string `json:"recipeInstructions,omitempty"`
+	Rating       *Rating  `json:"aggregateRating,omitempty"`
+}
+
+// Event is a schema.org Event, including its subtypes like MusicEvent.
+type Event struct {
+	Name           string   `json:"name,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	URL            string   `json:"url,omitempty"`
+	StartDate      string   `json:"startDate,omitempty"`
+	EndDate        string   `json:"endDate,omitempty"`
+	Status         string   `json:"eventStatus,omitempty"`
+	AttendanceMode string   `json:"eventAttendanceMode,omitempty"`
+	Location       string   `json:"location,omitempty"`
+	Address        string   `json:"address,omitempty"`
+	Organizer      string   `json:"organizer,omitempty"`
+	Performers     []string `json:"performer,omitempty"`
+	Offers         []Offer  `json:"offers,omitempty"`
+}
+
+// Product is a schema.org Product.
+type Product struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Image       string   `json:"image,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Brand       string   `json:"brand,omitempty"`
+	SKU         string   `json:"sku,omitempty"`
+	GTIN        string   `json:"gtin,omitempty"`
+	MPN         string   `json:"mpn,omitempty"`
+	Offers      []Offer  `json:"offers,omitempty"`
+	Rating      *Rating  `json:"aggregateRating,omitempty"`
+	Reviews     []Review `json:"reviews,omitempty"`
+}
+
+// Offer is a schema.org Offer or AggregateOffer. For AggregateOffer, Price is the
+// lowest price while HighPrice is the highest one.
+type Offer struct {
+	Price         string `json:"price,omitempty"`
+	HighPrice     string `json:"highPrice,omitempty"`
+	PriceCurrency string `json:"priceCurrency,omitempty"`
+	Availability  string `json:"availability,omitempty"`
+	URL           string `json:"url,omitempty"`
+	Seller        string `json:"seller,omitempty"`
+	ValidFrom     string `json:"validFrom,omitempty"`
+}
+
+// Review is a schema.org Review.
+type Review struct {
+	Name         string  `json:"name,omitempty"`
+	Author       string  `json:"author,omitempty"`
+	PublishedAt  string  `json:"datePublished,omitempty"`
+	Body         string  `json:"reviewBody,omitempty"`
+	ItemReviewed string  `json:"itemReviewed,omitempty"`
+	Rating       *Rating `json:"reviewRating,omitempty"`
+}
+
+// Rating is a schema.org Rating or AggregateRating. The counts are only
+// available in AggregateRating.
+type Rating struct {
+	Value       string `json:"ratingValue,omitempty"`
+	Best        string `json:"bestRating,omitempty"`
+	Worst       string `json:"worstRating,omitempty"`
+	RatingCount string `json:"ratingCount,omitempty"`
+	ReviewCount string `json:"reviewCount,omitempty"`
+}
+
+// FAQPage is a schema.org FAQPage.
+type FAQPage struct {
+	Name      string     `json:"name,omitempty"`
+	Questions []Question `json:"questions,omitempty"`
+}
+
+// Question is a question in FAQPage, along with its accepted answer.
+type Question struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer,omitempty"`
+}
+
+// HowTo is a schema.org HowTo.
+type HowTo struct {
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Image       string      `json:"image,omitempty"`
+	TotalTime   string      `json:"totalTime,omitempty"`
+	Supplies    []string    `json:"supply,omitempty"`
+	Tools       []string    `json:"tool,omitempty"`
+	Steps       []HowToStep `json:"steps,omitempty"`
+}
+
+// HowToStep is a step in HowTo.
+type HowToStep struct {
+	Name  string `json:"name,omitempty"`
+	Text  string `json:"text,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Image string `json:"image,omitempty"`
+}
@@ -25,20 +25,20 @@ package data
 import "time"
 
 type TimingEntry struct {
-	Name string
-	Time time.Duration
+	Name string        `json:"name"`
+	Time time.Duration `json:"time"`
 }
 
 type TimingInfo struct {
-	MarkupParsingTime        time.Duration
-	DocumentConstructionTime time.Duration
-	ArticleProcessingTime    time.Duration
-	FormattingTime           time.Duration
-	TotalTime                time.Duration
+	MarkupParsingTime        time.Duration `json:"markupParsingTime"`
+	DocumentConstructionTime time.Duration `json:"documentConstructionTime"`
+	ArticleProcessingTime    time.Duration `json:"articleProcessingTime"`
+	FormattingTime           time.Duration `json:"formattingTime"`
+	TotalTime                time.Duration `json:"totalTime"`
 
 	// A place to hold arbitrary breakdowns of time. The perf scoring/server
 	// should display these entries with appropriate names.
-	OtherTimes []TimingEntry
+	OtherTimes []TimingEntry `json:"otherTimes,omitempty"`
 }
 
 func (ti *TimingInfo) AddEntry(start time.Time, name string) {
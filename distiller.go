@@ -21,19 +21,24 @@
 package distiller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	nurl "net/url"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/go-shiori/dom"
 	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/charset"
 	"github.com/markusmobius/go-domdistiller/internal/extractor"
 	"github.com/markusmobius/go-domdistiller/internal/pagination"
+	"github.com/markusmobius/go-domdistiller/pipeline"
+	"github.com/markusmobius/go-domdistiller/sanitize"
+	"github.com/markusmobius/go-domdistiller/siterule"
+	"github.com/markusmobius/go-domdistiller/srcset"
 	"golang.org/x/net/html"
 )
 
@@ -61,12 +66,29 @@ type Result struct {
 	// Title is the title of the processed page.
 	Title string
 
-	// MarkupInfo is the metadata of the page. The metadata is extracted following three markup
-	// specifications: OpenGraphProtocol, IEReadingView and SchemaOrg. For now, OpenGraph protocol
-	// takes precedence because it uses specific meta tags and hence the fastest. The other
-	// specifications is used as fallback in case some metadata not found.
+	// Authors is the normalized list of authors of the page. It's taken from the metadata,
+	// or from the bylines (e.g. "By Jane Doe") in the page if the metadata has no author.
+	Authors []data.Author
+
+	// PublishedTime is the publication date of the page, along with where it's found. It's
+	// taken from the metadata, <meta> tags, <time> elements and datelines near the title,
+	// or the URL path, whichever is the most reliable. Nil if the date is not found.
+	PublishedTime *data.ExtractedDate
+
+	// ModifiedTime is the last modification date of the page. Nil if the date is not found.
+	ModifiedTime *data.ExtractedDate
+
+	// MarkupInfo is the metadata of the page. The metadata is extracted following seven markup
+	// specifications: OpenGraphProtocol, SchemaOrg microdata, JSON-LD, Twitter Card, Dublin Core,
+	// Highwire citation and IEReadingView. By default, OpenGraph protocol takes precedence because
+	// it uses specific meta tags and hence the fastest. The other specifications is used as fallback
+	// in case some metadata not found. The source of each field is recorded in MarkupInfo.Sources.
 	MarkupInfo data.MarkupInfo
 
+	// StructuredData is the typed schema.org items in the page, e.g. videos, recipes, events,
+	// products, reviews, FAQs and how-tos. They are taken from both microdata and JSON-LD.
+	StructuredData data.StructuredData
+
 	// TimingInfo is the record of the time it takes to do each step in the process of content extraction.
 	TimingInfo data.TimingInfo
 
@@ -83,8 +105,40 @@ type Result struct {
 	// Text is the string which contains the distilled content in text format.
 	Text string
 
+	// Markdown is the string which contains the distilled content in CommonMark format.
+	// Tables are rendered using GitHub Flavored Markdown syntax. URLs of links and images
+	// are checked using the same policy as Result.Node.
+	Markdown string
+
+	// ContentBlocks is the distilled content as a tree of typed blocks, e.g. paragraph,
+	// heading, list, table, figure and embed. It can be marshaled into JSON directly.
+	ContentBlocks []data.ContentBlock
+
 	// ContentImages is list of image URLs that used within the distilled content.
 	ContentImages []string
+
+	// Images is the images within the distilled content along with their metadata, e.g.
+	// dimensions, alt text, caption, srcset candidates and their position in the content.
+	Images []data.ArticleImage
+
+	// LeadImage is the main image of the article, which is useful as hero image. It's
+	// taken from the content if possible, or from the metadata (e.g. og:image) if the
+	// content has no suitable image. Nil if the page has no image at all.
+	LeadImage *data.LeadImage
+
+	// Language is the language of the distilled content as BCP-47 tag, along with its text
+	// direction. It's taken from Options.Language, <html lang>, Content-Language or og:locale,
+	// unless it's contradicted by the language that detected from the paragraphs in the page.
+	// That detection runs before the content is extracted since the language-specific filters
+	// need it, then the result is checked again against the distilled Text. Since the detector
+	// only knows a few languages for each script, a declared language that the detector doesn't
+	// know (e.g. Bulgarian or Urdu) is only overridden when the text is in a different script.
+	Language data.Language
+
+	// Encoding is the name of character encoding of the original page, e.g. "utf-8" or
+	// "shift_jis". It's detected from the BOM, the Content-Type header and the charset
+	// in <meta> tags. Empty if the page is given as parsed document in Apply.
+	Encoding string
 }
 
 // Options is configuration for the distiller.
@@ -93,7 +147,8 @@ type Options struct {
 	LogFlags LogFlag
 
 	// Original URL of the page, which is used in the heuristics in detecting
-	// next/prev page links. Will be ignored if Option is used in ApplyForURL.
+	// next/prev page links. Will be ignored if Option is used in ApplyForURL,
+	// in which case it will be set to the final URL after following redirects.
 	OriginalURL *nurl.URL
 
 	// Set to true to skip process for finding pagination.
@@ -101,41 +156,149 @@ type Options struct {
 
 	// Algorithm to use for next page detection.
 	PaginationAlgo PaginationAlgo
+
+	// PaginationKeywords is the extra terms for recognizing the next and previous page
+	// links in PrevNext algorithm. They are used in addition to the built-in keywords
+	// for the page's language.
+	PaginationKeywords PaginationKeywords
+
+	// Fetcher is used by ApplyForURL to download the web page. If nil, the page will
+	// be downloaded using http.Client with the timeout that specified in ApplyForURL.
+	Fetcher Fetcher
+
+	// AcceptedContentTypes is the list of media types that accepted by ApplyForURL.
+	// If empty, DefaultAcceptedContentTypes will be used.
+	AcceptedContentTypes []string
+
+	// MaxResponseSize is the maximum size in bytes of the page that downloaded by
+	// ApplyForURL. If the page is bigger, ErrResponseTooLarge will be returned.
+	// Zero or negative means no limit.
+	MaxResponseSize int64
+
+	// MaxPages is the maximum number of pages that followed by ApplyForURLMultiPage.
+	// If zero or negative, DefaultMaxPages will be used.
+	MaxPages int
+
+	// Pipeline is the chain of filters that used to decide which part of the page is
+	// the main content. If nil, pipeline.Default will be used.
+	Pipeline *pipeline.Pipeline
+
+	// DocumentPipeline is the chain of filters that processes the document after the main
+	// content is decided, e.g. to remove junk images and find the lead image. If nil,
+	// pipeline.DefaultDocument will be used.
+	DocumentPipeline *pipeline.DocumentPipeline
+
+	// SiteRules is the collection of extraction rules keyed by host pattern. The rule
+	// that matched with the host of OriginalURL is consulted before the heuristics.
+	SiteRules siterule.Rules
+
+	// Language is the BCP-47 tag of the page's language, e.g. "en" or "pt-BR". It's used to
+	// choose the language-specific heuristics. If empty, it will be detected from the page.
+	Language string
+
+	// MarkupPrecedence is the order of markup specifications that consulted when extracting
+	// MarkupInfo, e.g. []data.MarkupSource{data.MarkupFromJSONLD}. The specifications that not
+	// listed are consulted afterward in the default order: OpenGraph, schema.org, JSON-LD,
+	// Twitter Card, Dublin Core, Highwire and IE reader.
+	MarkupPrecedence []data.MarkupSource
+
+	// Sanitizer is the policy that used to remove unsafe elements, attributes and URLs from
+	// Result.Node and the HTML in Result.ContentBlocks. If nil, sanitize.Strict() is used.
+	Sanitizer *sanitize.Policy
+
+	// SkipSanitize disables the sanitizer, so Result.Node, Result.Markdown and the HTML in
+	// Result.ContentBlocks are left as generated by the distiller. Only use it when the
+	// output is not rendered as it is, e.g. for debugging a bad extraction.
+	SkipSanitize bool
+
+	// ImageResolver picks the best image from srcset and <picture> sources, then uses it as
+	// the src of the image, e.g. for e-reader that doesn't support responsive images. The
+	// original src is reported in Result.Images. If nil, the src is kept as it is.
+	ImageResolver *srcset.Resolver
+
+	// ImageBlocklist is the list of strings (e.g. host or path like "ads.example.com" and
+	// "/promo/") that, when found in the image URL, makes the image removed from the content.
+	// It's used in addition to the built-in heuristics that remove tracking pixels, icons,
+	// avatars, emoji and ads.
+	ImageBlocklist []string
 }
 
-// ApplyForURL runs distiller for the specified URL.
+// PaginationKeywords is the vocabulary that used to recognize the pagination links,
+// e.g. "next" and "suivant" for next page link, or "print" for the unrelated link.
+type PaginationKeywords = pagination.Keywords
+
+// CancelledError is returned by the context-aware functions (e.g. ApplyContext) when the
+// context is cancelled or its deadline is exceeded before the distillation is finished.
+// It wraps the context error, so errors.Is(err, context.Canceled) works as expected.
+type CancelledError = extractor.CancelledError
+
+// ApplyForURL runs distiller for the specified URL. The timeout is only used
+// when Options.Fetcher is nil.
 func ApplyForURL(url string, timeout time.Duration, opts *Options) (*Result, error) {
+	if opts == nil || opts.Fetcher == nil {
+		fetcher := &HTTPFetcher{Client: &http.Client{Timeout: timeout}}
+		return applyForURL(context.Background(), fetcher, url, opts)
+	}
+
+	return applyForURL(context.Background(), opts.Fetcher, url, opts)
+}
+
+// ApplyForURLContext runs distiller for the specified URL. The context is used to
+// cancel both the page download and the distillation process.
+func ApplyForURLContext(ctx context.Context, url string, opts *Options) (*Result, error) {
+	if opts == nil || opts.Fetcher == nil {
+		return applyForURL(ctx, &HTTPFetcher{}, url, opts)
+	}
+
+	return applyForURL(ctx, opts.Fetcher, url, opts)
+}
+
+func applyForURL(ctx context.Context, fetcher Fetcher, url string, opts *Options) (*Result, error) {
 	// Make sure URL absolute
 	parsedURL, err := nurl.ParseRequestURI(url)
 	if err != nil {
 		return nil, err
 	}
 
+	// Create default options
+	if opts == nil {
+		opts = &Options{}
+	}
+
 	// Fetch page from URL
-	client := &http.Client{Timeout: timeout}
-	resp, err := client.Get(url)
+	resp, err := fetcher.Fetch(ctx, url)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, &CancelledError{Stage: extractor.StageFetching, Err: ctxErr}
+		}
 		return nil, fmt.Errorf("failed to fetch the page: %v", err)
 	}
 	defer resp.Body.Close()
 
 	// Make sure content type is HTML
 	cp := resp.Header.Get("Content-Type")
-	if !strings.Contains(cp, "text/html") {
+	if !isAcceptedContentType(cp, opts.AcceptedContentTypes) {
 		return nil, fmt.Errorf("URL is not a HTML document")
 	}
 
-	// Apply distiller to response body
-	if opts == nil {
-		opts = &Options{}
+	// If there are redirects, use the final URL as the page URL
+	if resp.Request != nil && resp.Request.URL != nil {
+		parsedURL = resp.Request.URL
 	}
 
+	// Apply distiller to response body
 	opts.OriginalURL = parsedURL
-	return ApplyForReader(resp.Body, opts)
+	body := newLimitedReader(resp.Body, opts.MaxResponseSize)
+	return applyForReader(ctx, body, cp, resp.Header.Get("Content-Language"), opts)
 }
 
 // ApplyForFile runs distiller for the specified file.
 func ApplyForFile(path string, opts *Options) (*Result, error) {
+	return ApplyForFileContext(context.Background(), path, opts)
+}
+
+// ApplyForFileContext runs distiller for the specified file with cancellation support.
+func ApplyForFileContext(ctx context.Context, path string, opts *Options) (*Result, error) {
 	// Open file
 	f, err := os.Open(path)
 	if err != nil {
@@ -144,23 +307,69 @@ func ApplyForFile(path string, opts *Options) (*Result, error) {
 	defer f.Close()
 
 	// Apply distiller to file
-	return ApplyForReader(f, opts)
+	return ApplyForReaderContext(ctx, f, opts)
 }
 
-// Apply runs distiller for the specified io.Reader.
+// ApplyForReader runs distiller for the specified io.Reader.
 func ApplyForReader(r io.Reader, opts *Options) (*Result, error) {
+	return ApplyForReaderContext(context.Background(), r, opts)
+}
+
+// ApplyForReaderContext runs distiller for the specified io.Reader with cancellation support.
+// The context is checked on every read from r, so a slow input can be aborted while it's
+// parsed. However, the parsing of data that already read can't be interrupted, so the
+// cancellation is only noticed once the parser asks for more data or finishes.
+func ApplyForReaderContext(ctx context.Context, r io.Reader, opts *Options) (*Result, error) {
+	return applyForReader(ctx, r, "", "", opts)
+}
+
+func applyForReader(ctx context.Context, r io.Reader, contentType, contentLanguage string, opts *Options) (*Result, error) {
+	// Stop reading the input once the context is done
+	r = extractor.NewContextReader(ctx, r)
+
+	// Convert input to UTF-8
+	r, encoding, err := charset.NewReader(r, contentType)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, &CancelledError{Stage: extractor.StageHTMLParsing, Err: ctxErr}
+		}
+		return nil, fmt.Errorf("failed to detect encoding: %w", err)
+	}
+
 	// Parse input
 	doc, err := dom.Parse(r)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, &CancelledError{Stage: extractor.StageHTMLParsing, Err: ctxErr}
+		}
+		return nil, err
+	}
+
+	// Apply distiller to doc. The context is checked again right
+	// away, in case it's done while the last chunk is parsed.
+	result, err := applyContext(ctx, doc, contentLanguage, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// Apply distiller to doc
-	return Apply(doc, opts)
+	result.Encoding = encoding
+	return result, nil
 }
 
 // Apply runs distiller for the specified parsed document.
 func Apply(doc *html.Node, opts *Options) (*Result, error) {
+	return ApplyContext(context.Background(), doc, opts)
+}
+
+// ApplyContext runs distiller for the specified parsed document. The context is checked
+// between each major stage of distillation: markup parsing, DOM conversion, article
+// extraction, document filtering and pagination. If the context is done, the process
+// is stopped and CancelledError is returned.
+func ApplyContext(ctx context.Context, doc *html.Node, opts *Options) (*Result, error) {
+	return applyContext(ctx, doc, "", opts)
+}
+
+func applyContext(ctx context.Context, doc *html.Node, contentLanguage string, opts *Options) (*Result, error) {
 	// Mark the start time
 	distillerStart := time.Now()
 
@@ -181,27 +390,70 @@ func Apply(doc *html.Node, opts *Options) (*Result, error) {
 	logger := newDistillerLogger(opts.LogFlags)
 
 	// Start extractor
+	if err := extractor.CheckContext(ctx, extractor.StageMarkupParsing); err != nil {
+		return nil, err
+	}
+
 	ce := extractor.NewContentExtractor(doc, opts.OriginalURL, logger)
-	extractedDocument, wordCount := ce.ExtractContent()
+	ce.Pipeline = opts.Pipeline
+	ce.DocumentPipeline = opts.DocumentPipeline
+	ce.Parser.SetPrecedence(opts.MarkupPrecedence)
+	ce.ImageResolver = opts.ImageResolver
+	ce.ImageBlocklist = opts.ImageBlocklist
+	ce.Language = opts.Language
+	ce.ContentLanguage = contentLanguage
+	if opts.OriginalURL != nil {
+		ce.SiteRule = opts.SiteRules.Match(opts.OriginalURL.Hostname())
+	}
+	extractedDocument, wordCount, err := ce.ExtractContentContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate output
 	start := time.Now()
 	extractedText := extractedDocument.GenerateOutput(true)
 	extractedHTML := extractedDocument.GenerateOutput(false)
+	// The sanitizer is used for Markdown as well, since it's generated directly from document
+	sanitizer := opts.Sanitizer
+	if sanitizer == nil {
+		sanitizer = sanitize.Strict()
+	}
+
+	isAllowedURL := sanitizer.URLFilter()
+	if opts.SkipSanitize {
+		isAllowedURL = func(string, bool) bool { return true }
+	}
+
+	extractedMarkdown := extractedDocument.GenerateMarkdown(isAllowedURL)
+	extractedBlocks := extractedDocument.GenerateBlocks()
 	ce.TimingInfo.FormattingTime = time.Now().Sub(start)
 
-	// Convert generated html string into node
+	// Convert generated html string into node, then sanitize it
+
 	container := dom.CreateElement("div")
 	dom.SetInnerHTML(container, extractedHTML)
+	if !opts.SkipSanitize {
+		sanitizer.SanitizeNode(container)
+		sanitizer.SanitizeBlocks(extractedBlocks)
+	}
 
 	// Prepare result
 	result := Result{}
 	result.Node = container
 	result.Text = extractedText
+	result.Markdown = extractedMarkdown
+	result.ContentBlocks = extractedBlocks
 	result.WordCount = wordCount
 	result.Title = ce.ExtractTitle()
+	result.Authors = ce.ExtractAuthors()
+	result.PublishedTime, result.ModifiedTime = ce.ExtractDates()
 	result.ContentImages = ce.ImageURLs
-	result.MarkupInfo = ce.Parser.MarkupInfo()
+	result.Images = ce.Images
+	result.LeadImage = ce.ExtractLeadImage()
+	result.MarkupInfo = ce.MarkupInfo()
+	result.StructuredData = ce.Parser.StructuredData()
+	result.Language = ce.ExtractContentLanguage(result.Text)
 
 	if opts.OriginalURL != nil {
 		result.URL = opts.OriginalURL.String()
@@ -210,6 +462,10 @@ func Apply(doc *html.Node, opts *Options) (*Result, error) {
 	// Find pagination
 	timingInfo := ce.TimingInfo
 	if !opts.SkipPagination && opts.OriginalURL != nil {
+		if err := extractor.CheckContext(ctx, extractor.StagePagination); err != nil {
+			return nil, err
+		}
+
 		paginationStart := time.Now()
 
 		if opts.PaginationAlgo == PageNumber {
@@ -218,7 +474,8 @@ func Apply(doc *html.Node, opts *Options) (*Result, error) {
 			logger.PrintPaginationInfo("Paging by PageNum, prev: " + result.PaginationInfo.PrevPage)
 			logger.PrintPaginationInfo("Paging by PageNum, next: " + result.PaginationInfo.NextPage)
 		} else {
-			finder := pagination.NewPrevNextFinder(logger)
+			keywords := pagination.KeywordsForLanguage(result.Language.Tag).Merge(opts.PaginationKeywords)
+			finder := pagination.NewPrevNextFinder(logger, keywords)
 			result.PaginationInfo = finder.FindPagination(doc, opts.OriginalURL)
 			logger.PrintPaginationInfo("Paging by PrevNext, prev: " + result.PaginationInfo.PrevPage)
 			logger.PrintPaginationInfo("Paging by PrevNext, next: " + result.PaginationInfo.NextPage)
@@ -0,0 +1,352 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package distiller_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	distiller "github.com/markusmobius/go-domdistiller"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/pipeline"
+	"github.com/markusmobius/go-domdistiller/sanitize"
+	"github.com/markusmobius/go-domdistiller/srcset"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Distiller_CustomPipeline(t *testing.T) {
+	page := strings.Replace(testPage, "<body>", "<body><div>"+
+		`<a href="/home">Home</a> <a href="/news">News</a> <a href="/about">About us</a>`+
+		"</div>", 1)
+
+	// Default pipeline drops the navigation
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+	assert.NotContains(t, result.Text, "About us")
+
+	// Explicitly using the default pipeline gives the same result
+	defaultResult, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+		Pipeline:       pipeline.Default(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, result.Text, defaultResult.Text)
+
+	// Custom pipeline that keeps every block
+	keepAll := pipeline.FilterFunc(func(doc *pipeline.TextDocument) bool {
+		for _, tb := range doc.TextBlocks {
+			tb.SetIsContent(true)
+		}
+		return true
+	})
+
+	result, err = distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+		Pipeline:       pipeline.New(pipeline.NewStage("KeepAll", keepAll)),
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, result.Text, "About us")
+}
+
+func Test_Distiller_Sanitize(t *testing.T) {
+	page := strings.Replace(testPage, "<p>Lorem ipsum", `<p><a href="JavaScript:alert(1)"><b>Click</b></a> `+
+		`<a href="https://example.com/about">About</a> Lorem ipsum`, 1)
+
+	// Strict policy is used by default
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+
+	output := dom.InnerHTML(result.Node)
+	assert.NotContains(t, output, "javascript:")
+	assert.Contains(t, output, `<a href="https://example.com/about" rel="noopener noreferrer">About</a>`)
+	assert.NotContains(t, result.Markdown, "javascript:")
+	assert.Contains(t, result.Markdown, "[About](https://example.com/about)")
+
+	// Custom policy
+	policy := sanitize.Strict()
+	policy.LinkRel = "nofollow"
+	result, err = distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+		Sanitizer:      policy,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, dom.InnerHTML(result.Node), `<a href="https://example.com/about" rel="nofollow">About</a>`)
+
+	// Markdown uses the same policy
+	policy.URLSchemes = []string{"http"}
+	result, err = distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+		Sanitizer:      policy,
+	})
+	assert.NoError(t, err)
+	assert.NotContains(t, result.Markdown, "https://example.com/about")
+	assert.Contains(t, result.Markdown, "About Lorem ipsum")
+
+	// Sanitizer is disabled
+	result, err = distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+		SkipSanitize:   true,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, dom.InnerHTML(result.Node), `<a href="https://example.com/about">About</a>`)
+	assert.Contains(t, result.Markdown, "[About](https://example.com/about)")
+}
+
+func Test_Distiller_StructuredData(t *testing.T) {
+	page := strings.Replace(testPage, "</head>", `<script type="application/ld+json">{
+		"@context": "https://schema.org",
+		"@type": "Event",
+		"name": "Dummy Conference",
+		"startDate": "2023-09-01",
+		"location": {"@type": "Place", "name": "Convention Center"}
+	}</script></head>`, 1)
+
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.StructuredData.Events))
+	assert.Equal(t, "Dummy Conference", result.StructuredData.Events[0].Name)
+	assert.Equal(t, "Convention Center", result.StructuredData.Events[0].Location)
+}
+
+func Test_Distiller_LeadImage(t *testing.T) {
+	ogImage := `<meta property="og:title" content="Test page">` +
+		`<meta property="og:type" content="article">` +
+		`<meta property="og:url" content="http://example.com/article">` +
+		`<meta property="og:image" content="http://example.com/og.jpg">`
+
+	// Without any image in content, og:image is used
+	page := strings.Replace(testPage, "</head>", ogImage+"</head>", 1)
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Images)
+	assert.NotNil(t, result.LeadImage)
+	assert.Equal(t, "http://example.com/og.jpg", result.LeadImage.URL)
+	assert.Equal(t, data.LeadImageFromMarkup, result.LeadImage.Source)
+	assert.Equal(t, data.MarkupFromOpenGraph, result.LeadImage.MarkupSource)
+
+	// Junk og:image is not used, either by URL pattern or blocklist
+	junkPage := strings.Replace(page, "http://example.com/og.jpg", "http://example.com/favicon.png", 1)
+	result, err = distiller.ApplyForReader(strings.NewReader(junkPage), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, result.LeadImage)
+
+	result, err = distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+		ImageBlocklist: []string{"example.com/og"},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, result.LeadImage)
+
+	// Image in content is preferred
+	page = strings.Replace(page, "<body>", `<body><figure>`+
+		`<img src="http://example.com/hero.jpg" alt="Hero" width="800" height="450" `+
+		`srcset="http://example.com/hero-400.jpg 400w, http://example.com/hero.jpg 800w">`+
+		`<figcaption>The hero image</figcaption></figure>`, 1)
+	result, err = distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, result.LeadImage)
+	assert.Equal(t, "http://example.com/hero.jpg", result.LeadImage.URL)
+	assert.Equal(t, data.LeadImageFromContent, result.LeadImage.Source)
+	assert.NotEmpty(t, result.LeadImage.Scores)
+
+	assert.Equal(t, 1, len(result.Images))
+	assert.Equal(t, "Hero", result.Images[0].Alt)
+	assert.Equal(t, "The hero image", result.Images[0].Caption)
+	assert.Equal(t, 800, result.Images[0].Width)
+	assert.Equal(t, 450, result.Images[0].Height)
+	assert.Equal(t, 2, len(result.Images[0].Candidates))
+}
+
+func Test_Distiller_ImageResolver(t *testing.T) {
+	page := strings.Replace(testPage, "<body>", `<body><figure><picture>`+
+		`<source type="image/webp" srcset="http://example.com/hero-800.webp 800w, http://example.com/hero-1600.webp 1600w">`+
+		`<img src="http://example.com/hero-thumb.jpg" `+
+		`srcset="http://example.com/hero-800.jpg 800w, http://example.com/hero-1600.jpg 1600w">`+
+		`</picture><figcaption>The hero image</figcaption></figure>`, 1)
+
+	// Without resolver, the src is kept
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Images))
+	assert.Equal(t, "http://example.com/hero-thumb.jpg", result.Images[0].URL)
+	assert.Empty(t, result.Images[0].OriginalURL)
+
+	// With resolver, the best candidate is used as src
+	result, err = distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+		ImageResolver: &srcset.Resolver{
+			TargetWidth: 1000,
+			AvoidTypes:  []string{"image/webp"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Images))
+	assert.Equal(t, "http://example.com/hero-1600.jpg", result.Images[0].URL)
+	assert.Equal(t, "http://example.com/hero-thumb.jpg", result.Images[0].OriginalURL)
+	assert.Contains(t, dom.InnerHTML(result.Node), `src="http://example.com/hero-1600.jpg"`)
+}
+
+func Test_Distiller_JunkImages(t *testing.T) {
+	page := strings.Replace(testPage, "<p>Lorem ipsum", `<p>`+
+		`<img src="http://example.com/photo.jpg" width="800" height="600">`+
+		`<img src="http://example.com/collect.gif" width="1" height="1">`+
+		`<img class="emoji" src="http://example.com/smile.png">`+
+		`<img src="http://promo.example.com/banner.jpg">`+
+		`</p><p>Lorem ipsum`, 1)
+
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+		ImageBlocklist: []string{"promo.example.com"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://example.com/photo.jpg"}, result.ContentImages)
+	assert.NotContains(t, dom.InnerHTML(result.Node), "smile.png")
+}
+
+func Test_Distiller_CustomDocumentPipeline(t *testing.T) {
+	page := strings.Replace(testPage, "</p>", "</p>"+
+		`<p><img src="http://example.com/collect.gif" width="1" height="1"></p>`, 1)
+
+	// Tracking pixel is removed by default
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, result.ContentImages)
+
+	// Without the junk image filter, the tracking pixel is kept
+	dp := pipeline.DefaultDocument()
+	assert.True(t, dp.Remove(pipeline.JunkImages))
+
+	result, err = distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination:   true,
+		DocumentPipeline: dp,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://example.com/collect.gif"}, result.ContentImages)
+}
+
+func Test_Distiller_Embeds(t *testing.T) {
+	page := strings.Replace(testPage, "</p>", "</p>"+
+		`<iframe src="https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC"></iframe>`+
+		`<iframe src="https://codepen.io/someone/embed/xYzAbC"></iframe>`+
+		`<iframe src="https://www.google.com/maps/embed?pb=abc"></iframe>`+
+		`<script src="https://gist.github.com/someone/0123456789abcdef.js"></script>`, 1)
+
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+
+	// Embeds are kept after sanitized by the default policy
+	output := dom.InnerHTML(result.Node)
+	assert.Contains(t, output, `<iframe src="https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC"></iframe>`)
+	assert.Contains(t, output, `<iframe src="https://codepen.io/someone/embed/xYzAbC"></iframe>`)
+	assert.Contains(t, output, `<iframe src="https://www.google.com/maps/embed?pb=abc"></iframe>`)
+
+	// Script embed is rendered as link
+	assert.Contains(t, output, `<a href="https://gist.github.com/someone/0123456789abcdef" rel="noopener noreferrer">GitHub Gist</a>`)
+}
+
+func Test_Distiller_UnrenderedEmbeds(t *testing.T) {
+	page := strings.Replace(testPage, "</p>", "</p>"+
+		`<div class="fb-post" data-href="https://www.facebook.com/somepage/posts/123456">`+
+		`<blockquote cite="https://www.facebook.com/somepage/posts/123456" class="fb-xfbml-parse-ignore">`+
+		`<a href="https://www.facebook.com/somepage/posts/123456">A post from our page</a></blockquote></div>`+
+		`<p class="codepen" data-user="someone" data-slug-hash="xYzAbC" data-default-tab="result">`+
+		`<span>See the Pen by someone.</span></p>`, 1)
+
+	result, err := distiller.ApplyForReader(strings.NewReader(page), &distiller.Options{
+		SkipPagination: true,
+	})
+	assert.NoError(t, err)
+
+	// Facebook post keeps its fallback blockquote
+	output := dom.InnerHTML(result.Node)
+	assert.Contains(t, output, `<div class="embed-placeholder" data-type="facebook" data-id="123456">`+
+		`<blockquote cite="https://www.facebook.com/somepage/posts/123456">`+
+		`<a href="https://www.facebook.com/somepage/posts/123456" rel="noopener noreferrer">A post from our page</a>`+
+		`</blockquote></div>`)
+
+	// Embed without fallback is rendered as link
+	assert.Contains(t, output, `<a href="https://codepen.io/someone/pen/xYzAbC" rel="noopener noreferrer">CodePen</a>`)
+}
+
+func Test_Distiller_CancelWhileReading(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Context is cancelled in the middle of the input
+	r := &cancellingReader{
+		chunk:  "<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit.</p>",
+		remain: 1000,
+		cancel: cancel,
+		after:  10,
+	}
+
+	_, err := distiller.ApplyForReaderContext(ctx, r, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var cancelledErr *distiller.CancelledError
+	assert.ErrorAs(t, err, &cancelledErr)
+	assert.Equal(t, "html parsing", cancelledErr.Stage)
+	assert.Less(t, r.reads, 1000)
+}
+
+// cancellingReader reads the chunk repeatedly and cancels the context after
+// the specified number of reads.
+type cancellingReader struct {
+	chunk  string
+	remain int
+	reads  int
+	after  int
+	cancel context.CancelFunc
+}
+
+func (r *cancellingReader) Read(p []byte) (int, error) {
+	if r.remain == 0 {
+		return 0, io.EOF
+	}
+
+	r.reads++
+	r.remain--
+	if r.reads == r.after {
+		r.cancel()
+	}
+	return copy(p, r.chunk), nil
+}
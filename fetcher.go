@@ -0,0 +1,143 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package distiller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrResponseTooLarge is returned by ApplyForURL when the size of the
+	// downloaded page exceeds Options.MaxResponseSize.
+	ErrResponseTooLarge = errors.New("response body exceeds the maximum size")
+
+	// DefaultAcceptedContentTypes is the list of content types that accepted by
+	// ApplyForURL when Options.AcceptedContentTypes is empty.
+	DefaultAcceptedContentTypes = []string{"text/html", "application/xhtml+xml"}
+)
+
+// Fetcher is used by ApplyForURL to download the web page. Implement it to use
+// your own HTTP client, e.g. to set User-Agent and cookies, to use a proxy or to
+// retry on failed request. The returned response body will be closed by the caller.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (*http.Response, error)
+}
+
+// FetcherFunc is an adapter to allow the use of ordinary function as Fetcher.
+type FetcherFunc func(ctx context.Context, url string) (*http.Response, error)
+
+// Fetch calls f(ctx, url).
+func (f FetcherFunc) Fetch(ctx context.Context, url string) (*http.Response, error) {
+	return f(ctx, url)
+}
+
+// HTTPFetcher is the default Fetcher which download the page using http.Client.
+type HTTPFetcher struct {
+	// Client is the HTTP client that used to send the request. If nil,
+	// http.DefaultClient will be used.
+	Client *http.Client
+
+	// RequestHook is called before the request is sent, so it can be used
+	// to modify the request, e.g. to set the headers or cookies.
+	RequestHook func(req *http.Request) error
+}
+
+// Fetch sends GET request to the specified URL.
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.RequestHook != nil {
+		if err = f.RequestHook(req); err != nil {
+			return nil, err
+		}
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(req)
+}
+
+// isAcceptedContentType checks if the media type in Content-Type header
+// is one of the accepted content types.
+func isAcceptedContentType(contentType string, acceptedTypes []string) bool {
+	if len(acceptedTypes) == 0 {
+		acceptedTypes = DefaultAcceptedContentTypes
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	for _, acceptedType := range acceptedTypes {
+		if strings.ToLower(acceptedType) == mediaType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// limitedReader is like io.LimitedReader, except it returns
+// ErrResponseTooLarge when the limit is exceeded.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func newLimitedReader(r io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	return &limitedReader{r: r, n: limit}
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.n < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	// Read one more byte than the limit, so we
+	// can tell if the limit is exceeded.
+	if int64(len(p)) > lr.n+1 {
+		p = p[:lr.n+1]
+	}
+
+	n, err := lr.r.Read(p)
+	lr.n -= int64(n)
+	if lr.n < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	return n, err
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package distiller_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	distiller "github.com/markusmobius/go-domdistiller"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/charmap"
+)
+
+var testPage = `<html><head><title>Test page</title></head><body>` +
+	strings.Repeat(`<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod `+
+		`tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis `+
+		`nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>`, 5) +
+	`</body></html>`
+
+func Test_Fetcher_RedirectAndRequestHook(t *testing.T) {
+	var userAgent string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		w.Header().Set("Content-Type", "application/xhtml+xml; charset=utf-8")
+		w.Write([]byte(testPage))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	opts := &distiller.Options{
+		SkipPagination: true,
+		Fetcher: &distiller.HTTPFetcher{
+			Client: server.Client(),
+			RequestHook: func(req *http.Request) error {
+				req.Header.Set("User-Agent", "test-agent")
+				return nil
+			},
+		},
+	}
+
+	result, err := distiller.ApplyForURLContext(context.Background(), server.URL+"/old", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-agent", userAgent)
+	assert.Equal(t, server.URL+"/new", result.URL)
+	assert.Equal(t, server.URL+"/new", opts.OriginalURL.String())
+	assert.Equal(t, "Test page", result.Title)
+}
+
+func Test_Fetcher_AcceptedContentTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(testPage))
+	}))
+	defer server.Close()
+
+	_, err := distiller.ApplyForURL(server.URL, 0, nil)
+	assert.Error(t, err)
+
+	opts := &distiller.Options{AcceptedContentTypes: []string{"text/plain"}}
+	result, err := distiller.ApplyForURL(server.URL, 0, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test page", result.Title)
+}
+
+func Test_Fetcher_MaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(testPage))
+	}))
+	defer server.Close()
+
+	opts := &distiller.Options{MaxResponseSize: 32}
+	_, err := distiller.ApplyForURL(server.URL, 0, opts)
+	assert.True(t, errors.Is(err, distiller.ErrResponseTooLarge))
+
+	opts = &distiller.Options{MaxResponseSize: int64(len(testPage))}
+	_, err = distiller.ApplyForURL(server.URL, 0, opts)
+	assert.NoError(t, err)
+}
+
+func Test_Fetcher_FetcherFunc(t *testing.T) {
+	fetcher := distiller.FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "text/html")
+		rec.WriteString(testPage)
+
+		resp := rec.Result()
+		resp.Request, _ = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		return resp, nil
+	})
+
+	opts := &distiller.Options{Fetcher: fetcher, SkipPagination: true}
+	result, err := distiller.ApplyForURL("http://example.com/page", 0, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://example.com/page", result.URL)
+	assert.True(t, strings.Contains(result.Text, "Lorem ipsum"))
+}
+
+func Test_Fetcher_EncodingFromHeader(t *testing.T) {
+	page := strings.Replace(testPage, "Test page", "Русский заголовок", 1)
+	encoded, err := charmap.Windows1251.NewEncoder().String(page)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=windows-1251")
+		w.Write([]byte(encoded))
+	}))
+	defer server.Close()
+
+	result, err := distiller.ApplyForURL(server.URL, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "windows-1251", result.Encoding)
+	assert.Equal(t, "Русский заголовок", result.Title)
+}
+
+func Test_Fetcher_LanguageFromHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Content-Language", "it-IT")
+		w.Write([]byte(testPage))
+	}))
+	defer server.Close()
+
+	result, err := distiller.ApplyForURL(server.URL, 0, &distiller.Options{SkipPagination: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "it-IT", result.Language.Tag)
+	assert.Equal(t, "ltr", result.Language.Direction)
+}
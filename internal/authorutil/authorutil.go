@@ -0,0 +1,145 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package authorutil contains functions for normalizing the author names
+// that found in metadata and bylines.
+package authorutil
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const maxNameWords = 6
+
+var (
+	rxBylinePrefix  = regexp.MustCompile(`(?i)^(?:(?:written|posted|reported|words|story|text)\s+by|by|authors?)\b\s*[:\-–—]?\s*`)
+	rxSeparator     = regexp.MustCompile(`(?i)\s*(?:[;&|•·]|\band\b)\s*`)
+	rxJobTitle      = regexp.MustCompile(`(?i)\b(?:reporter|editor|writer|correspondent|columnist|journalist|contributor|photographer|producer|analyst|staff|freelancer?|intern)s?\b`)
+	rxDigit         = regexp.MustCompile(`\d`)
+	rxEmailWithName = regexp.MustCompile(`^\S+@\S+\s*\(([^)]+)\)$`)
+	rxEmail         = regexp.MustCompile(`[<(\[]?[^\s@<>()\[\]]+@[^\s@<>()\[\]]+\.[^\s@<>()\[\]]+[>)\]]?`)
+	rxURL           = regexp.MustCompile(`(?i)^(?:https?://|www\.)\S+$`)
+	rxEmptyBrackets = regexp.MustCompile(`[(<\[]\s*[)>\]]`)
+)
+
+// Normalize cleans up the author names that taken from several sources. Each entry might
+// contain several names, e.g. "By Jane Doe and John Smith", so it will be splitted. The
+// entries that are not a name, e.g. URL or email, are removed along with the duplicates.
+func Normalize(entries []string) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, entry := range entries {
+		for _, name := range Split(entry) {
+			key := strings.ToLower(name)
+			if _, exist := seen[key]; exist {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Split splits text like "By Jane Doe and John Smith" into the author names.
+func Split(text string) []string {
+	text = strings.Join(strings.Fields(text), " ")
+	text = rxBylinePrefix.ReplaceAllString(text, "")
+	text = removeEmails(text)
+
+	var names []string
+	for _, part := range rxSeparator.Split(text, -1) {
+		names = append(names, splitComma(part)...)
+	}
+	return names
+}
+
+// splitComma splits the text by comma, but only if every fragment looks like a full
+// name, e.g. "Jane Doe, John Smith". Fragments that are not a name, like job title in
+// "Jane Doe, Senior Reporter" or date, are removed. Otherwise the comma is kept since
+// it might be a part of the name, e.g. "Doe, Jane".
+func splitComma(text string) []string {
+	var fragments []string
+	for _, fragment := range strings.Split(text, ",") {
+		if rxJobTitle.MatchString(fragment) {
+			continue
+		}
+
+		if name := NormalizeName(fragment); name != "" {
+			fragments = append(fragments, name)
+		}
+	}
+
+	if len(fragments) == 0 {
+		return nil
+	}
+
+	for _, fragment := range fragments {
+		if !isFullName(fragment) {
+			return []string{strings.Join(fragments, ", ")}
+		}
+	}
+
+	return fragments
+}
+
+// isFullName returns true if the name has at least two capitalized words,
+// e.g. "Jane Doe" or "Ludwig van Beethoven".
+func isFullName(name string) bool {
+	var nCapitalized int
+	for _, word := range strings.Fields(name) {
+		if r, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(r) {
+			nCapitalized++
+		}
+	}
+	return nCapitalized >= 2
+}
+
+// NormalizeName cleans up the author name. Returns empty string if the text
+// doesn't look like a name, e.g. it's a date, URL, email or too long.
+func NormalizeName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	name = rxBylinePrefix.ReplaceAllString(name, "")
+	name = removeEmails(name)
+	name = strings.Trim(name, " ,;:|-–—•·")
+
+	if name == "" || rxDigit.MatchString(name) || rxURL.MatchString(name) ||
+		strings.Contains(name, "@") || len(strings.Fields(name)) > maxNameWords {
+		return ""
+	}
+
+	return name
+}
+
+// removeEmails removes the email address from text. The common format of
+// "jane@example.com (Jane Doe)" in RSS feed is converted into the name.
+func removeEmails(text string) string {
+	if m := rxEmailWithName.FindStringSubmatch(text); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+
+	text = rxEmail.ReplaceAllString(text, "")
+	text = rxEmptyBrackets.ReplaceAllString(text, "")
+	return strings.Join(strings.Fields(text), " ")
+}
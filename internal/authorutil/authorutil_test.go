@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package authorutil_test
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/internal/authorutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuthorUtil_Normalize(t *testing.T) {
+	names := authorutil.Normalize([]string{
+		"By Jane Doe and John Smith",
+		"jane doe",
+		"Alice Cooper, Bob Marley",
+		"https://example.com/authors/jane",
+		"www.example.com/john",
+		"editor@example.com",
+		"carol@example.com (Carol King)",
+		"Dave Brown <dave@example.com>",
+		"Posted on 14 May 2023",
+	})
+
+	assert.Equal(t, []string{
+		"Jane Doe",
+		"John Smith",
+		"Alice Cooper",
+		"Bob Marley",
+		"Carol King",
+		"Dave Brown",
+	}, names)
+}
+
+func Test_AuthorUtil_Split(t *testing.T) {
+	// Comma is only used as separator if every part is a full name
+	assert.Equal(t, []string{"Jane Doe", "John Smith"}, authorutil.Split("By Jane Doe, John Smith"))
+	assert.Equal(t, []string{"Doe, Jane"}, authorutil.Split("By Doe, Jane"))
+	assert.Equal(t, []string{"Doe, Jane", "John Smith"}, authorutil.Split("Doe, Jane and John Smith"))
+
+	// Job title and date are not a name
+	assert.Equal(t, []string{"Jane Doe"}, authorutil.Split("Jane Doe, Senior Reporter"))
+	assert.Equal(t, []string{"Jane Doe", "John Smith"}, authorutil.Split("Jane Doe, Staff Writer, and John Smith"))
+	assert.Equal(t, []string{"John Smith"}, authorutil.Split("By John Smith, October 12, 2020"))
+}
+
+func Test_AuthorUtil_NormalizeName(t *testing.T) {
+	assert.Equal(t, "Jane Doe", authorutil.NormalizeName("  Written by:  Jane   Doe "))
+	assert.Equal(t, "", authorutil.NormalizeName("May 14, 2023"))
+	assert.Equal(t, "", authorutil.NormalizeName("http://example.com/jane"))
+	assert.Equal(t, "", authorutil.NormalizeName("@janedoe"))
+	assert.Equal(t, "", authorutil.NormalizeName("the staff of the newspaper editorial desk today"))
+}
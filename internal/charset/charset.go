@@ -0,0 +1,170 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package charset detects the character encoding of HTML document and
+// transcodes it into UTF-8 before it's parsed.
+package charset
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"strings"
+
+	"golang.org/x/net/html"
+	htmlcharset "golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// PreviewSize is the number of bytes that inspected to find the BOM and
+// <meta> charset declaration. HTML spec only requires the first 1024 bytes,
+// however in the wild many pages declare their charset after a long list of
+// other meta tags, so we look a bit further.
+const PreviewSize = 4096
+
+// NewReader returns a reader that converts the content of r to UTF-8, along with
+// the name of the detected encoding. The encoding is determined by checking, in
+// order, the BOM, the charset in Content-Type header and the <meta charset> or
+// <meta http-equiv="Content-Type"> tag. If none of them exists, the content is
+// assumed to be UTF-8.
+func NewReader(r io.Reader, contentType string) (io.Reader, string, error) {
+	br := bufio.NewReaderSize(r, PreviewSize)
+	preview, err := br.Peek(PreviewSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, "", err
+	}
+
+	enc, name := DetermineEncoding(preview, contentType)
+	if enc == encoding.Nop {
+		return br, name, nil
+	}
+
+	return transform.NewReader(br, enc.NewDecoder()), name, nil
+}
+
+// DetermineEncoding determines the encoding of the HTML document by checking its
+// BOM, the charset in Content-Type header and the charset declared in <meta> tag.
+// If the encoding is UTF-8 or unknown, it returns encoding.Nop and "utf-8".
+func DetermineEncoding(preview []byte, contentType string) (encoding.Encoding, string) {
+	// BOM takes precedence over everything else. For UTF-8 we still need
+	// to decode it, so the BOM is removed from the content.
+	if enc, name := fromBOM(preview); enc != nil {
+		return enc, name
+	}
+
+	// Check charset in Content-Type header, then the <meta> tags.
+	enc, name := fromContentType(contentType)
+	if enc == nil {
+		enc, name = fromMeta(preview)
+	}
+
+	if enc == nil || name == "utf-8" {
+		return encoding.Nop, "utf-8"
+	}
+
+	return enc, name
+}
+
+func fromContentType(contentType string) (encoding.Encoding, string) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ""
+	}
+	return lookup(params["charset"])
+}
+
+func fromBOM(preview []byte) (encoding.Encoding, string) {
+	switch {
+	case bytes.HasPrefix(preview, []byte{0xEF, 0xBB, 0xBF}):
+		return unicode.UTF8BOM, "utf-8"
+	case bytes.HasPrefix(preview, []byte{0xFE, 0xFF}):
+		return unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), "utf-16be"
+	case bytes.HasPrefix(preview, []byte{0xFF, 0xFE}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM), "utf-16le"
+	default:
+		return nil, ""
+	}
+}
+
+// fromMeta looks for charset declared in <meta charset> or in the content of
+// <meta http-equiv="Content-Type">. This is a simplified version of the prescan
+// algorithm in HTML spec.
+func fromMeta(preview []byte) (encoding.Encoding, string) {
+	z := html.NewTokenizer(bytes.NewReader(preview))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return nil, ""
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tagName, hasAttr := z.TagName()
+			if !bytes.Equal(tagName, []byte("meta")) {
+				continue
+			}
+
+			var label, content string
+			var isContentType bool
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				switch string(key) {
+				case "charset":
+					label = string(val)
+				case "content":
+					content = string(val)
+				case "http-equiv":
+					isContentType = strings.EqualFold(string(val), "content-type")
+				}
+			}
+
+			if label == "" && isContentType && content != "" {
+				if _, params, err := mime.ParseMediaType(content); err == nil {
+					label = params["charset"]
+				}
+			}
+
+			if enc, name := lookup(label); enc != nil {
+				// A document that declares itself as UTF-16 in <meta> tag is
+				// impossible since we are able to read the tag, so it's UTF-8.
+				if strings.HasPrefix(name, "utf-16") {
+					return encoding.Nop, "utf-8"
+				}
+				return enc, name
+			}
+		}
+	}
+}
+
+func lookup(label string) (encoding.Encoding, string) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return nil, ""
+	}
+
+	enc, name := htmlcharset.Lookup(label)
+	if enc == nil {
+		return nil, ""
+	}
+
+	return enc, name
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package charset_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/internal/charset"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func Test_Charset_MetaCharset(t *testing.T) {
+	text := "日本語のテキスト"
+	page := `<html><head><meta charset="Shift_JIS"></head><body>` + text + `</body></html>`
+	assertDecoded(t, encode(t, japanese.ShiftJIS, page), "", "shift_jis", page)
+}
+
+func Test_Charset_MetaHttpEquiv(t *testing.T) {
+	text := "Русский текст"
+	page := `<html><head><meta http-equiv="Content-Type" content="text/html; charset=windows-1251">` +
+		`</head><body>` + text + `</body></html>`
+	assertDecoded(t, encode(t, charmap.Windows1251, page), "", "windows-1251", page)
+}
+
+func Test_Charset_ContentTypeHeader(t *testing.T) {
+	// Content-Type header takes precedence over meta tag
+	text := "Русский текст"
+	page := `<html><head><meta charset="utf-8"></head><body>` + text + `</body></html>`
+	assertDecoded(t, encode(t, charmap.KOI8R, page), "text/html; charset=KOI8-R", "koi8-r", page)
+}
+
+func Test_Charset_BOM(t *testing.T) {
+	page := `<html><head><meta charset="windows-1252"></head><body>Ünïcödé</body></html>`
+
+	utf16 := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	assertDecoded(t, encode(t, utf16, page), "text/html; charset=windows-1252", "utf-16le", page)
+
+	utf8 := append([]byte{0xEF, 0xBB, 0xBF}, []byte(page)...)
+	assertDecoded(t, utf8, "", "utf-8", page)
+}
+
+func Test_Charset_DefaultToUTF8(t *testing.T) {
+	// Non-ASCII character only appears after the preview,
+	// and there are no charset declaration at all.
+	page := `<html><body>` + strings.Repeat("a", charset.PreviewSize) + `日本語</body></html>`
+	assertDecoded(t, []byte(page), "text/html", "utf-8", page)
+
+	enc, name := charset.DetermineEncoding([]byte(page), "")
+	assert.Equal(t, encoding.Nop, enc)
+	assert.Equal(t, "utf-8", name)
+}
+
+func encode(t *testing.T, enc encoding.Encoding, str string) []byte {
+	encoded, err := enc.NewEncoder().String(str)
+	assert.NoError(t, err)
+	return []byte(encoded)
+}
+
+func assertDecoded(t *testing.T, input []byte, contentType, expectedName, expectedContent string) {
+	r, name, err := charset.NewReader(bytes.NewReader(input), contentType)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedName, name)
+
+	content, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedContent, string(content))
+}
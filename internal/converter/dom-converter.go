@@ -36,6 +36,7 @@ import (
 	"github.com/markusmobius/go-domdistiller/internal/logutil"
 	"github.com/markusmobius/go-domdistiller/internal/tableclass"
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/markusmobius/go-domdistiller/siterule"
 	"golang.org/x/net/html"
 )
 
@@ -55,6 +56,14 @@ type DomConverter struct {
 	logger          logutil.Logger
 	tableClassifier *tableclass.Classifier
 	flags           ConverterFlag
+
+	// SiteRule is the rule for the current site. If not nil, its strip and always
+	// content selectors are consulted before running the heuristics.
+	SiteRule *siterule.Rule
+
+	strippedNodes      map[*html.Node]struct{}
+	alwaysContentNodes map[*html.Node]struct{}
+	bylines            []*html.Node
 }
 
 func NewDomConverter(flags ConverterFlag, builder webdoc.DocumentBuilder, pageURL *nurl.URL, logger logutil.Logger) *DomConverter {
@@ -63,6 +72,17 @@ func NewDomConverter(flags ConverterFlag, builder webdoc.DocumentBuilder, pageUR
 		embed.NewTwitterExtractor(pageURL, logger),
 		embed.NewVimeoExtractor(pageURL, logger),
 		embed.NewYouTubeExtractor(pageURL, logger),
+		embed.NewInstagramExtractor(pageURL, logger),
+		embed.NewFacebookExtractor(pageURL, logger),
+		embed.NewTikTokExtractor(pageURL, logger),
+		embed.NewMastodonExtractor(pageURL, logger),
+		embed.NewBlueskyExtractor(pageURL, logger),
+		embed.NewSpotifyExtractor(pageURL, logger),
+		embed.NewSoundCloudExtractor(pageURL, logger),
+		embed.NewApplePodcastsExtractor(pageURL, logger),
+		embed.NewGistExtractor(pageURL, logger),
+		embed.NewCodePenExtractor(pageURL, logger),
+		embed.NewGoogleMapsExtractor(pageURL, logger),
 	}
 
 	embedTagNames := make(map[string]struct{})
@@ -84,10 +104,71 @@ func NewDomConverter(flags ConverterFlag, builder webdoc.DocumentBuilder, pageUR
 }
 
 func (dc *DomConverter) Convert(root *html.Node) {
+	dc.bylines = nil
 	clone := dom.Clone(root, true)
+
+	// Images are recovered before the site rule is applied, so the selectors are
+	// matched against the real images instead of their lazy placeholders. However,
+	// stripped <noscript> must be removed first, otherwise its image is unwrapped.
+	dc.removeStrippedNoscripts(clone)
+	recoverImages(clone)
+	dc.applySiteRule(clone)
 	domutil.WalkNodes(clone, dc.visitNodeHandler, dc.exitNodeHandler)
 }
 
+// Bylines returns the byline elements (e.g. "By Jane Doe") which found in the last
+// conversion. Those elements are not included in the converted document.
+func (dc *DomConverter) Bylines() []*html.Node {
+	return dc.bylines
+}
+
+// IsAlwaysContent returns true if the node is (or inside of) element that matched
+// with the always content selectors in site rule. The node must be the one that
+// used in the converted document, not the one from the original page.
+func (dc *DomConverter) IsAlwaysContent(node *html.Node) bool {
+	_, exist := dc.alwaysContentNodes[node]
+	return exist
+}
+
+// removeStrippedNoscripts removes the <noscript> elements that matched with the strip
+// selectors in site rule, so the images inside them won't be recovered.
+func (dc *DomConverter) removeStrippedNoscripts(root *html.Node) {
+	if dc.SiteRule == nil {
+		return
+	}
+
+	for _, selector := range dc.SiteRule.Strip {
+		for _, node := range dom.QuerySelectorAll(root, selector) {
+			if dom.TagName(node) == "noscript" && node.Parent != nil {
+				node.Parent.RemoveChild(node)
+			}
+		}
+	}
+}
+
+func (dc *DomConverter) applySiteRule(root *html.Node) {
+	dc.strippedNodes = make(map[*html.Node]struct{})
+	dc.alwaysContentNodes = make(map[*html.Node]struct{})
+	if dc.SiteRule == nil {
+		return
+	}
+
+	for _, selector := range dc.SiteRule.Strip {
+		for _, node := range dom.QuerySelectorAll(root, selector) {
+			dc.strippedNodes[node] = struct{}{}
+		}
+	}
+
+	for _, selector := range dc.SiteRule.AlwaysContent {
+		for _, node := range dom.QuerySelectorAll(root, selector) {
+			domutil.WalkNodes(node, func(child *html.Node) bool {
+				dc.alwaysContentNodes[child] = struct{}{}
+				return true
+			}, nil)
+		}
+	}
+}
+
 func (dc *DomConverter) visitNodeHandler(node *html.Node) bool {
 	switch node.Type {
 	case html.TextNode:
@@ -113,50 +194,17 @@ func (dc *DomConverter) exitNodeHandler(node *html.Node) {
 }
 
 func (dc *DomConverter) visitElementNodeHandler(node *html.Node) bool {
-	// In original dom-distiller they skip invisible or uninteresting elements.
-	// Unfortunately it's impossible to do that perfectly here (NEED-COMPUTE-CSS).
-	if !domutil.IsProbablyVisible(node) {
+	// Site rule takes precedence over the heuristics below.
+	if _, stripped := dc.strippedNodes[node]; stripped {
 		return false
 	}
 
-	// Skip social and sharing elements.
-	// See crbug.com/692553, crbug.com/696556, and crbug.com/674557
+	tagName := dom.TagName(node)
 	className := dom.ClassName(node)
-	component := dom.GetAttribute(node, "data-component")
-	if className == "sharing" || className == "socialArea" || component == "share" {
+	if !dc.IsAlwaysContent(node) && !dc.passHeuristics(node, tagName, className) {
 		return false
 	}
 
-	// Skip byline (author)
-	nodeData := dom.ClassName(node) + " " + dom.ID(node)
-	if isByline(node, nodeData) {
-		return false
-	}
-
-	// Skip unlikely candidates
-	tagName := dom.TagName(node)
-	if dc.hasFlag(SkipUnlikelies) {
-		if rxUnlikelyCandidates.MatchString(nodeData) && !rxOkMaybeItsACandidate.MatchString(nodeData) &&
-			!domutil.HasAncestor(node, "table") && tagName != "body" && tagName != "a" {
-			return false
-		}
-
-		role := dom.GetAttribute(node, "role")
-		if _, isUnlikely := unlikelyRoles[role]; isUnlikely {
-			return false
-		}
-	}
-
-	// Remove DIV, SECTION, and HEADER nodes without any
-	// content(e.g. text, image, video, or iframe).
-	switch tagName {
-	case "div", "section", "header",
-		"h1", "h2", "h3", "h4", "h5", "h6":
-		if isElementWithoutContent(node) {
-			return false
-		}
-	}
-
 	// Node-type specific extractors check for elements they are interested in here.
 	// Everything else will be filtered through the switch below.
 	if _, isEmbed := dc.embedTagNames[tagName]; isEmbed {
@@ -248,6 +296,59 @@ func (dc *DomConverter) visitElementNodeHandler(node *html.Node) bool {
 	return true
 }
 
+// passHeuristics checks if the element is probably part of the content,
+// i.e. it's visible, not a social widget, not a byline and not an unlikely
+// candidate.
+func (dc *DomConverter) passHeuristics(node *html.Node, tagName, className string) bool {
+	// In original dom-distiller they skip invisible or uninteresting elements.
+	// Unfortunately it's impossible to do that perfectly here (NEED-COMPUTE-CSS).
+	// Script is never visible, but GitHub Gist is embedded using script so let the
+	// embed extractors check it.
+	isGistScript := tagName == "script" && domutil.HasRootDomain(dom.GetAttribute(node, "src"), "gist.github.com")
+	if !isGistScript && !domutil.IsProbablyVisible(node) {
+		return false
+	}
+
+	// Skip social and sharing elements.
+	// See crbug.com/692553, crbug.com/696556, and crbug.com/674557
+	component := dom.GetAttribute(node, "data-component")
+	if className == "sharing" || className == "socialArea" || component == "share" {
+		return false
+	}
+
+	// Skip byline (author), but save it for extracting the author names.
+	nodeData := className + " " + dom.ID(node)
+	if isByline(node, nodeData) {
+		dc.bylines = append(dc.bylines, node)
+		return false
+	}
+
+	// Skip unlikely candidates
+	if dc.hasFlag(SkipUnlikelies) {
+		if rxUnlikelyCandidates.MatchString(nodeData) && !rxOkMaybeItsACandidate.MatchString(nodeData) &&
+			!domutil.HasAncestor(node, "table") && tagName != "body" && tagName != "a" {
+			return false
+		}
+
+		role := dom.GetAttribute(node, "role")
+		if _, isUnlikely := unlikelyRoles[role]; isUnlikely {
+			return false
+		}
+	}
+
+	// Remove DIV, SECTION, and HEADER nodes without any
+	// content(e.g. text, image, video, or iframe).
+	switch tagName {
+	case "div", "section", "header",
+		"h1", "h2", "h3", "h4", "h5", "h6":
+		if isElementWithoutContent(node) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (dc *DomConverter) logTableInfo(table *html.Node, tableType tableclass.Type) {
 	if dc.logger == nil {
 		return
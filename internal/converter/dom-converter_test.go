@@ -34,6 +34,7 @@ import (
 	"github.com/markusmobius/go-domdistiller/internal/stringutil"
 	"github.com/markusmobius/go-domdistiller/internal/testutil"
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/markusmobius/go-domdistiller/siterule"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -217,3 +218,123 @@ func assertEmpty(t *testing.T, innerHTML string) {
 
 	assert.Equal(t, "", builder.Build())
 }
+
+func Test_Converter_SiteRule(t *testing.T) {
+	div := dom.CreateElement("div")
+	dom.SetInnerHTML(div, `<p class="ad">Ad</p>`+
+		`<div class="sharing"><p>Keep</p></div>`+
+		`<li class="sharing"></li>`)
+
+	builder := testutil.NewFakeWebDocumentBuilder()
+	dc := converter.NewDomConverter(converter.Default, builder, nil, nil)
+	dc.SiteRule = &siterule.Rule{
+		Strip:         []string{".ad"},
+		AlwaysContent: []string{"div.sharing"},
+	}
+	dc.Convert(div)
+
+	// Stripped element is removed, while the always content element is
+	// kept even though it's detected as social element.
+	expected := `<div><div class="sharing"><p>Keep</p></div></div>`
+	assert.Equal(t, expected, builder.Build())
+}
+
+func Test_Converter_RecoverImages(t *testing.T) {
+	div := dom.CreateElement("div")
+	dom.SetInnerHTML(div, `<p>Text content</p>`+
+		// Real image in noscript replaces the lazy one
+		`<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" alt="Lazy" width="800">`+
+		`<noscript><img src="http://example.com/noscript.jpg"></noscript>`+
+		// Placeholder is replaced by the lazy loading attributes
+		`<img src="/images/blank.gif" data-lazy-src="http://example.com/lazy.jpg">`+
+		// Background image is promoted into figure, except for decorative one
+		`<div class="hero" style="background-image: url('http://example.com/hero.jpg')"></div>`+
+		`<span class="icon" style="background: url(http://example.com/icon.png)"></span>`+
+		`<p>More content</p>`)
+
+	builder := webdoc.NewWebDocumentBuilder(stringutil.FastWordCounter{}, nil)
+	converter.NewDomConverter(converter.Default, builder, nil, nil).Convert(div)
+
+	var urls []string
+	var images []*webdoc.Image
+	for _, e := range builder.Build().Elements {
+		switch element := e.(type) {
+		case *webdoc.Image:
+			urls = append(urls, element.GetURLs()...)
+			images = append(images, element)
+		case *webdoc.Figure:
+			urls = append(urls, element.GetURLs()...)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"http://example.com/noscript.jpg",
+		"http://example.com/lazy.jpg",
+		"http://example.com/hero.jpg",
+	}, urls)
+
+	// Attributes of the lazy image are kept
+	assert.Equal(t, "Lazy", dom.GetAttribute(images[0].Element, "alt"))
+	assert.Equal(t, "800", dom.GetAttribute(images[0].Element, "width"))
+
+	// The original document is not modified
+	assert.NotNil(t, dom.QuerySelector(div, "noscript"))
+	assert.Nil(t, dom.QuerySelector(div, "figure"))
+}
+
+func Test_Converter_SiteRuleLazyImage(t *testing.T) {
+	html := `<p>Text content</p>` +
+		`<img class="promo" src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">` +
+		`<noscript><img src="http://example.com/promo.jpg"></noscript>` +
+		`<p>More content</p>`
+
+	// The lazy image is stripped along with its noscript fallback, whether
+	// the selector points to the lazy image or to the noscript.
+	for _, selector := range []string{"img.promo", "noscript"} {
+		div := dom.CreateElement("div")
+		dom.SetInnerHTML(div, html)
+
+		builder := webdoc.NewWebDocumentBuilder(stringutil.FastWordCounter{}, nil)
+		dc := converter.NewDomConverter(converter.Default, builder, nil, nil)
+		dc.SiteRule = &siterule.Rule{Strip: []string{selector}}
+		dc.Convert(div)
+
+		var urls []string
+		for _, e := range builder.Build().Elements {
+			if image, isImage := e.(*webdoc.Image); isImage {
+				urls = append(urls, image.GetURLs()...)
+			}
+		}
+		assert.NotContains(t, urls, "http://example.com/promo.jpg", selector)
+	}
+}
+
+func Test_Converter_Embeds(t *testing.T) {
+	div := dom.CreateElement("div")
+	dom.SetInnerHTML(div, `<p>Text content</p>`+
+		`<blockquote class="instagram-media" data-instgrm-permalink="https://www.instagram.com/p/CxYz123/">`+
+		`<a href="https://www.instagram.com/p/CxYz123/">A post shared by someone</a></blockquote>`+
+		`<iframe src="https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC"></iframe>`+
+		`<p class="codepen" data-slug-hash="xYzAbC" data-user="someone">See the Pen on CodePen.</p>`+
+		// Script is kept only if it's an embed
+		`<script src="https://gist.github.com/someone/0123456789abcdef.js"></script>`+
+		`<script src="https://example.com/analytics.js"></script>`+
+		`<p>More content</p>`)
+
+	builder := webdoc.NewWebDocumentBuilder(stringutil.FastWordCounter{}, nil)
+	converter.NewDomConverter(converter.Default, builder, nil, nil).Convert(div)
+
+	var embeds []string
+	for _, e := range builder.Build().Elements {
+		if embed, isEmbed := e.(*webdoc.Embed); isEmbed {
+			embeds = append(embeds, embed.Type+":"+embed.ID)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"instagram:CxYz123",
+		"spotify:4uLU6hMCjMI75M1A2tKUQC",
+		"codepen:xYzAbC",
+		"gist:0123456789abcdef",
+	}, embeds)
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package converter
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"golang.org/x/net/html"
+)
+
+var (
+	rxPlaceholderImage  = regexp.MustCompile(`(?i)(^|[-_.])(blank|spacer|placeholder|transparent|lazy|loading|loader|dummy|1x1)([-_.]|$)`)
+	rxBackgroundURL     = regexp.MustCompile(`(?i)url\(\s*['"]?([^'")]+?)['"]?\s*\)`)
+	rxBackgroundStyle   = regexp.MustCompile(`(?i)background(?:-image)?\s*:[^;]*url\(`)
+	rxDecorativeElement = regexp.MustCompile(`(?i)icon|logo|avatar|sprite|button|btn|badge|emoji|social|share|overlay|gradient|pattern`)
+
+	backgroundImageAttrs = []string{
+		"data-bg",
+		"data-background",
+		"data-background-image",
+		"data-bg-src",
+		"data-bg-image",
+	}
+)
+
+// recoverImages restores the real images that hidden by lazy loading before
+// the document is converted:
+//   - images inside <noscript> are unwrapped, replacing its lazy sibling;
+//   - placeholder src (e.g. blank GIF or tiny data URI) is replaced by the
+//     real URL from the lazy loading attributes;
+//   - elements that use background image as photo are given a figure.
+func recoverImages(root *html.Node) {
+	unwrapNoscriptImages(root)
+	replacePlaceholderImages(root)
+	promoteBackgroundImages(root)
+}
+
+// unwrapNoscriptImages moves the image inside <noscript> out. If the previous
+// sibling of the <noscript> is an image, it's assumed as the lazy version of
+// the real image, so it's replaced.
+func unwrapNoscriptImages(root *html.Node) {
+	for _, noscript := range dom.GetElementsByTagName(root, "noscript") {
+		if noscript.Parent == nil {
+			continue
+		}
+
+		// Noscript is a bit weird in Go. Sometimes its content is parsed as HTML
+		// elements, but most of the time it's treated as plain text.
+		content := dom.CreateElement("div")
+		if dom.FirstElementChild(noscript) != nil {
+			for _, child := range dom.ChildNodes(noscript) {
+				dom.AppendChild(content, dom.Clone(child, true))
+			}
+		} else {
+			dom.SetInnerHTML(content, dom.TextContent(noscript))
+		}
+
+		if !isSingleImage(content) {
+			continue
+		}
+
+		image := domutil.GetFirstElementByTagName(content, "picture")
+		if image == nil {
+			image = domutil.GetFirstElementByTagName(content, "img")
+		}
+		image.Parent.RemoveChild(image)
+
+		prev := dom.PreviousElementSibling(noscript)
+		if prev != nil && isSingleImage(prev) {
+			copyImageAttributes(image, prev)
+			prev.Parent.InsertBefore(image, prev)
+			prev.Parent.RemoveChild(prev)
+		} else {
+			noscript.Parent.InsertBefore(image, noscript)
+		}
+
+		noscript.Parent.RemoveChild(noscript)
+	}
+}
+
+// copyImageAttributes copies the id, class, alt text and dimensions from the lazy
+// image, if the real image doesn't have them.
+func copyImageAttributes(image, lazy *html.Node) {
+	img := domutil.GetFirstElementByTagNameInc(image, "img")
+	lazyImg := domutil.GetFirstElementByTagNameInc(lazy, "img")
+	if img == nil || lazyImg == nil {
+		return
+	}
+
+	for _, attrName := range []string{"id", "class", "alt", "width", "height"} {
+		if !dom.HasAttribute(img, attrName) && dom.HasAttribute(lazyImg, attrName) {
+			dom.SetAttribute(img, attrName, dom.GetAttribute(lazyImg, attrName))
+		}
+	}
+}
+
+// replacePlaceholderImages replaces the src of image which is missing or only a
+// placeholder with the real URL that stored in the lazy loading attributes.
+func replacePlaceholderImages(root *html.Node) {
+	for _, img := range dom.GetElementsByTagName(root, "img") {
+		src := strings.TrimSpace(dom.GetAttribute(img, "src"))
+		if src != "" && !isPlaceholderImage(src) {
+			continue
+		}
+
+		for _, attrName := range domutil.LazyImageSrcAttrs {
+			if value := strings.TrimSpace(dom.GetAttribute(img, attrName)); value != "" && !domutil.IsTinyDataURL(value) {
+				dom.SetAttribute(img, "src", value)
+				break
+			}
+		}
+
+		srcsetURLs := domutil.GetSrcSetURLs(img)
+		if len(srcsetURLs) > 0 && !isPlaceholderImage(srcsetURLs[0]) {
+			continue
+		}
+
+		for _, attrName := range domutil.LazyImageSrcsetAttrs {
+			if value := strings.TrimSpace(dom.GetAttribute(img, attrName)); value != "" {
+				dom.SetAttribute(img, "srcset", value)
+				break
+			}
+		}
+	}
+}
+
+// promoteBackgroundImages looks for element that shows a photo as its CSS background
+// image, then prepends a figure of the image into it. Element that already contains
+// an image or looks like decoration (e.g. icon and logo) is skipped.
+func promoteBackgroundImages(root *html.Node) {
+	// Check the nodes in reverse order, so the inner element gets
+	// the figure first and its ancestors will skip it.
+	nodes := dom.GetElementsByTagName(root, "*")
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		switch dom.TagName(node) {
+		case "html", "body", "img", "picture", "figure", "video", "svg":
+			continue
+		}
+
+		url := backgroundImageURL(node)
+		if url == "" || strings.HasPrefix(url, "data:") || isPlaceholderImage(url) {
+			continue
+		}
+
+		if rxDecorativeElement.MatchString(dom.ClassName(node) + " " + dom.ID(node)) {
+			continue
+		}
+
+		if len(dom.QuerySelectorAll(node, "img,picture,video,figure")) > 0 {
+			continue
+		}
+
+		img := dom.CreateElement("img")
+		dom.SetAttribute(img, "src", url)
+		if alt := dom.GetAttribute(node, "aria-label"); alt != "" {
+			dom.SetAttribute(img, "alt", alt)
+		} else if alt := dom.GetAttribute(node, "title"); alt != "" {
+			dom.SetAttribute(img, "alt", alt)
+		}
+
+		figure := dom.CreateElement("figure")
+		dom.AppendChild(figure, img)
+		dom.PrependChild(node, figure)
+	}
+}
+
+// backgroundImageURL returns the URL of background image of the node, which
+// taken from the lazy loading attributes or the inline style.
+func backgroundImageURL(node *html.Node) string {
+	for _, attrName := range backgroundImageAttrs {
+		value := strings.TrimSpace(dom.GetAttribute(node, attrName))
+		if value == "" {
+			continue
+		}
+
+		if match := rxBackgroundURL.FindStringSubmatch(value); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+		return value
+	}
+
+	style := dom.GetAttribute(node, "style")
+	if loc := rxBackgroundStyle.FindStringIndex(style); loc != nil {
+		if match := rxBackgroundURL.FindStringSubmatch(style[loc[0]:]); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+	}
+
+	return ""
+}
+
+// isSingleImage checks if the node is an image, or only contains a single image.
+func isSingleImage(node *html.Node) bool {
+	switch dom.TagName(node) {
+	case "img", "picture":
+		return true
+	}
+
+	children := dom.Children(node)
+	if len(children) != 1 || strings.TrimSpace(dom.TextContent(node)) != "" {
+		return false
+	}
+
+	return isSingleImage(children[0])
+}
+
+// isPlaceholderImage checks if the image URL is a placeholder, i.e. a tiny data URI
+// or a file whose name looks like placeholder (e.g. blank.gif and spacer.png).
+func isPlaceholderImage(url string) bool {
+	if strings.HasPrefix(strings.ToLower(url), "data:") {
+		return domutil.IsTinyDataURL(url)
+	}
+
+	// Only check the file name, since the placeholder words are
+	// commonly used in the path, e.g. "/lazy-images/photo.jpg".
+	fileName := url
+	if idx := strings.IndexAny(fileName, "?#"); idx >= 0 {
+		fileName = fileName[:idx]
+	}
+	fileName = path.Base(fileName)
+	fileName = strings.TrimSuffix(fileName, path.Ext(fileName))
+
+	return rxPlaceholderImage.MatchString(fileName)
+}
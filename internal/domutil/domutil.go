@@ -30,9 +30,11 @@ import (
 	"bytes"
 	nurl "net/url"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
 	"github.com/markusmobius/go-domdistiller/internal/stringutil"
 	"golang.org/x/net/html"
 )
@@ -215,6 +217,32 @@ func GetSrcSetURLs(node *html.Node) []string {
 	return urls
 }
 
+// ParseSrcSet parses the srcset value into list of image candidates. Candidate
+// without descriptor is treated as having 1x pixel density.
+func ParseSrcSet(srcset string) []data.ImageCandidate {
+	matches := rxSrcsetURL.FindAllStringSubmatch(srcset, -1)
+	candidates := make([]data.ImageCandidate, 0, len(matches))
+	for _, group := range matches {
+		candidate := data.ImageCandidate{URL: group[1], Density: 1}
+
+		descriptor := strings.TrimSpace(group[2])
+		if descriptor != "" {
+			value, _ := strconv.ParseFloat(descriptor[:len(descriptor)-1], 64)
+			switch descriptor[len(descriptor)-1] {
+			case 'w', 'W':
+				candidate.Width = int(value)
+				candidate.Density = 0
+			default:
+				candidate.Density = value
+			}
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
 func GetAllSrcSetURLs(root *html.Node) []string {
 	urls := GetSrcSetURLs(root)
 	for _, node := range dom.QuerySelectorAll(root, "[srcset]") {
@@ -548,3 +576,30 @@ func GetDisplayStyle(node *html.Node) string {
 	// In case I forgot any tags, fallback to block
 	return "block"
 }
+
+// GetMetaContents returns the content of <meta> tags whose name or property starts
+// with any of the prefixes, keyed by its lowercase name. The prefixes should be in
+// lowercase as well. A name might have several contents, e.g. the list of authors.
+func GetMetaContents(root *html.Node, prefixes ...string) map[string][]string {
+	contents := make(map[string][]string)
+	for _, meta := range dom.GetElementsByTagName(root, "meta") {
+		name := dom.GetAttribute(meta, "name")
+		if name == "" {
+			name = dom.GetAttribute(meta, "property")
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		content := strings.TrimSpace(dom.GetAttribute(meta, "content"))
+		if name == "" || content == "" {
+			continue
+		}
+
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, prefix) {
+				contents[name] = append(contents[name], content)
+				break
+			}
+		}
+	}
+	return contents
+}
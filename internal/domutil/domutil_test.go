@@ -32,6 +32,7 @@ import (
 	"testing"
 
 	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
 	"github.com/markusmobius/go-domdistiller/internal/domutil"
 	"github.com/markusmobius/go-domdistiller/internal/testutil"
 	"github.com/stretchr/testify/assert"
@@ -180,6 +181,17 @@ func Test_DomUtil_GetAllSrcSetURLs(t *testing.T) {
 	assert.Equal(t, "//example.org/image300", srcsetURLs[3])
 }
 
+func Test_DomUtil_ParseSrcSet(t *testing.T) {
+	candidates := domutil.ParseSrcSet("image.jpg, image-2x.jpg 2x, image-800.jpg 800w")
+	assert.Equal(t, []data.ImageCandidate{
+		{URL: "image.jpg", Density: 1},
+		{URL: "image-2x.jpg", Density: 2},
+		{URL: "image-800.jpg", Width: 800},
+	}, candidates)
+
+	assert.Empty(t, domutil.ParseSrcSet(""))
+}
+
 func Test_DomUtil_StripImageElements(t *testing.T) {
 	html := `<img id="a" alt="alt" dir="rtl" title="t" style="typo" align="left"` +
 		`src="image" class="a" srcset="image200 200w" data-dummy="a"/>` +
@@ -392,3 +404,13 @@ func Test_DomUtil_ZeroOrNoNodeDepth(t *testing.T) {
 	assert.Equal(t, 0, domutil.GetNodeDepth(div))
 	assert.Equal(t, -1, domutil.GetNodeDepth(nil))
 }
+
+func Test_DomUtil_IsTinyDataURL(t *testing.T) {
+	tinyGIF := "data:image/gif;base64,R0lGODlhAQABAAAAACH5BAEKAAEALAAAAAABAAEAAAICTAEAOw=="
+	assert.True(t, domutil.IsTinyDataURL(tinyGIF))
+	assert.True(t, domutil.IsTinyDataURL(`data:image/svg+xml,%3Csvg%3E%3C/svg%3E`))
+	assert.True(t, domutil.IsTinyDataURL("data:image/svg+xml;base64,"))
+	assert.False(t, domutil.IsTinyDataURL("data:image/svg+xml;base64,PHN2Zz48L3N2Zz4="))
+	assert.False(t, domutil.IsTinyDataURL("data:image/png;base64,"+strings.Repeat("A", 200)))
+	assert.False(t, domutil.IsTinyDataURL("http://example.com/image.gif"))
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package domutil
+
+import (
+	"regexp"
+	"strings"
+)
+
+var rxB64DataURL = regexp.MustCompile(`(?i)^data:\s*([^\s;,]+)\s*;\s*base64\s*,`)
+
+// LazyImageSrcAttrs is the attributes that commonly used by lazy loading
+// scripts to store the real src of an image, ordered by priority.
+var LazyImageSrcAttrs = []string{
+	"data-src",
+	"data-original",
+	"data-lazy-src",
+	"data-lazy",
+	"data-orig-src",
+	"data-full-src",
+	"data-hi-res-src",
+	"data-echo",
+	"data-url",
+	"datasrc",
+}
+
+// LazyImageSrcsetAttrs is the attributes that commonly used by lazy loading
+// scripts to store the real srcset of an image, ordered by priority.
+var LazyImageSrcsetAttrs = []string{
+	"data-srcset",
+	"data-lazy-srcset",
+	"datasrcset",
+}
+
+// IsTinyDataURL checks if the data URI is too small to be a real image,
+// e.g. the 1px GIF that used as placeholder by lazy loading scripts.
+func IsTinyDataURL(url string) bool {
+	if !strings.HasPrefix(strings.ToLower(url), "data:") {
+		return false
+	}
+
+	// Not encoded data URI is usually an inline SVG, which
+	// only used as placeholder if it's short.
+	parts := rxB64DataURL.FindStringSubmatch(url)
+	if len(parts) == 0 {
+		return len(url) < 200
+	}
+
+	// SVG can have a meaningful image in tiny size, so it's
+	// only a placeholder when it's completely empty.
+	if strings.EqualFold(parts[1], "image/svg+xml") {
+		return len(url) <= len(parts[0])
+	}
+
+	// If image is less than 100 bytes (or 133B after encoded
+	// to base64), it will be too small to be a real image.
+	return len(url)-len(parts[0]) < 133
+}
@@ -43,92 +43,35 @@
 package extractor
 
 import (
-	"github.com/markusmobius/go-domdistiller/internal/filter/english"
-	"github.com/markusmobius/go-domdistiller/internal/filter/heuristic"
-	"github.com/markusmobius/go-domdistiller/internal/filter/simple"
-	"github.com/markusmobius/go-domdistiller/internal/label"
 	"github.com/markusmobius/go-domdistiller/internal/logutil"
-	"github.com/markusmobius/go-domdistiller/internal/stringutil"
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/markusmobius/go-domdistiller/pipeline"
 )
 
 type ArticleExtractor struct {
-	logger logutil.Logger
+	logger   logutil.Logger
+	pipeline *pipeline.Pipeline
 }
 
-func NewArticleExtractor(logger logutil.Logger) *ArticleExtractor {
-	return &ArticleExtractor{logger: logger}
-}
-
-// Extract extracts TextDocument. It is tuned towards news articles.
-func (ae *ArticleExtractor) Extract(doc *webdoc.TextDocument, wc stringutil.WordCounter, candidateTitles []string) bool {
-	// Prepare filters
-	terminatingBlocksFinder := english.NewTerminatingBlocksFinder()
-	documentTitleMatch := heuristic.NewDocumentTitleMatch(wc, candidateTitles...)
-	numWordsRulesClassifier := english.NewNumWordsRulesClassifier()
-	labelNotContentToBoilerplate := simple.NewLabelToBoilerplate(label.StrictlyNotContent)
-
-	similarSiblingContentExpansion1 := heuristic.NewSimilarSiblingContentExpansion()
-	similarSiblingContentExpansion1.AllowCrossHeadings = true
-	similarSiblingContentExpansion1.MaxLinkDensity = 0.5
-	similarSiblingContentExpansion1.MaxBlockDistance = 10
-
-	similarSiblingContentExpansion2 := heuristic.NewSimilarSiblingContentExpansion()
-	similarSiblingContentExpansion2.AllowCrossHeadings = true
-	similarSiblingContentExpansion2.AllowMixedTags = true
-	similarSiblingContentExpansion2.MaxBlockDistance = 10
+// NewArticleExtractor creates ArticleExtractor that uses the specified pipeline.
+// If the pipeline is nil, pipeline.Default will be used.
+func NewArticleExtractor(logger logutil.Logger, p *pipeline.Pipeline) *ArticleExtractor {
+	if p == nil {
+		p = pipeline.Default()
+	}
 
-	headingFusion := heuristic.NewHeadingFusion()
-	blockProximityFusionPre := heuristic.NewBlockProximityFusion(false)
-	boilerplateBlockKeepTitle := simple.NewBoilerplateBlock(label.Title)
-	blockProximityFusionPost := heuristic.NewBlockProximityFusion(true)
-	keepLargestBlockExpandToSibling := heuristic.NewKeepLargestBlock(true)
-	expandTitleToContent := heuristic.NewExpandTitleToContent()
-	largeBlockAroundTagLevel := heuristic.NewLargeBlockAroundTagLevelToContent()
-	listAtEnd := heuristic.NewListAtEnd()
+	return &ArticleExtractor{logger: logger, pipeline: p}
+}
 
+// Extract extracts TextDocument. By default it is tuned towards news articles.
+func (ae *ArticleExtractor) Extract(doc *webdoc.TextDocument, info pipeline.DocumentInfo) bool {
 	ae.printArticleLog(doc, true, "Start")
 
-	// Run filters
-	// Intentionally don't print changes from these two
-	terminatingBlocksFinder.Process(doc)
-	documentTitleMatch.Process(doc)
-
-	changed := numWordsRulesClassifier.Process(doc)
-	ae.printArticleLog(doc, changed, "Classification complete")
-
-	changed = labelNotContentToBoilerplate.Process(doc)
-	ae.printArticleLog(doc, changed, "Ignore strictly not content blocks")
-
-	changed = similarSiblingContentExpansion1.Process(doc)
-	ae.printArticleLog(doc, changed, "Cross headings SimilarSiblingContentExpansion")
-
-	changed = similarSiblingContentExpansion2.Process(doc)
-	ae.printArticleLog(doc, changed, "Mixed tags SimilarSiblingContentExpansion")
-
-	changed = headingFusion.Process(doc)
-	ae.printArticleLog(doc, changed, "HeadingFusion")
-
-	changed = blockProximityFusionPre.Process(doc)
-	ae.printArticleLog(doc, changed, "BlockProximityFusion for distance=1")
-
-	changed = boilerplateBlockKeepTitle.Process(doc)
-	ae.printArticleLog(doc, changed, "BlockFilter keep title")
-
-	changed = blockProximityFusionPost.Process(doc)
-	ae.printArticleLog(doc, changed, "BlockProximityFusion for same level content-only")
-
-	changed = keepLargestBlockExpandToSibling.Process(doc)
-	ae.printArticleLog(doc, changed, "Keep largest block")
-
-	changed = expandTitleToContent.Process(doc)
-	ae.printArticleLog(doc, changed, "Expand title to content")
-
-	changed = largeBlockAroundTagLevel.Process(doc)
-	ae.printArticleLog(doc, changed, "Largest block with same tag level to content")
-
-	changed = listAtEnd.Process(doc)
-	ae.printArticleLog(doc, changed, "List at end filter")
+	ae.pipeline.Run(doc, info, func(stage pipeline.Stage, changed bool) {
+		if !stage.SkipLog {
+			ae.printArticleLog(doc, changed, stage.Name)
+		}
+	})
 
 	return true
 }
@@ -0,0 +1,189 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extractor
+
+import (
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/authorutil"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+	"golang.org/x/net/html"
+)
+
+// ExtractAuthors returns the normalized list of authors of the document. The authors
+// from markup (including the one from site rule) are preferred, while the bylines in
+// the page are only used as fallback or to complete the profile URL.
+func (ce *ContentExtractor) ExtractAuthors() []data.Author {
+	bylineAuthors := extractBylineAuthors(ce.bylines, ce.pageURL)
+
+	info := ce.MarkupInfo()
+	markupNames := info.Article.Authors
+	if len(markupNames) == 0 && info.Author != "" {
+		markupNames = []string{info.Author}
+	}
+
+	var authors []data.Author
+	for _, markupName := range markupNames {
+		markupName = strings.TrimSpace(markupName)
+
+		// Some sites (e.g. in OpenGraph's article:author) use
+		// profile URL instead of name. Look for its name in bylines.
+		if isAbsoluteURL(markupName) {
+			for _, author := range bylineAuthors {
+				if author.URL == markupName {
+					authors = append(authors, author)
+					break
+				}
+			}
+			continue
+		}
+
+		for _, name := range authorutil.Split(markupName) {
+			author := data.Author{Name: name}
+			for _, bylineAuthor := range bylineAuthors {
+				if strings.EqualFold(bylineAuthor.Name, name) {
+					author.URL = bylineAuthor.URL
+					break
+				}
+			}
+			authors = append(authors, author)
+		}
+	}
+
+	if len(authors) == 0 {
+		return bylineAuthors
+	}
+
+	return mergeAuthors(authors)
+}
+
+// extractBylineAuthors extracts the authors from byline elements, i.e. elements
+// whose class or ID mention author, `rel=author` links and `itemprop=author`.
+func extractBylineAuthors(bylines []*html.Node, pageURL *nurl.URL) []data.Author {
+	var authors []data.Author
+	for _, byline := range bylines {
+		authors = append(authors, authorsFromByline(byline, pageURL)...)
+	}
+	return mergeAuthors(authors)
+}
+
+func authorsFromByline(byline *html.Node, pageURL *nurl.URL) []data.Author {
+	// Schema.org microdata, where the name is put in its own element.
+	if strings.Contains(dom.GetAttribute(byline, "itemprop"), "author") {
+		if nameNode := dom.QuerySelector(byline, `[itemprop~="name"]`); nameNode != nil {
+			name := dom.GetAttribute(nameNode, "content")
+			if name == "" {
+				name = domutil.InnerText(nameNode)
+			}
+
+			if name = authorutil.NormalizeName(name); name == "" {
+				return nil
+			}
+
+			var profileURL string
+			if urlNode := dom.QuerySelector(byline, `[itemprop~="url"]`); urlNode != nil {
+				profileURL = dom.GetAttribute(urlNode, "href")
+				if profileURL == "" {
+					profileURL = dom.GetAttribute(urlNode, "content")
+				}
+			} else {
+				profileURL = linkURL(byline)
+			}
+
+			return []data.Author{{
+				Name: name,
+				URL:  stringutil.CreateAbsoluteURL(profileURL, pageURL),
+			}}
+		}
+	}
+
+	// Links in byline are usually pointed to the author's profile page.
+	links := []*html.Node{byline}
+	if dom.TagName(byline) != "a" {
+		links = dom.QuerySelectorAll(byline, "a[href]")
+	}
+
+	var authors []data.Author
+	for _, link := range links {
+		href := dom.GetAttribute(link, "href")
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") {
+			continue
+		}
+
+		if name := authorutil.NormalizeName(domutil.InnerText(link)); name != "" {
+			authors = append(authors, data.Author{
+				Name: name,
+				URL:  stringutil.CreateAbsoluteURL(href, pageURL),
+			})
+		}
+	}
+
+	if len(authors) > 0 {
+		return authors
+	}
+
+	// If there are no links, use the plain text.
+	for _, name := range authorutil.Split(domutil.InnerText(byline)) {
+		authors = append(authors, data.Author{Name: name})
+	}
+
+	return authors
+}
+
+// mergeAuthors removes the duplicate authors. If the duplicates
+// have different profile URL, the first non empty URL is used.
+func mergeAuthors(authors []data.Author) []data.Author {
+	var merged []data.Author
+	indexes := make(map[string]int)
+	for _, author := range authors {
+		key := strings.ToLower(author.Name)
+		if idx, exist := indexes[key]; exist {
+			if merged[idx].URL == "" {
+				merged[idx].URL = author.URL
+			}
+			continue
+		}
+
+		indexes[key] = len(merged)
+		merged = append(merged, author)
+	}
+	return merged
+}
+
+func linkURL(node *html.Node) string {
+	if dom.TagName(node) == "a" {
+		return dom.GetAttribute(node, "href")
+	}
+
+	if link := dom.QuerySelector(node, "a[href]"); link != nil {
+		return dom.GetAttribute(link, "href")
+	}
+
+	return ""
+}
+
+func isAbsoluteURL(str string) bool {
+	return strings.HasPrefix(str, "http://") || strings.HasPrefix(str, "https://")
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extractor_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/extractor"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Extractor_Byline_PlainText(t *testing.T) {
+	authors := extractAuthors(`<div class="byline">By Jane Doe and John Smith, October 12, 2020</div>`)
+	assert.Equal(t, []data.Author{
+		{Name: "Jane Doe"},
+		{Name: "John Smith"},
+	}, authors)
+}
+
+func Test_Extractor_Byline_ProfileLinks(t *testing.T) {
+	authors := extractAuthors(`<p class="article-author">Written by ` +
+		`<a href="/people/jane">Jane Doe</a> &amp; <a href="/people/john">John Smith</a></p>` +
+		`<div class="author"><a href="/people/jane">Jane Doe</a></div>`)
+	assert.Equal(t, []data.Author{
+		{Name: "Jane Doe", URL: "http://example.com/people/jane"},
+		{Name: "John Smith", URL: "http://example.com/people/john"},
+	}, authors)
+}
+
+func Test_Extractor_Byline_Microdata(t *testing.T) {
+	authors := extractAuthors(`<span itemprop="author" itemscope itemtype="http://schema.org/Person">` +
+		`<a itemprop="url" href="/people/jane"><span itemprop="name">Jane Doe</span></a></span>`)
+	assert.Equal(t, []data.Author{
+		{Name: "Jane Doe", URL: "http://example.com/people/jane"},
+	}, authors)
+}
+
+func Test_Extractor_Byline_PreferMarkup(t *testing.T) {
+	// Author from markup is used, but the profile URL is taken from byline.
+	doc, body := createHTML()
+	script := dom.CreateElement("script")
+	dom.SetAttribute(script, "type", "application/ld+json")
+	dom.SetTextContent(script, `{"@type": "NewsArticle", "author": {"@type": "Person", "name": "John Smith"}}`)
+	dom.AppendChild(dom.QuerySelector(doc, "head"), script)
+
+	dom.SetInnerHTML(body, `<div class="byline">By <a href="/people/jane">Jane Doe</a></div>`+
+		`<div class="author"><a href="/people/john">John Smith</a></div>`+
+		`<p>`+contentText+`</p>`)
+
+	ce := extractor.NewContentExtractor(doc, testPageURL(), nil)
+	ce.ExtractContent()
+	assert.Equal(t, []data.Author{
+		{Name: "John Smith", URL: "http://example.com/people/john"},
+	}, ce.ExtractAuthors())
+}
+
+func extractAuthors(bylineHTML string) []data.Author {
+	doc, body := createHTML()
+	dom.SetInnerHTML(body, bylineHTML+"<p>"+contentText+"</p>")
+
+	ce := extractor.NewContentExtractor(doc, testPageURL(), nil)
+	ce.ExtractContent()
+	return ce.ExtractAuthors()
+}
+
+func testPageURL() *nurl.URL {
+	pageURL, _ := nurl.Parse("http://example.com/article/")
+	return pageURL
+}
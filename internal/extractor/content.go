@@ -27,17 +27,24 @@
 package extractor
 
 import (
+	"context"
 	nurl "net/url"
+	"strings"
 	"time"
 
 	"github.com/go-shiori/dom"
 	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/authorutil"
 	"github.com/markusmobius/go-domdistiller/internal/converter"
-	"github.com/markusmobius/go-domdistiller/internal/filter/docfilter"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
 	"github.com/markusmobius/go-domdistiller/internal/logutil"
 	"github.com/markusmobius/go-domdistiller/internal/markup"
 	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+	"github.com/markusmobius/go-domdistiller/internal/timeutil"
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/markusmobius/go-domdistiller/pipeline"
+	"github.com/markusmobius/go-domdistiller/siterule"
+	"github.com/markusmobius/go-domdistiller/srcset"
 	"golang.org/x/net/html"
 )
 
@@ -49,11 +56,43 @@ type ContentExtractor struct {
 	Parser      *markup.Parser
 	TimingInfo  *data.TimingInfo
 	ImageURLs   []string
+	Images      []data.ArticleImage
 	WordCounter stringutil.WordCounter
 
+	// Pipeline is the chain of filters that used to find the content.
+	// If nil, pipeline.Default will be used.
+	Pipeline *pipeline.Pipeline
+
+	// DocumentPipeline is the chain of filters that processes the document after
+	// the content is found. If nil, pipeline.DefaultDocument will be used.
+	DocumentPipeline *pipeline.DocumentPipeline
+
+	// SiteRule is the extraction rule for the current site, which
+	// consulted before running the heuristics. Might be nil.
+	SiteRule *siterule.Rule
+
+	// Language is the BCP-47 tag of the page's language. If empty, the
+	// language is detected from the page.
+	Language string
+
+	// ContentLanguage is the value of Content-Language header of the page.
+	ContentLanguage string
+
+	// ImageResolver is used to replace the src of images with the best candidate
+	// from their srcset and <picture> sources. If nil, images are kept as they are.
+	ImageResolver *srcset.Resolver
+
+	// ImageBlocklist is the list of strings that, when found in the image URL, makes the
+	// image removed from the content. It's used along with the built-in heuristics that
+	// remove tracking pixels, icons, avatars, emoji and ads.
+	ImageBlocklist []string
+
 	pageURL         *nurl.URL
 	documentElement *html.Node
 	candidateTitles []string
+	bylines         []*html.Node
+	language        *data.Language
+	leadImage       *data.LeadImage
 	logger          logutil.Logger
 }
 
@@ -91,29 +130,127 @@ func (ce *ContentExtractor) ExtractTitle() string {
 }
 
 func (ce *ContentExtractor) ExtractContent() (*webdoc.Document, int) {
+	webDocument, wordCount, _ := ce.ExtractContentContext(context.Background())
+	return webDocument, wordCount
+}
+
+// ExtractContentContext is like ExtractContent but it checks the context between each
+// major stages, i.e. DOM conversion, article extraction and document filtering. If the
+// context is done, the extraction is stopped and CancelledError is returned.
+func (ce *ContentExtractor) ExtractContentContext(ctx context.Context) (*webdoc.Document, int, error) {
 	start := time.Now()
-	webDocument := ce.createWebDocumentInfoFromPage(converter.SkipUnlikelies)
-	wordCount := ce.processDocument(webDocument)
+	if err := CheckContext(ctx, StageDomConversion); err != nil {
+		return nil, 0, err
+	}
 
+	language := ce.ExtractLanguage().Tag
+	webDocument, dc := ce.createWebDocumentInfoFromPage(converter.SkipUnlikelies)
+	if err := CheckContext(ctx, StageArticleExtraction); err != nil {
+		return nil, 0, err
+	}
+
+	wordCount := ce.processDocument(webDocument, dc, language)
+	ce.bylines = dc.Bylines()
 	if wordCount < documentCharThreshold {
-		webDocument = ce.createWebDocumentInfoFromPage(converter.Default)
-		wordCount = ce.processDocument(webDocument)
+		if err := CheckContext(ctx, StageDomConversion); err != nil {
+			return nil, 0, err
+		}
+
+		webDocument, dc = ce.createWebDocumentInfoFromPage(converter.Default)
+		if err := CheckContext(ctx, StageArticleExtraction); err != nil {
+			return nil, 0, err
+		}
+
+		wordCount = ce.processDocument(webDocument, dc, language)
+		ce.bylines = dc.Bylines()
 	}
 
 	ce.TimingInfo.DocumentConstructionTime = time.Now().Sub(start)
+	if err := CheckContext(ctx, StageDocumentFiltering); err != nil {
+		return nil, 0, err
+	}
 
 	start = time.Now()
-	docfilter.NewRelevantElements().Process(webDocument)
-	docfilter.NewLeadImageFinder(ce.logger).Process(webDocument)
-	docfilter.NewNestedElementRetainer().Process(webDocument)
+	documentPipeline := ce.DocumentPipeline
+	if documentPipeline == nil {
+		documentPipeline = pipeline.DefaultDocument()
+	}
+
+	documentPipeline.Run(webDocument, ce.documentInfo(language))
 	ce.TimingInfo.ArticleProcessingTime = time.Now().Sub(start)
 
 	ce.ImageURLs = webDocument.GetImageURLs()
-	return webDocument, wordCount
+	ce.Images = webDocument.GetImages()
+	ce.leadImage = webDocument.LeadImage
+	return webDocument, wordCount, nil
+}
+
+// MarkupInfo returns the metadata from markup parser. If the site rule has selector
+// for author or publication date, the value from selector will be used instead.
+func (ce *ContentExtractor) MarkupInfo() data.MarkupInfo {
+	info := ce.Parser.MarkupInfo()
+	if ce.SiteRule == nil {
+		return info
+	}
+
+	if author := ce.selectText(ce.SiteRule.Author); author != "" {
+		info.Author = author
+		info.Article.Authors = []string{author}
+		info.Article.AuthorNames = authorutil.Split(author)
+		overrideMarkupField(&info, data.MarkupFieldAuthor, author)
+		info.Sources[data.MarkupFieldAuthors] = data.MarkupFromSiteRule
+	}
+
+	if date := ce.siteRuleDate(); date != "" {
+		info.Article.PublishedTime = date
+		info.Article.Published, _ = timeutil.ParseOrFind(date)
+		overrideMarkupField(&info, data.MarkupFieldPublishedTime, date)
+	}
+
+	return info
+}
+
+// overrideMarkupField marks the field in MarkupInfo as taken from site rule,
+// and puts the value as its first candidate.
+func overrideMarkupField(info *data.MarkupInfo, field data.MarkupField, value string) {
+	if info.Sources == nil {
+		info.Sources = make(map[data.MarkupField]data.MarkupSource)
+	}
+	if info.Candidates == nil {
+		info.Candidates = make(map[data.MarkupField][]data.MarkupCandidate)
+	}
+
+	candidate := data.MarkupCandidate{Value: value, Source: data.MarkupFromSiteRule}
+	info.Sources[field] = data.MarkupFromSiteRule
+	info.Candidates[field] = append([]data.MarkupCandidate{candidate}, info.Candidates[field]...)
+}
+
+// siteRuleDate returns the publication date from element that selected by
+// site rule's date selector. The date is not parsed yet.
+func (ce *ContentExtractor) siteRuleDate() string {
+	if ce.SiteRule == nil || ce.SiteRule.Date == "" {
+		return ""
+	}
+
+	node := dom.QuerySelector(ce.documentElement, ce.SiteRule.Date)
+	if node == nil {
+		return ""
+	}
+
+	date := dom.GetAttribute(node, "datetime")
+	if date == "" {
+		date = dom.GetAttribute(node, "content")
+	}
+	if date == "" {
+		date = domutil.InnerText(node)
+	}
+
+	return strings.TrimSpace(date)
 }
 
 // ensureTitleInitialized populates list of candidate titles in
 // descending priority order:
+// 0) The element from site rule's title selector
 // 1) meta-information
 // 2) The document's title element, modified based on some readability heuristics
 // 3) The document's title element, if it's a string
@@ -122,6 +259,12 @@ func (ce *ContentExtractor) ensureTitleInitialized() {
 		return
 	}
 
+	if ce.SiteRule != nil {
+		if title := ce.selectText(ce.SiteRule.Title); title != "" {
+			ce.candidateTitles = append(ce.candidateTitles, title)
+		}
+	}
+
 	title := ce.Parser.Title()
 	if title != "" {
 		ce.candidateTitles = append(ce.candidateTitles, title)
@@ -132,23 +275,115 @@ func (ce *ContentExtractor) ensureTitleInitialized() {
 }
 
 // createWebDocumentInfoFromPage converts the original HTML page into a webdoc.Document for analysis.
-func (ce *ContentExtractor) createWebDocumentInfoFromPage(flags converter.ConverterFlag) *webdoc.Document {
+// If the site rule has content root that exists in the page, only the content root is converted.
+func (ce *ContentExtractor) createWebDocumentInfoFromPage(flags converter.ConverterFlag) (*webdoc.Document, *converter.DomConverter) {
+	root := ce.contentRoot()
+	if root == nil {
+		root = ce.documentElement
+	}
+
 	docBuilder := webdoc.NewWebDocumentBuilder(ce.WordCounter, ce.pageURL)
-	converter.NewDomConverter(flags, docBuilder, ce.pageURL, ce.logger).Convert(ce.documentElement)
+	dc := converter.NewDomConverter(flags, docBuilder, ce.pageURL, ce.logger)
+	dc.SiteRule = ce.SiteRule
+	dc.Convert(root)
+
 	webDocument := docBuilder.Build()
 	ce.ensureTitleInitialized()
-	return webDocument
+	return webDocument, dc
+}
+
+// documentInfo returns the information about the page that needed by the pipelines.
+func (ce *ContentExtractor) documentInfo(language string) pipeline.DocumentInfo {
+	return pipeline.DocumentInfo{
+		WordCounter:     ce.WordCounter,
+		CandidateTitles: ce.candidateTitles,
+		Language:        language,
+		ImageBlocklist:  ce.ImageBlocklist,
+		ImageResolver:   ce.ImageResolver,
+		Logger:          ce.logger,
+	}
 }
 
 // processDocument do the actual analysis of the page content,
 // identifying the core elements of the page. Returns word count
 // inside document.
-func (ce *ContentExtractor) processDocument(doc *webdoc.Document) int {
+func (ce *ContentExtractor) processDocument(doc *webdoc.Document, dc *converter.DomConverter, language string) int {
 	textDocument := doc.CreateTextDocument()
 
-	NewArticleExtractor(ce.logger).Extract(textDocument, ce.WordCounter, ce.candidateTitles)
-	wordCount := textDocument.CountWordsInContent()
+	// If content root from site rule is used, every block is content.
+	if ce.contentRoot() != nil {
+		for _, tb := range textDocument.TextBlocks {
+			tb.SetIsContent(true)
+		}
+	} else {
+		NewArticleExtractor(ce.logger, ce.Pipeline).Extract(textDocument, ce.documentInfo(language))
+	}
 
+	wordCount := textDocument.CountWordsInContent()
 	textDocument.ApplyToModel()
+
+	// Mark elements from site rule's always content selectors as content,
+	// regardless of what decided by the pipeline.
+	for _, e := range doc.Elements {
+		if e.IsContent() || !isAlwaysContent(e, dc) {
+			continue
+		}
+
+		e.SetIsContent(true)
+		if text, isText := e.(*webdoc.Text); isText {
+			wordCount += text.NumWords
+		}
+	}
+
 	return wordCount
 }
+
+// contentRoot returns the element that selected by content root selector in
+// site rule. Returns nil if there are no site rule or the element not found.
+func (ce *ContentExtractor) contentRoot() *html.Node {
+	if ce.SiteRule == nil || ce.SiteRule.ContentRoot == "" {
+		return nil
+	}
+
+	return dom.QuerySelector(ce.documentElement, ce.SiteRule.ContentRoot)
+}
+
+// selectText returns the normalized text of the first element
+// that matched with the selector.
+func (ce *ContentExtractor) selectText(selector string) string {
+	if selector == "" {
+		return ""
+	}
+
+	node := dom.QuerySelector(ce.documentElement, selector)
+	if node == nil {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(domutil.InnerText(node)), " ")
+}
+
+func isAlwaysContent(e webdoc.Element, dc *converter.DomConverter) bool {
+	var node *html.Node
+	switch element := e.(type) {
+	case *webdoc.Text:
+		for _, textNode := range element.TextNodes {
+			if dc.IsAlwaysContent(textNode) {
+				return true
+			}
+		}
+		return false
+	case *webdoc.Image:
+		node = element.Element
+	case *webdoc.Figure:
+		node = element.Element
+	case *webdoc.Embed:
+		node = element.Element
+	case *webdoc.Table:
+		node = element.Element
+	case *webdoc.Video:
+		node = element.Element
+	}
+
+	return node != nil && dc.IsAlwaysContent(node)
+}
@@ -27,6 +27,7 @@
 package extractor_test
 
 import (
+	"context"
 	nurl "net/url"
 	"strings"
 	"testing"
@@ -36,6 +37,7 @@ import (
 	"github.com/markusmobius/go-domdistiller/internal/extractor"
 	"github.com/markusmobius/go-domdistiller/internal/markup/opengraph"
 	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/markusmobius/go-domdistiller/siterule"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/html"
 )
@@ -739,3 +741,76 @@ func extractContent(ce *extractor.ContentExtractor) string {
 	extractedDocument, _ := ce.ExtractContent()
 	return extractedDocument.GenerateOutput(false)
 }
+
+func Test_Extractor_Content_CancelledContext(t *testing.T) {
+	doc, body := createHTML()
+	dom.AppendChild(body, testutil.CreateParagraph(contentText))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ce := extractor.NewContentExtractor(doc, nil, nil)
+	extractedDocument, wordCount, err := ce.ExtractContentContext(ctx)
+	assert.Nil(t, extractedDocument)
+	assert.Equal(t, 0, wordCount)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var cancelledErr *extractor.CancelledError
+	assert.ErrorAs(t, err, &cancelledErr)
+	assert.Equal(t, extractor.StageDomConversion, cancelledErr.Stage)
+
+	// Without cancellation the result should be the same as ExtractContent.
+	ce = extractor.NewContentExtractor(doc, nil, nil)
+	extractedDocument, _, err = ce.ExtractContentContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, extractContent(extractor.NewContentExtractor(doc, nil, nil)),
+		extractedDocument.GenerateOutput(false))
+}
+
+func Test_Extractor_Content_SiteRule(t *testing.T) {
+	doc, body := createHTML()
+	dom.SetInnerHTML(body, `<h1 class="headline">Site rule title</h1>`+
+		`<div class="meta"><span class="author"> John   Doe </span>`+
+		`<time datetime="2020-10-30T10:00:00Z">30 Oct 2020</time></div>`+
+		`<div id="story"><p>`+contentText+`</p><p class="promo">Subscribe now</p></div>`+
+		`<div class="related"><p>Related stuff</p></div>`)
+
+	ce := extractor.NewContentExtractor(doc, nil, nil)
+	ce.SiteRule = &siterule.Rule{
+		ContentRoot: "#story",
+		Strip:       []string{".promo"},
+		Title:       "h1.headline",
+		Author:      ".meta .author",
+		Date:        ".meta time",
+	}
+
+	// Only the content root is used, even though the content is short
+	extractedDocument, wordCount := ce.ExtractContent()
+	assert.Equal(t, "<p>"+contentText+"</p>", extractedDocument.GenerateOutput(false))
+	assert.Equal(t, 6, wordCount)
+
+	assert.Equal(t, "Site rule title", ce.ExtractTitle())
+
+	markupInfo := ce.MarkupInfo()
+	assert.Equal(t, "John Doe", markupInfo.Author)
+	assert.Equal(t, []string{"John Doe"}, markupInfo.Article.Authors)
+	assert.Equal(t, "2020-10-30T10:00:00Z", markupInfo.Article.PublishedTime)
+}
+
+func Test_Extractor_Content_SiteRuleAlwaysContent(t *testing.T) {
+	rawHTML := `<p>` + strings.Repeat(contentText+" ", 10) + `</p>` +
+		`<ul class="links"><li><a href="/a">Link A</a></li><li><a href="/b">Link B</a></li></ul>` +
+		`<p>` + strings.Repeat(contentText+" ", 10) + `</p>` +
+		`<div class="footer"><a href="/c">Important footnote</a></div>`
+
+	doc, body := createHTML()
+	dom.SetInnerHTML(body, rawHTML)
+	ce := extractor.NewContentExtractor(doc, nil, nil)
+	assert.NotContains(t, extractContent(ce), "Important footnote")
+
+	doc, body = createHTML()
+	dom.SetInnerHTML(body, rawHTML)
+	ce = extractor.NewContentExtractor(doc, nil, nil)
+	ce.SiteRule = &siterule.Rule{AlwaysContent: []string{".footer"}}
+	assert.Contains(t, extractContent(ce), "Important footnote")
+}
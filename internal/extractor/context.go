@@ -0,0 +1,87 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Names of the major stages in distillation process. They are used
+// in CancelledError to tell where the distillation is stopped.
+const (
+	StageFetching          = "fetching"
+	StageHTMLParsing       = "html parsing"
+	StageMarkupParsing     = "markup parsing"
+	StageDomConversion     = "dom conversion"
+	StageArticleExtraction = "article extraction"
+	StageDocumentFiltering = "document filtering"
+	StagePagination        = "pagination"
+)
+
+// CancelledError is returned when the context is cancelled or its
+// deadline is exceeded before the distillation is finished.
+type CancelledError struct {
+	// Stage is the name of stage that about to be started when
+	// the cancellation is detected.
+	Stage string
+
+	// Err is the error from the context, i.e. either context.Canceled
+	// or context.DeadlineExceeded.
+	Err error
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("distillation cancelled before %s: %v", e.Stage, e.Err)
+}
+
+func (e *CancelledError) Unwrap() error {
+	return e.Err
+}
+
+// CheckContext returns CancelledError if the context is already done.
+func CheckContext(ctx context.Context, stage string) error {
+	if err := ctx.Err(); err != nil {
+		return &CancelledError{Stage: stage, Err: err}
+	}
+	return nil
+}
+
+// ContextReader is io.Reader that stops reading once the context is done, by returning
+// the context error from Read. It's used to abort the HTML parsing of a large or slow
+// input, since the parser itself doesn't accept any context.
+type ContextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// NewContextReader returns ContextReader that reads from r until ctx is done.
+func NewContextReader(ctx context.Context, r io.Reader) *ContextReader {
+	return &ContextReader{ctx: ctx, r: r}
+}
+
+func (cr *ContextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extractor
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"github.com/markusmobius/go-domdistiller/internal/timeutil"
+	"golang.org/x/net/html"
+)
+
+// Score of each date source. The candidate with highest score is used.
+const (
+	siteRuleDateScore       = 100
+	markupDateScore         = 90
+	metaDateScore           = 80
+	markedTimeElementScore  = 75
+	timeElementScore        = 60
+	datelineScore           = 50
+	urlDateScore            = 40
+	urlMonthScore           = 20
+	maxDatelineLength       = 100
+	maxFutureDateDifference = 48 * time.Hour
+
+	// maxDateRegionLevels is how many ancestors of the title heading whose
+	// siblings are used as the date region.
+	maxDateRegionLevels = 2
+
+	// maxDateRegionSiblings is how many siblings after the title heading
+	// (and after its ancestors) that used as the date region.
+	maxDateRegionSiblings = 3
+)
+
+var (
+	rxDatelineHint  = regexp.MustCompile(`(?i)(?:^|[\s_-])(?:date|dateline|time|timestamp|stamp|published|publish|posted|pubdate|byline|meta)(?:[\s_-]|$)`)
+	rxModifiedHint  = regexp.MustCompile(`(?i)updat|modif|edited|revis`)
+	rxPublishedHint = regexp.MustCompile(`(?i)publish|posted|pubdate|created`)
+	rxURLDate       = regexp.MustCompile(`/((?:19|20)\d{2})[/-](\d{1,2})[/-](\d{1,2})(?:[/\-_.]|$)`)
+	rxURLMonth      = regexp.MustCompile(`/((?:19|20)\d{2})/(\d{1,2})(?:/|$)`)
+
+	publishedMetaNames = map[string]struct{}{
+		"article:published_time": {},
+		"article.published":      {},
+		"datepublished":          {},
+		"date":                   {},
+		"dc.date":                {},
+		"dc.date.issued":         {},
+		"dcterms.created":        {},
+		"dcterms.date":           {},
+		"dcterms.issued":         {},
+		"og:published_time":      {},
+		"parsely-pub-date":       {},
+		"pubdate":                {},
+		"publish-date":           {},
+		"publishdate":            {},
+		"publish_date":           {},
+		"sailthru.date":          {},
+	}
+
+	modifiedMetaNames = map[string]struct{}{
+		"article:modified_time": {},
+		"datemodified":          {},
+		"dc.date.modified":      {},
+		"dcterms.modified":      {},
+		"last-modified":         {},
+		"lastmod":               {},
+		"og:updated_time":       {},
+	}
+)
+
+type dateCandidate struct {
+	date  data.ExtractedDate
+	score int
+}
+
+// dateCandidates is list of date candidates, sorted by the order they are found.
+type dateCandidates []dateCandidate
+
+func (dc *dateCandidates) add(raw string, source data.DateSource, score int, parse func(string) (time.Time, bool)) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return
+	}
+
+	t, ok := parse(raw)
+	if !ok || !isPlausibleDate(t) {
+		return
+	}
+
+	*dc = append(*dc, dateCandidate{
+		date:  data.ExtractedDate{Time: t, Source: source, Raw: raw},
+		score: score,
+	})
+}
+
+// best returns the candidate with highest score. If several candidates
+// have the same score, the one which found first is used.
+func (dc dateCandidates) best() *data.ExtractedDate {
+	var best *dateCandidate
+	for i, candidate := range dc {
+		if best == nil || candidate.score > best.score {
+			best = &dc[i]
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	date := best.date
+	return &date
+}
+
+// ExtractDates returns the publication and modification date of the document. The
+// candidates are collected from site rule, metadata, <meta> tags, <time> elements,
+// datelines and the URL, then the one with highest score is used. The <time> elements
+// and datelines are only taken from the region around the title (see dateRegion).
+// Returns nil if the date is not found.
+func (ce *ContentExtractor) ExtractDates() (published, modified *data.ExtractedDate) {
+	var publishedCandidates, modifiedCandidates dateCandidates
+
+	// Site rule and metadata
+	publishedCandidates.add(ce.siteRuleDate(), data.DateFromSiteRule, siteRuleDateScore, timeutil.ParseOrFind)
+
+	article := ce.Parser.MarkupInfo().Article
+	publishedCandidates.add(article.PublishedTime, data.DateFromMarkup, markupDateScore, timeutil.ParseOrFind)
+	modifiedCandidates.add(article.ModifiedTime, data.DateFromMarkup, markupDateScore, timeutil.ParseOrFind)
+
+	// Other <meta> tags
+	for _, meta := range dom.GetElementsByTagName(ce.documentElement, "meta") {
+		name := dom.GetAttribute(meta, "name")
+		if name == "" {
+			name = dom.GetAttribute(meta, "property")
+		}
+		if name == "" {
+			name = dom.GetAttribute(meta, "itemprop")
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		content := dom.GetAttribute(meta, "content")
+		if _, isPublished := publishedMetaNames[name]; isPublished {
+			publishedCandidates.add(content, data.DateFromMeta, metaDateScore, timeutil.Parse)
+		} else if _, isModified := modifiedMetaNames[name]; isModified {
+			modifiedCandidates.add(content, data.DateFromMeta, metaDateScore, timeutil.Parse)
+		}
+	}
+
+	// Elements around the title
+	var regionElements []*html.Node
+	for _, root := range ce.dateRegion() {
+		regionElements = append(regionElements, root)
+		regionElements = append(regionElements, dom.GetElementsByTagName(root, "*")...)
+	}
+
+	// <time> elements
+	for _, timeElement := range regionElements {
+		if dom.TagName(timeElement) != "time" {
+			continue
+		}
+
+		raw := dom.GetAttribute(timeElement, "datetime")
+		if raw == "" {
+			raw = domutil.InnerText(timeElement)
+		}
+
+		hint := dom.GetAttribute(timeElement, "itemprop") + " " + dateHint(timeElement)
+		switch {
+		case rxModifiedHint.MatchString(hint):
+			modifiedCandidates.add(raw, data.DateFromTimeElement, markedTimeElementScore, timeutil.ParseOrFind)
+		case dom.HasAttribute(timeElement, "pubdate") || rxPublishedHint.MatchString(hint):
+			publishedCandidates.add(raw, data.DateFromTimeElement, markedTimeElementScore, timeutil.ParseOrFind)
+		default:
+			publishedCandidates.add(raw, data.DateFromTimeElement, timeElementScore, timeutil.ParseOrFind)
+		}
+	}
+
+	// Datelines, i.e. short text in element whose class or ID mention date
+	for _, element := range regionElements {
+		switch dom.TagName(element) {
+		case "html", "head", "body", "time", "meta", "script", "style":
+			continue
+		}
+
+		hint := dom.ClassName(element) + " " + dom.ID(element)
+		if !rxDatelineHint.MatchString(hint) {
+			continue
+		}
+
+		text := strings.Join(strings.Fields(domutil.InnerText(element)), " ")
+		if len(text) > maxDatelineLength {
+			continue
+		}
+
+		if rxModifiedHint.MatchString(hint) {
+			modifiedCandidates.add(text, data.DateFromDateline, datelineScore, timeutil.Find)
+		} else {
+			publishedCandidates.add(text, data.DateFromDateline, datelineScore, timeutil.Find)
+		}
+	}
+
+	// URL path
+	if ce.pageURL != nil {
+		path := ce.pageURL.Path
+		publishedCandidates.add(path, data.DateFromURL, urlDateScore, parseURLDate)
+		publishedCandidates.add(path, data.DateFromURL, urlMonthScore, parseURLMonth)
+	}
+
+	return publishedCandidates.best(), modifiedCandidates.best()
+}
+
+// dateRegion returns the elements around the title heading of the article, where the
+// dateline and <time> of the article are usually found. The region consists of the
+// heading, the element before it and a few elements after it, as well as a few elements
+// after its close ancestors, so the dates of comments, related articles and sidebar
+// are ignored. If the page doesn't have <h1>, the whole document is used.
+func (ce *ContentExtractor) dateRegion() []*html.Node {
+	heading := ce.titleHeading()
+	if heading == nil {
+		return []*html.Node{ce.documentElement}
+	}
+
+	var region []*html.Node
+	node := heading
+	for level := 0; level <= maxDateRegionLevels; level++ {
+		if level == 0 {
+			if prev := dom.PreviousElementSibling(node); prev != nil {
+				region = append(region, prev)
+			}
+			region = append(region, node)
+		}
+
+		next := dom.NextElementSibling(node)
+		for i := 0; next != nil && i < maxDateRegionSiblings; i++ {
+			region = append(region, next)
+			next = dom.NextElementSibling(next)
+		}
+
+		node = domutil.GetParentElement(node)
+		if node == nil || dom.TagName(node) == "body" || dom.TagName(node) == "html" {
+			break
+		}
+	}
+
+	return region
+}
+
+// titleHeading returns the <h1> that contains the article title, or the first <h1>
+// if none of them matched. Returns nil if there are no <h1> in the document.
+func (ce *ContentExtractor) titleHeading() *html.Node {
+	headings := dom.GetElementsByTagName(ce.documentElement, "h1")
+	if len(headings) == 0 {
+		return nil
+	}
+
+	title := strings.ToLower(ce.ExtractTitle())
+	for _, heading := range headings {
+		text := strings.ToLower(strings.Join(strings.Fields(domutil.InnerText(heading)), " "))
+		if text != "" && title != "" && (strings.Contains(title, text) || strings.Contains(text, title)) {
+			return heading
+		}
+	}
+
+	return headings[0]
+}
+
+// dateHint returns the class name and ID of the element and its parent, which
+// might tell whether the date inside it is publication or modification date.
+func dateHint(node *html.Node) string {
+	hint := dom.ClassName(node) + " " + dom.ID(node)
+	if parent := domutil.GetParentElement(node); parent != nil {
+		hint += " " + dom.ClassName(parent) + " " + dom.ID(parent)
+	}
+	return hint
+}
+
+// parseURLDate parses date from URL path like "/2023/05/14/slug" or "/2023-05-14-slug".
+func parseURLDate(path string) (time.Time, bool) {
+	parts := rxURLDate.FindStringSubmatch(path)
+	if parts == nil {
+		return time.Time{}, false
+	}
+
+	year, _ := strconv.Atoi(parts[1])
+	month, _ := strconv.Atoi(parts[2])
+	day, _ := strconv.Atoi(parts[3])
+	return timeutil.Date(year, month, day)
+}
+
+// parseURLMonth parses date from URL path like "/2023/05/slug". Since
+// the day is unknown, it's assumed as the first day of the month.
+func parseURLMonth(path string) (time.Time, bool) {
+	parts := rxURLMonth.FindStringSubmatch(path)
+	if parts == nil {
+		return time.Time{}, false
+	}
+
+	year, _ := strconv.Atoi(parts[1])
+	month, _ := strconv.Atoi(parts[2])
+	return timeutil.Date(year, month, 1)
+}
+
+// isPlausibleDate checks if the date could be a publication date,
+// i.e. it's not before the web exists and not in the future.
+func isPlausibleDate(t time.Time) bool {
+	return t.Year() >= 1990 && t.Before(time.Now().Add(maxFutureDateDifference))
+}
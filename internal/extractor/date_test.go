@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extractor_test
+
+import (
+	nurl "net/url"
+	"testing"
+	"time"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/extractor"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func Test_Extractor_Date_MetaTags(t *testing.T) {
+	doc, body := createHTML()
+	head := dom.QuerySelector(doc, "head")
+	appendMeta(head, "name", "pubdate", "2020-10-12T08:30:00Z")
+	appendMeta(head, "name", "last-modified", "2020-10-13")
+	dom.SetInnerHTML(body, "<p>"+contentText+"</p>")
+
+	published, modified := extractDates(doc, testPageURL())
+	assert.Equal(t, &data.ExtractedDate{
+		Time:   time.Date(2020, 10, 12, 8, 30, 0, 0, time.UTC),
+		Source: data.DateFromMeta,
+		Raw:    "2020-10-12T08:30:00Z",
+	}, published)
+	assert.Equal(t, data.DateFromMeta, modified.Source)
+	assert.Equal(t, time.Date(2020, 10, 13, 0, 0, 0, 0, time.UTC), modified.Time)
+}
+
+func Test_Extractor_Date_MarkupPreferred(t *testing.T) {
+	doc, body := createHTML()
+	head := dom.QuerySelector(doc, "head")
+	script := dom.CreateElement("script")
+	dom.SetAttribute(script, "type", "application/ld+json")
+	dom.SetTextContent(script, `{"@type": "NewsArticle", "datePublished": "2019-01-02T03:04:05Z"}`)
+	dom.AppendChild(head, script)
+	appendMeta(head, "name", "sailthru.date", "2019-05-06")
+	dom.SetInnerHTML(body, `<time datetime="2018-01-01">January 1, 2018</time>`+
+		"<p>"+contentText+"</p>")
+
+	published, _ := extractDates(doc, testPageURL())
+	assert.Equal(t, data.DateFromMarkup, published.Source)
+	assert.Equal(t, time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC), published.Time)
+}
+
+func Test_Extractor_Date_TimeElement(t *testing.T) {
+	doc, body := createHTML()
+	dom.SetInnerHTML(body, `<time datetime="2021-03-01">March 1</time>`+
+		`<div class="updated"><time datetime="2021-03-05T10:00:00+02:00">March 5</time></div>`+
+		`<time datetime="2021-02-27" pubdate>February 27</time>`+
+		"<p>"+contentText+"</p>")
+
+	published, modified := extractDates(doc, testPageURL())
+	assert.Equal(t, data.DateFromTimeElement, published.Source)
+	assert.Equal(t, "2021-02-27", published.Raw)
+	assert.Equal(t, data.DateFromTimeElement, modified.Source)
+	assert.True(t, modified.Time.Equal(time.Date(2021, 3, 5, 8, 0, 0, 0, time.UTC)))
+}
+
+func Test_Extractor_Date_Dateline(t *testing.T) {
+	doc, body := createHTML()
+	dom.SetInnerHTML(body, `<div class="post-meta">Posted on 14 May 2020 by Jane Doe</div>`+
+		"<p>"+contentText+"</p>")
+
+	published, modified := extractDates(doc, testPageURL())
+	assert.Nil(t, modified)
+	assert.Equal(t, data.DateFromDateline, published.Source)
+	assert.Equal(t, time.Date(2020, 5, 14, 0, 0, 0, 0, time.UTC), published.Time)
+}
+
+func Test_Extractor_Date_TitleRegion(t *testing.T) {
+	doc, body := createHTML()
+	dom.SetInnerHTML(body, `<div class="sidebar">`+
+		`<time datetime="2019-01-01">January 1, 2019</time>`+
+		`<div class="post-date">2 January 2019</div></div>`+
+		`<article><header><h1>`+titleText+`</h1>`+
+		`<div class="timeline">3 February 2020</div>`+
+		`<div class="entry-meta">Posted on 14 May 2020 by Jane Doe</div></header>`+
+		"<p>"+contentText+"</p>"+
+		`<div class="comments"><p>Nice!</p><div class="comment-time">3 June 2020</div></div>`+
+		`</article>`)
+
+	// Dates in sidebar and comments are ignored, so does the date
+	// in element whose class merely contains "time".
+	published, modified := extractDates(doc, testPageURL())
+	assert.Nil(t, modified)
+	assert.Equal(t, data.DateFromDateline, published.Source)
+	assert.Equal(t, time.Date(2020, 5, 14, 0, 0, 0, 0, time.UTC), published.Time)
+}
+
+func Test_Extractor_Date_URL(t *testing.T) {
+	tests := map[string]time.Time{
+		"http://example.com/2020/05/14/some-article":      time.Date(2020, 5, 14, 0, 0, 0, 0, time.UTC),
+		"http://example.com/news/2020-05-14-some-article": time.Date(2020, 5, 14, 0, 0, 0, 0, time.UTC),
+		"http://example.com/2020/05/some-article":         time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for rawURL, expected := range tests {
+		doc, body := createHTML()
+		dom.SetInnerHTML(body, "<p>"+contentText+"</p>")
+
+		pageURL, _ := nurl.Parse(rawURL)
+		published, _ := extractDates(doc, pageURL)
+		if assert.NotNil(t, published, rawURL) {
+			assert.Equal(t, data.DateFromURL, published.Source, rawURL)
+			assert.Equal(t, expected, published.Time, rawURL)
+		}
+	}
+}
+
+func Test_Extractor_Date_Implausible(t *testing.T) {
+	doc, body := createHTML()
+	future := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+	dom.SetInnerHTML(body, `<time datetime="`+future+`">Next year</time>`+
+		`<time datetime="1900-01-01">Long ago</time>`+
+		"<p>"+contentText+"</p>")
+
+	published, modified := extractDates(doc, testPageURL())
+	assert.Nil(t, published)
+	assert.Nil(t, modified)
+}
+
+func extractDates(doc *html.Node, pageURL *nurl.URL) (*data.ExtractedDate, *data.ExtractedDate) {
+	ce := extractor.NewContentExtractor(doc, pageURL, nil)
+	ce.ExtractContent()
+	return ce.ExtractDates()
+}
+
+func appendMeta(head *html.Node, attrName, name, content string) {
+	meta := dom.CreateElement("meta")
+	dom.SetAttribute(meta, attrName, name)
+	dom.SetAttribute(meta, "content", content)
+	dom.AppendChild(head, meta)
+}
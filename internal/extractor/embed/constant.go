@@ -29,7 +29,9 @@ package embed
 import "regexp"
 
 var (
-	rxB64DataURL      = regexp.MustCompile(`(?i)^data:\s*([^\s;,]+)\s*;\s*base64\s*`)
+	rxMastodonStatusPath = regexp.MustCompile(`^/(?:@[^/]+|users/[^/]+/statuses)/(\d+)(?:/embed)?/?$`)
+	rxApplePodcastID     = regexp.MustCompile(`^id(\d+)$`)
+
 	rxSrcsetURL       = regexp.MustCompile(`(?i)(\S+)(\s+[\d.]+[xw])?(\s*(?:,|$))`)
 	rxImgExtensions   = regexp.MustCompile(`(?i)\.(jpg|jpeg|png|webp)`)
 	rxLazyImageSrcset = regexp.MustCompile(`(?i)\.(jpg|jpeg|png|webp)\s+\d`)
@@ -42,18 +44,6 @@ var (
 		"img",
 	}
 
-	lazyImageSrcAttrs = []string{
-		"data-src",
-		"data-original",
-		"datasrc",
-		"data-url",
-	}
-
-	lazyImageSrcsetAttrs = []string{
-		"data-srcset",
-		"datasrcset",
-	}
-
 	relevantImageTags = map[string]struct{}{
 		// TODO: Add "div" to this list for css images and possibly captions.
 		"img":     {},
@@ -75,4 +65,56 @@ var (
 		"iframe": {},
 		"object": {},
 	}
+
+	relevantInstagramTags = map[string]struct{}{
+		"blockquote": {},
+		"iframe":     {},
+	}
+
+	relevantFacebookTags = map[string]struct{}{
+		"div":        {},
+		"blockquote": {},
+		"iframe":     {},
+	}
+
+	relevantTikTokTags = map[string]struct{}{
+		"blockquote": {},
+		"iframe":     {},
+	}
+
+	relevantMastodonTags = map[string]struct{}{
+		"blockquote": {},
+		"iframe":     {},
+	}
+
+	relevantBlueskyTags = map[string]struct{}{
+		"blockquote": {},
+		"iframe":     {},
+	}
+
+	relevantSpotifyTags = map[string]struct{}{
+		"iframe": {},
+	}
+
+	relevantSoundCloudTags = map[string]struct{}{
+		"iframe": {},
+	}
+
+	relevantApplePodcastsTags = map[string]struct{}{
+		"iframe": {},
+	}
+
+	relevantGistTags = map[string]struct{}{
+		"script": {},
+	}
+
+	relevantCodePenTags = map[string]struct{}{
+		"p":      {},
+		"div":    {},
+		"iframe": {},
+	}
+
+	relevantGoogleMapsTags = map[string]struct{}{
+		"iframe": {},
+	}
 )
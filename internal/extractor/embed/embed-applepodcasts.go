@@ -0,0 +1,115 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// ApplePodcastsExtractor is used for extracting Apple Podcasts player, either
+// for the whole show or a single episode.
+type ApplePodcastsExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewApplePodcastsExtractor(pageURL *nurl.URL, logger logutil.Logger) *ApplePodcastsExtractor {
+	return &ApplePodcastsExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (ae *ApplePodcastsExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantApplePodcastsTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (ae *ApplePodcastsExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantApplePodcastsTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	src := parseEmbedURL(dom.GetAttribute(node, "src"), ae.PageURL)
+	if !hasRootDomain(src, "embed.podcasts.apple.com") {
+		return nil
+	}
+
+	// The player URL looks like "/<country>/podcast/<name>/id<podcast-id>?i=<episode-id>".
+	var podcastID string
+	pathParts := urlPathParts(src)
+	for _, part := range pathParts {
+		if parts := rxApplePodcastID.FindStringSubmatch(part); len(parts) > 1 {
+			podcastID = parts[1]
+			break
+		}
+	}
+
+	if podcastID == "" {
+		return nil
+	}
+
+	params := urlQueryParams(src, "i")
+	if episodeID := src.Query().Get("i"); episodeID != "" {
+		params["episode"] = episodeID
+	}
+
+	if len(pathParts[0]) == 2 {
+		params["country"] = pathParts[0]
+	}
+
+	podcastURL := "https://podcasts.apple.com/" + strings.Join(pathParts, "/")
+	if episodeID := params["episode"]; episodeID != "" {
+		podcastURL += "?i=" + nurl.QueryEscape(episodeID)
+	}
+	params["url"] = podcastURL
+
+	logMsg := fmt.Sprintf("Apple Podcasts embed extracted (ID: %s)", podcastID)
+	ae.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "applepodcasts",
+		ID:      podcastID,
+		Params:  params,
+	}
+}
+
+func (ae *ApplePodcastsExtractor) printLog(args ...interface{}) {
+	if ae.logger != nil {
+		ae.logger.PrintVisibilityInfo(args...)
+	}
+}
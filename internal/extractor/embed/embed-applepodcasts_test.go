@@ -0,0 +1,55 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_ApplePodcasts_Extract(t *testing.T) {
+	episode := dom.CreateElement("iframe")
+	dom.SetAttribute(episode, "src", "https://embed.podcasts.apple.com/us/podcast/the-show/id1200361736?i=1000500000000&theme=dark")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewApplePodcastsExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(episode)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "applepodcasts", result.Type)
+	assert.Equal(t, "1200361736", result.ID)
+	assert.Equal(t, "1000500000000", result.Params["episode"])
+	assert.Equal(t, "us", result.Params["country"])
+	assert.Equal(t, "dark", result.Params["theme"])
+	assert.Equal(t, "https://podcasts.apple.com/us/podcast/the-show/id1200361736?i=1000500000000", result.Params["url"])
+
+	// Begin negative test
+	wrongDomain := dom.CreateElement("iframe")
+	dom.SetAttribute(wrongDomain, "src", "https://example.com/us/podcast/the-show/id1200361736")
+
+	result, _ = (extractor.Extract(wrongDomain)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
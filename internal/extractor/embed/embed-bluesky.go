@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// BlueskyExtractor is used to look for Bluesky posts. The post is identified by its
+// AT URI (e.g. "at://<did>/app.bsky.feed.post/<id>"), which is used both in the
+// unrendered blockquote and the rendered iframe.
+type BlueskyExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewBlueskyExtractor(pageURL *nurl.URL, logger logutil.Logger) *BlueskyExtractor {
+	return &BlueskyExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (be *BlueskyExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantBlueskyTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (be *BlueskyExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantBlueskyTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	var author, postID string
+	if nodeTagName == "blockquote" {
+		if !hasClass(node, "bluesky-embed") {
+			return nil
+		}
+
+		// Use the AT URI if possible, otherwise use the link to the post
+		// which looks like "https://bsky.app/profile/<author>/post/<id>".
+		uri := dom.GetAttribute(node, "data-bluesky-uri")
+		if strings.HasPrefix(uri, "at://") {
+			author, postID = be.getPostFromParts(strings.Split(strings.TrimPrefix(uri, "at://"), "/"))
+		} else if postURL := lastAnchorURL(node, be.PageURL, "bsky.app"); postURL != nil {
+			pathParts := urlPathParts(postURL)
+			if len(pathParts) == 4 && pathParts[0] == "profile" && pathParts[2] == "post" {
+				author, postID = pathParts[1], pathParts[3]
+			}
+		}
+	} else {
+		// Rendered post is an iframe to "https://embed.bsky.app/embed/<did>/app.bsky.feed.post/<id>".
+		src := parseEmbedURL(dom.GetAttribute(node, "src"), be.PageURL)
+		if !hasRootDomain(src, "embed.bsky.app") {
+			return nil
+		}
+
+		pathParts := urlPathParts(src)
+		if len(pathParts) > 0 && pathParts[0] == "embed" {
+			author, postID = be.getPostFromParts(pathParts[1:])
+		}
+	}
+
+	if author == "" || postID == "" {
+		return nil
+	}
+
+	logMsg := fmt.Sprintf("Bluesky embed extracted (ID: %s)", postID)
+	be.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "bluesky",
+		ID:      postID,
+		Params: map[string]string{
+			"author": author,
+			"url":    "https://bsky.app/profile/" + author + "/post/" + postID,
+		},
+	}
+}
+
+// getPostFromParts returns the author and post ID from the parts of AT URI,
+// i.e. "<author>/app.bsky.feed.post/<id>".
+func (be *BlueskyExtractor) getPostFromParts(parts []string) (string, string) {
+	if len(parts) != 3 || parts[1] != "app.bsky.feed.post" {
+		return "", ""
+	}
+	return parts[0], parts[2]
+}
+
+func (be *BlueskyExtractor) printLog(args ...interface{}) {
+	if be.logger != nil {
+		be.logger.PrintVisibilityInfo(args...)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_Bluesky_ExtractNotRendered(t *testing.T) {
+	post := dom.CreateElement("blockquote")
+	dom.SetAttribute(post, "class", "bluesky-embed")
+	dom.SetAttribute(post, "data-bluesky-uri", "at://did:plc:abc123/app.bsky.feed.post/3kxyz")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewBlueskyExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(post)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "bluesky", result.Type)
+	assert.Equal(t, "3kxyz", result.ID)
+	assert.Equal(t, "did:plc:abc123", result.Params["author"])
+	assert.Equal(t, "https://bsky.app/profile/did:plc:abc123/post/3kxyz", result.Params["url"])
+
+	// AT URI might be missing, so use the anchor instead
+	post = dom.CreateElement("blockquote")
+	dom.SetAttribute(post, "class", "bluesky-embed")
+	dom.SetInnerHTML(post, `<a href="https://bsky.app/profile/someone.bsky.social/post/3kabc">Link</a>`)
+
+	result, _ = (extractor.Extract(post)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "3kabc", result.ID)
+	assert.Equal(t, "someone.bsky.social", result.Params["author"])
+
+	// Begin negative test
+	profile := dom.CreateElement("blockquote")
+	dom.SetAttribute(profile, "class", "bluesky-embed")
+	dom.SetInnerHTML(profile, `<a href="https://bsky.app/profile/someone.bsky.social">Profile</a>`)
+
+	result, _ = (extractor.Extract(profile)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
+
+func Test_Embed_Bluesky_ExtractRendered(t *testing.T) {
+	iframe := dom.CreateElement("iframe")
+	dom.SetAttribute(iframe, "src", "https://embed.bsky.app/embed/did:plc:abc123/app.bsky.feed.post/3kxyz?id=1")
+
+	extractor := embed.NewBlueskyExtractor(nil, nil)
+	result, _ := (extractor.Extract(iframe)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "bluesky", result.Type)
+	assert.Equal(t, "3kxyz", result.ID)
+
+	// Begin negative test
+	wrongDomain := dom.CreateElement("iframe")
+	dom.SetAttribute(wrongDomain, "src", "https://example.com/embed/did:plc:abc123/app.bsky.feed.post/3kxyz")
+
+	result, _ = (extractor.Extract(wrongDomain)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// CodePenExtractor is used for extracting CodePen pens. The unrendered pen is an
+// element with "codepen" class and the pen's slug hash, while the rendered one is
+// an iframe to "codepen.io/<user>/embed/<hash>".
+type CodePenExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewCodePenExtractor(pageURL *nurl.URL, logger logutil.Logger) *CodePenExtractor {
+	return &CodePenExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (ce *CodePenExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantCodePenTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (ce *CodePenExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantCodePenTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	var user, penID string
+	params := make(map[string]string)
+	if nodeTagName == "iframe" {
+		src := parseEmbedURL(dom.GetAttribute(node, "src"), ce.PageURL)
+		if !hasRootDomain(src, "codepen.io") {
+			return nil
+		}
+
+		// The path is either "/<user>/embed/<hash>" or "/<user>/embed/preview/<hash>".
+		pathParts := urlPathParts(src)
+		if len(pathParts) < 3 || pathParts[1] != "embed" {
+			return nil
+		}
+
+		user, penID = pathParts[0], pathParts[len(pathParts)-1]
+		params = urlQueryParams(src)
+	} else {
+		if !hasClass(node, "codepen") {
+			return nil
+		}
+
+		user = dom.GetAttribute(node, "data-user")
+		penID = dom.GetAttribute(node, "data-slug-hash")
+		if tab := dom.GetAttribute(node, "data-default-tab"); tab != "" {
+			params["default-tab"] = tab
+		}
+	}
+
+	if user == "" || penID == "" {
+		return nil
+	}
+
+	params["user"] = user
+	params["url"] = "https://codepen.io/" + user + "/pen/" + penID
+
+	logMsg := fmt.Sprintf("CodePen embed extracted (ID: %s)", penID)
+	ce.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "codepen",
+		ID:      penID,
+		Params:  params,
+	}
+}
+
+func (ce *CodePenExtractor) printLog(args ...interface{}) {
+	if ce.logger != nil {
+		ce.logger.PrintVisibilityInfo(args...)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_CodePen_ExtractNotRendered(t *testing.T) {
+	pen := dom.CreateElement("p")
+	dom.SetAttribute(pen, "class", "codepen")
+	dom.SetAttribute(pen, "data-slug-hash", "xYzAbC")
+	dom.SetAttribute(pen, "data-user", "someone")
+	dom.SetAttribute(pen, "data-default-tab", "css,result")
+	dom.SetTextContent(pen, "See the Pen by someone on CodePen.")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewCodePenExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(pen)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "codepen", result.Type)
+	assert.Equal(t, "xYzAbC", result.ID)
+	assert.Equal(t, "someone", result.Params["user"])
+	assert.Equal(t, "css,result", result.Params["default-tab"])
+	assert.Equal(t, "https://codepen.io/someone/pen/xYzAbC", result.Params["url"])
+
+	// Begin negative test
+	paragraph := dom.CreateElement("p")
+	dom.SetAttribute(paragraph, "data-slug-hash", "xYzAbC")
+	dom.SetAttribute(paragraph, "data-user", "someone")
+
+	result, _ = (extractor.Extract(paragraph)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
+
+func Test_Embed_CodePen_ExtractRendered(t *testing.T) {
+	iframe := dom.CreateElement("iframe")
+	dom.SetAttribute(iframe, "src", "https://codepen.io/someone/embed/preview/xYzAbC?default-tab=result")
+
+	extractor := embed.NewCodePenExtractor(nil, nil)
+	result, _ := (extractor.Extract(iframe)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "codepen", result.Type)
+	assert.Equal(t, "xYzAbC", result.ID)
+	assert.Equal(t, "result", result.Params["default-tab"])
+
+	// Begin negative test
+	notEmbed := dom.CreateElement("iframe")
+	dom.SetAttribute(notEmbed, "src", "https://codepen.io/someone/pen/xYzAbC")
+
+	result, _ = (extractor.Extract(notEmbed)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
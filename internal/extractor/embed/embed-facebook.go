@@ -0,0 +1,149 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// FacebookExtractor is used to look for Facebook posts and videos. The embed code
+// from Facebook is a div with the URL of the post, which contains a blockquote as
+// fallback. Once rendered, it's replaced by iframe to the post plugin.
+type FacebookExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewFacebookExtractor(pageURL *nurl.URL, logger logutil.Logger) *FacebookExtractor {
+	return &FacebookExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (fe *FacebookExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantFacebookTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (fe *FacebookExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantFacebookTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	var postKind string
+	var postURL *nurl.URL
+	switch nodeTagName {
+	case "div":
+		switch {
+		case hasClass(node, "fb-post"):
+			postKind = "post"
+		case hasClass(node, "fb-video"):
+			postKind = "video"
+		default:
+			return nil
+		}
+		postURL = parseEmbedURL(dom.GetAttribute(node, "data-href"), fe.PageURL)
+
+	case "blockquote":
+		if !hasClass(node, "fb-xfbml-parse-ignore") {
+			return nil
+		}
+		postKind = "post"
+		postURL = parseEmbedURL(dom.GetAttribute(node, "cite"), fe.PageURL)
+
+	case "iframe":
+		// The plugin URL looks like "/plugins/post.php?href=<post-url>".
+		pluginURL := parseEmbedURL(dom.GetAttribute(node, "src"), fe.PageURL)
+		if !hasRootDomain(pluginURL, "facebook.com") {
+			return nil
+		}
+
+		switch pluginURL.Path {
+		case "/plugins/post.php":
+			postKind = "post"
+		case "/plugins/video.php":
+			postKind = "video"
+		default:
+			return nil
+		}
+		postURL = parseEmbedURL(pluginURL.Query().Get("href"), nil)
+	}
+
+	if !hasRootDomain(postURL, "facebook.com") {
+		return nil
+	}
+
+	postID := fe.getPostIdFromURL(postURL)
+	if postID == "" {
+		return nil
+	}
+
+	logMsg := fmt.Sprintf("Facebook embed extracted (ID: %s)", postID)
+	fe.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "facebook",
+		ID:      postID,
+		Params: map[string]string{
+			"kind": postKind,
+			"url":  postURL.String(),
+		},
+	}
+}
+
+func (fe *FacebookExtractor) getPostIdFromURL(postURL *nurl.URL) string {
+	// Old style permalink keeps the ID in query, e.g. "/permalink.php?story_fbid=<id>".
+	query := postURL.Query()
+	for _, key := range []string{"story_fbid", "fbid", "v"} {
+		if id := query.Get(key); id != "" {
+			return id
+		}
+	}
+
+	// Otherwise the ID will be the last part of the path, e.g. "/<page>/posts/<id>".
+	pathParts := urlPathParts(postURL)
+	if len(pathParts) < 2 {
+		return ""
+	}
+	return pathParts[len(pathParts)-1]
+}
+
+func (fe *FacebookExtractor) printLog(args ...interface{}) {
+	if fe.logger != nil {
+		fe.logger.PrintVisibilityInfo(args...)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_Facebook_ExtractNotRendered(t *testing.T) {
+	post := dom.CreateElement("div")
+	dom.SetAttribute(post, "class", "fb-post")
+	dom.SetAttribute(post, "data-href", "https://www.facebook.com/somepage/posts/123456789")
+	dom.SetInnerHTML(post, `<blockquote class="fb-xfbml-parse-ignore">Some post</blockquote>`)
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewFacebookExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(post)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "facebook", result.Type)
+	assert.Equal(t, "123456789", result.ID)
+	assert.Equal(t, "post", result.Params["kind"])
+	assert.Equal(t, "https://www.facebook.com/somepage/posts/123456789", result.Params["url"])
+
+	// Old style permalink
+	quote := dom.CreateElement("blockquote")
+	dom.SetAttribute(quote, "class", "fb-xfbml-parse-ignore")
+	dom.SetAttribute(quote, "cite", "https://www.facebook.com/permalink.php?story_fbid=987&id=1")
+
+	result, _ = (extractor.Extract(quote)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "987", result.ID)
+
+	// Begin negative test
+	wrongDomain := dom.CreateElement("div")
+	dom.SetAttribute(wrongDomain, "class", "fb-post")
+	dom.SetAttribute(wrongDomain, "data-href", "https://example.com/posts/123456789")
+
+	result, _ = (extractor.Extract(wrongDomain)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
+
+func Test_Embed_Facebook_ExtractRendered(t *testing.T) {
+	iframe := dom.CreateElement("iframe")
+	dom.SetAttribute(iframe, "src", "https://www.facebook.com/plugins/video.php?"+
+		"href=https%3A%2F%2Fwww.facebook.com%2Fsomepage%2Fvideos%2F555%2F&width=500")
+
+	extractor := embed.NewFacebookExtractor(nil, nil)
+	result, _ := (extractor.Extract(iframe)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "facebook", result.Type)
+	assert.Equal(t, "555", result.ID)
+	assert.Equal(t, "video", result.Params["kind"])
+
+	// Begin negative test
+	likeButton := dom.CreateElement("iframe")
+	dom.SetAttribute(likeButton, "src", "https://www.facebook.com/plugins/like.php?"+
+		"href=https%3A%2F%2Fwww.facebook.com%2Fsomepage%2Fposts%2F123")
+
+	result, _ = (extractor.Extract(likeButton)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// GistExtractor is used for extracting GitHub Gist. Gist is embedded using script
+// that writes the code into the page, e.g. "gist.github.com/<user>/<id>.js".
+type GistExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewGistExtractor(pageURL *nurl.URL, logger logutil.Logger) *GistExtractor {
+	return &GistExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (ge *GistExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantGistTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (ge *GistExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantGistTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	src := parseEmbedURL(dom.GetAttribute(node, "src"), ge.PageURL)
+	if !hasRootDomain(src, "gist.github.com") {
+		return nil
+	}
+
+	// Anonymous gist doesn't have the user name in its path.
+	pathParts := urlPathParts(src)
+	if len(pathParts) == 0 || len(pathParts) > 2 {
+		return nil
+	}
+
+	gistID := pathParts[len(pathParts)-1]
+	if !strings.HasSuffix(gistID, ".js") {
+		return nil
+	}
+
+	gistID = strings.TrimSuffix(gistID, ".js")
+	if gistID == "" {
+		return nil
+	}
+
+	gistPath := gistID
+	params := urlQueryParams(src)
+	if len(pathParts) == 2 {
+		params["user"] = pathParts[0]
+		gistPath = pathParts[0] + "/" + gistID
+	}
+	params["url"] = "https://gist.github.com/" + gistPath
+
+	logMsg := fmt.Sprintf("Gist embed extracted (ID: %s)", gistID)
+	ge.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "gist",
+		ID:      gistID,
+		Params:  params,
+	}
+}
+
+func (ge *GistExtractor) printLog(args ...interface{}) {
+	if ge.logger != nil {
+		ge.logger.PrintVisibilityInfo(args...)
+	}
+}
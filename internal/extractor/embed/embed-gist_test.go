@@ -0,0 +1,62 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_Gist_Extract(t *testing.T) {
+	gist := dom.CreateElement("script")
+	dom.SetAttribute(gist, "src", "https://gist.github.com/someone/0123456789abcdef.js?file=main.go")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewGistExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(gist)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "gist", result.Type)
+	assert.Equal(t, "0123456789abcdef", result.ID)
+	assert.Equal(t, "someone", result.Params["user"])
+	assert.Equal(t, "main.go", result.Params["file"])
+	assert.Equal(t, "https://gist.github.com/someone/0123456789abcdef", result.Params["url"])
+
+	// Anonymous gist
+	anonymous := dom.CreateElement("script")
+	dom.SetAttribute(anonymous, "src", "//gist.github.com/fedcba9876543210.js")
+
+	result, _ = (extractor.Extract(anonymous)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "fedcba9876543210", result.ID)
+
+	// Begin negative test
+	notGist := dom.CreateElement("script")
+	dom.SetAttribute(notGist, "src", "https://gist.github.com/assets/embed.css")
+
+	result, _ = (extractor.Extract(notGist)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
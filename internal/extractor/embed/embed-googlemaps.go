@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// GoogleMapsExtractor is used for extracting Google Maps. Since the embedded map
+// doesn't have a real ID, the searched place (or the encoded "pb" parameter when
+// it's not available) is used as ID.
+type GoogleMapsExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewGoogleMapsExtractor(pageURL *nurl.URL, logger logutil.Logger) *GoogleMapsExtractor {
+	return &GoogleMapsExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (ge *GoogleMapsExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantGoogleMapsTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (ge *GoogleMapsExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantGoogleMapsTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	// There are several forms of embedded map:
+	// - "google.com/maps/embed?pb=<data>" from the share menu,
+	// - "google.com/maps/embed/v1/<mode>?key=<key>&q=<place>" from Maps Embed API,
+	// - "maps.google.com/maps?q=<place>&output=embed" the legacy one.
+	src := parseEmbedURL(dom.GetAttribute(node, "src"), ge.PageURL)
+	if !hasRootDomain(src, "google.com") {
+		return nil
+	}
+
+	query := src.Query()
+	isEmbedPath := src.Path == "/maps/embed" || strings.HasPrefix(src.Path, "/maps/embed/")
+	isLegacyEmbed := src.Path == "/maps" && query.Get("output") == "embed"
+	if !isEmbedPath && !isLegacyEmbed {
+		return nil
+	}
+
+	// Never keep the API key.
+	params := urlQueryParams(src, "key")
+	if pathParts := urlPathParts(src); len(pathParts) == 4 && pathParts[2] == "v1" {
+		params["mode"] = pathParts[3]
+	}
+
+	mapID := params["q"]
+	if mapID != "" {
+		params["url"] = "https://www.google.com/maps/search/?api=1&query=" + nurl.QueryEscape(mapID)
+	} else if mapID = params["pb"]; mapID != "" {
+		params["url"] = "https://www.google.com/maps/embed?pb=" + nurl.QueryEscape(mapID)
+	} else {
+		return nil
+	}
+
+	logMsg := fmt.Sprintf("Google Maps embed extracted (ID: %s)", mapID)
+	ge.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "googlemaps",
+		ID:      mapID,
+		Params:  params,
+	}
+}
+
+func (ge *GoogleMapsExtractor) printLog(args ...interface{}) {
+	if ge.logger != nil {
+		ge.logger.PrintVisibilityInfo(args...)
+	}
+}
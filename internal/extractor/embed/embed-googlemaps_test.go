@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_GoogleMaps_Extract(t *testing.T) {
+	maps := dom.CreateElement("iframe")
+	dom.SetAttribute(maps, "src", "https://www.google.com/maps/embed/v1/place?key=SECRET&q=Eiffel+Tower,Paris")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewGoogleMapsExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(maps)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "googlemaps", result.Type)
+	assert.Equal(t, "Eiffel Tower,Paris", result.ID)
+	assert.Equal(t, "place", result.Params["mode"])
+	assert.Equal(t, "https://www.google.com/maps/search/?api=1&query=Eiffel+Tower%2CParis", result.Params["url"])
+	assert.NotContains(t, result.Params, "key")
+
+	// Map from share menu
+	shared := dom.CreateElement("iframe")
+	dom.SetAttribute(shared, "src", "https://www.google.com/maps/embed?pb=!1m18!1m12")
+
+	result, _ = (extractor.Extract(shared)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "!1m18!1m12", result.ID)
+
+	// Legacy map
+	legacy := dom.CreateElement("iframe")
+	dom.SetAttribute(legacy, "src", "https://maps.google.com/maps?q=Jakarta&output=embed")
+
+	result, _ = (extractor.Extract(legacy)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "Jakarta", result.ID)
+
+	// Begin negative test
+	notMaps := dom.CreateElement("iframe")
+	dom.SetAttribute(notMaps, "src", "https://www.google.com/search?q=Jakarta")
+
+	result, _ = (extractor.Extract(notMaps)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
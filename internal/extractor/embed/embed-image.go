@@ -206,13 +206,13 @@ func (ie *ImageExtractor) replaceLazySrcAttr(img *html.Node) {
 	// In some sites (e.g. Kotaku), they put 1px square image as data uri in the src attribute.
 	// So, here we check if the data uri is too short, just might as well remove it.
 	imgSrc := dom.GetAttribute(img, "src")
-	if imgSrc != "" && !ie.imageSrcIsValid(imgSrc) {
+	if imgSrc != "" && domutil.IsTinyDataURL(imgSrc) {
 		dom.RemoveAttribute(img, "src")
 		imgSrc = ""
 	}
 
 	// Try to get the common lazily-loaded src attrs first.
-	for _, attrName := range lazyImageSrcAttrs {
+	for _, attrName := range domutil.LazyImageSrcAttrs {
 		if attrValue := dom.GetAttribute(img, attrName); attrValue != "" {
 			imgSrc = attrValue
 			break
@@ -240,7 +240,7 @@ func (ie *ImageExtractor) replaceLazySrcAttr(img *html.Node) {
 func (ie *ImageExtractor) replaceLazySrcsetAttr(img *html.Node) {
 	// Try to get the common lazily-loaded srcset attrs first.
 	imgSrcset := dom.GetAttribute(img, "srcset")
-	for _, attrName := range lazyImageSrcsetAttrs {
+	for _, attrName := range domutil.LazyImageSrcsetAttrs {
 		if attrValue := dom.GetAttribute(img, attrName); attrValue != "" {
 			imgSrcset = attrValue
 			break
@@ -265,33 +265,6 @@ func (ie *ImageExtractor) replaceLazySrcsetAttr(img *html.Node) {
 	}
 }
 
-// dataURLIsValid checks if the image src doesn't contains small data URL
-// image which often used as placeholder.
-func (ie *ImageExtractor) imageSrcIsValid(src string) bool {
-	// Check if it's base64 encoded image.
-	// If it's not, we assume it's valid image.
-	parts := rxB64DataURL.FindStringSubmatch(src)
-	if len(parts) == 0 {
-		return true
-	}
-
-	// If it's SVG, we assume it's valid because SVG can have a meaningful
-	// image in under 133 bytes.
-	if parts[1] == "image/svg+xml" {
-		return true
-	}
-
-	// If image is less than 100 bytes (or 133B after encoded to base64),
-	// it will be too small therefore it's not a valid image.
-	b64starts := strings.Index(src, "base64") + 7
-	b64length := len(src) - b64starts
-	if b64length < 133 {
-		return false
-	}
-
-	return true
-}
-
 func (ie *ImageExtractor) createFigCaption(base *html.Node) *html.Node {
 	// In some sites noscript is put inside figure caption (eg Medium).
 	// So, before fetching the inner text we need to parse it first.
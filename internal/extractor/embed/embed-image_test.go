@@ -75,6 +75,8 @@ func Test_Embed_Image_LazyLoadedImage(t *testing.T) {
 	extractLazyLoadedImage(t, "data-url", "src")
 	extractLazyLoadedImage(t, "data-srcset", "srcset")
 	extractLazyLoadedImage(t, "datasrcset", "srcset")
+	extractLazyLoadedImage(t, "data-lazy-src", "src")
+	extractLazyLoadedImage(t, "data-lazy-srcset", "srcset")
 
 	// Custom lazy attributes
 	extractLazyLoadedImage(t, "lazy-src", "src")
@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// InstagramExtractor is used to look for Instagram posts, either the unrendered
+// blockquote from the official embed code or the rendered iframe.
+type InstagramExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewInstagramExtractor(pageURL *nurl.URL, logger logutil.Logger) *InstagramExtractor {
+	return &InstagramExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (ie *InstagramExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantInstagramTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (ie *InstagramExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantInstagramTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	// Unrendered post is a blockquote with the permalink of the post, while
+	// the rendered one is an iframe to "/p/<id>/embed".
+	var postURL *nurl.URL
+	if nodeTagName == "blockquote" {
+		if !hasClass(node, "instagram-media") {
+			return nil
+		}
+
+		postURL = parseEmbedURL(dom.GetAttribute(node, "data-instgrm-permalink"), ie.PageURL)
+		if postURL == nil {
+			postURL = lastAnchorURL(node, ie.PageURL, "instagram.com")
+		}
+	} else {
+		postURL = parseEmbedURL(dom.GetAttribute(node, "src"), ie.PageURL)
+	}
+
+	if !hasRootDomain(postURL, "instagram.com") {
+		return nil
+	}
+
+	postKind, postID := ie.getPostFromURL(postURL)
+	if postID == "" {
+		return nil
+	}
+
+	logMsg := fmt.Sprintf("Instagram embed extracted (ID: %s)", postID)
+	ie.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "instagram",
+		ID:      postID,
+		Params: map[string]string{
+			"kind": postKind,
+			"url":  "https://www.instagram.com/" + postKind + "/" + postID + "/",
+		},
+	}
+}
+
+// getPostFromURL returns the kind (post, reel or IGTV) and the short code of the post.
+func (ie *InstagramExtractor) getPostFromURL(postURL *nurl.URL) (string, string) {
+	pathParts := urlPathParts(postURL)
+	for i := 0; i < len(pathParts)-1; i++ {
+		switch pathParts[i] {
+		case "p", "reel", "tv":
+			return pathParts[i], pathParts[i+1]
+		}
+	}
+	return "", ""
+}
+
+func (ie *InstagramExtractor) printLog(args ...interface{}) {
+	if ie.logger != nil {
+		ie.logger.PrintVisibilityInfo(args...)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_Instagram_ExtractNotRendered(t *testing.T) {
+	post := dom.CreateElement("blockquote")
+	dom.SetAttribute(post, "class", "instagram-media")
+	dom.SetAttribute(post, "data-instgrm-permalink", "https://www.instagram.com/p/CxYz123/?utm_source=ig_embed")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewInstagramExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(post)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "instagram", result.Type)
+	assert.Equal(t, "CxYz123", result.ID)
+	assert.Equal(t, "p", result.Params["kind"])
+	assert.Equal(t, "https://www.instagram.com/p/CxYz123/", result.Params["url"])
+
+	// Permalink might be missing, so use the anchor instead
+	reel := dom.CreateElement("blockquote")
+	dom.SetAttribute(reel, "class", "instagram-media")
+	dom.SetInnerHTML(reel, `<a href="https://www.instagram.com/reel/AbC987/">View this post</a>`)
+
+	result, _ = (extractor.Extract(reel)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "AbC987", result.ID)
+	assert.Equal(t, "reel", result.Params["kind"])
+
+	// Begin negative test
+	notInstagram := dom.CreateElement("blockquote")
+	dom.SetInnerHTML(notInstagram, `<a href="https://www.instagram.com/p/CxYz123/">Quote</a>`)
+
+	result, _ = (extractor.Extract(notInstagram)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
+
+func Test_Embed_Instagram_ExtractRendered(t *testing.T) {
+	iframe := dom.CreateElement("iframe")
+	dom.SetAttribute(iframe, "src", "//www.instagram.com/p/CxYz123/embed/captioned/")
+
+	extractor := embed.NewInstagramExtractor(nil, nil)
+	result, _ := (extractor.Extract(iframe)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "instagram", result.Type)
+	assert.Equal(t, "CxYz123", result.ID)
+
+	// Begin negative test
+	profile := dom.CreateElement("iframe")
+	dom.SetAttribute(profile, "src", "https://www.instagram.com/someone/embed")
+
+	result, _ = (extractor.Extract(profile)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// MastodonExtractor is used to look for Mastodon statuses. Since Mastodon is hosted
+// in many instances, the status is detected using its URL path (e.g. "/@<user>/<id>")
+// instead of its domain.
+type MastodonExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewMastodonExtractor(pageURL *nurl.URL, logger logutil.Logger) *MastodonExtractor {
+	return &MastodonExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (me *MastodonExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantMastodonTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (me *MastodonExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantMastodonTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	var statusURL *nurl.URL
+	if nodeTagName == "blockquote" {
+		// Unrendered status is a blockquote with the embed URL and the
+		// link to the original status as fallback.
+		if !hasClass(node, "mastodon-embed") {
+			return nil
+		}
+
+		statusURL = parseEmbedURL(dom.GetAttribute(node, "data-embed-url"), me.PageURL)
+		if statusURL == nil {
+			anchors := dom.GetElementsByTagName(node, "a")
+			for i := len(anchors) - 1; i >= 0 && statusURL == nil; i-- {
+				href := parseEmbedURL(dom.GetAttribute(anchors[i], "href"), me.PageURL)
+				if href != nil && rxMastodonStatusPath.MatchString(href.Path) {
+					statusURL = href
+				}
+			}
+		}
+	} else {
+		// Rendered status is an iframe to "/@<user>/<id>/embed". Since it could be
+		// any domain, make sure it's either marked or using the embed path.
+		statusURL = parseEmbedURL(dom.GetAttribute(node, "src"), me.PageURL)
+		if statusURL != nil && !hasClass(node, "mastodon-embed") &&
+			!strings.HasSuffix(strings.TrimSuffix(statusURL.Path, "/"), "/embed") {
+			return nil
+		}
+	}
+
+	if statusURL == nil {
+		return nil
+	}
+
+	parts := rxMastodonStatusPath.FindStringSubmatch(statusURL.Path)
+	if len(parts) < 2 {
+		return nil
+	}
+
+	statusID := parts[1]
+	pathParts := urlPathParts(statusURL)
+	user := strings.TrimPrefix(pathParts[0], "@")
+	if pathParts[0] == "users" {
+		user = pathParts[1]
+	}
+
+	logMsg := fmt.Sprintf("Mastodon embed extracted (ID: %s)", statusID)
+	me.printLog(logMsg)
+
+	instance := statusURL.Host
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "mastodon",
+		ID:      statusID,
+		Params: map[string]string{
+			"instance": instance,
+			"user":     user,
+			"url":      "https://" + instance + "/@" + user + "/" + statusID,
+		},
+	}
+}
+
+func (me *MastodonExtractor) printLog(args ...interface{}) {
+	if me.logger != nil {
+		me.logger.PrintVisibilityInfo(args...)
+	}
+}
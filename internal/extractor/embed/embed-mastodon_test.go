@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_Mastodon_ExtractNotRendered(t *testing.T) {
+	status := dom.CreateElement("blockquote")
+	dom.SetAttribute(status, "class", "mastodon-embed")
+	dom.SetAttribute(status, "data-embed-url", "https://mastodon.social/@someone/110123456789/embed")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewMastodonExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(status)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "mastodon", result.Type)
+	assert.Equal(t, "110123456789", result.ID)
+	assert.Equal(t, "mastodon.social", result.Params["instance"])
+	assert.Equal(t, "someone", result.Params["user"])
+	assert.Equal(t, "https://mastodon.social/@someone/110123456789", result.Params["url"])
+
+	// Embed URL might be missing, so use the anchor instead
+	status = dom.CreateElement("blockquote")
+	dom.SetAttribute(status, "class", "mastodon-embed")
+	dom.SetInnerHTML(status, `<p>Hello</p>`+
+		`<a href="https://fosstodon.org/users/other/statuses/42">Post by @other</a>`)
+
+	result, _ = (extractor.Extract(status)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "42", result.ID)
+	assert.Equal(t, "other", result.Params["user"])
+
+	// Begin negative test
+	quote := dom.CreateElement("blockquote")
+	dom.SetInnerHTML(quote, `<a href="https://mastodon.social/@someone/110123456789">Link</a>`)
+
+	result, _ = (extractor.Extract(quote)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
+
+func Test_Embed_Mastodon_ExtractRendered(t *testing.T) {
+	iframe := dom.CreateElement("iframe")
+	dom.SetAttribute(iframe, "src", "https://mastodon.social/@someone/110123456789/embed")
+
+	extractor := embed.NewMastodonExtractor(nil, nil)
+	result, _ := (extractor.Extract(iframe)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "mastodon", result.Type)
+	assert.Equal(t, "110123456789", result.ID)
+
+	// Begin negative test, iframe without embed path is not a Mastodon status
+	notEmbed := dom.CreateElement("iframe")
+	dom.SetAttribute(notEmbed, "src", "https://example.com/@someone/110123456789")
+
+	result, _ = (extractor.Extract(notEmbed)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
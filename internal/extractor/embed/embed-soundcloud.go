@@ -0,0 +1,104 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// SoundCloudExtractor is used for extracting SoundCloud player. The player is an
+// iframe to "w.soundcloud.com/player" with the URL of the sound in its query.
+type SoundCloudExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewSoundCloudExtractor(pageURL *nurl.URL, logger logutil.Logger) *SoundCloudExtractor {
+	return &SoundCloudExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (se *SoundCloudExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantSoundCloudTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (se *SoundCloudExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantSoundCloudTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	src := parseEmbedURL(dom.GetAttribute(node, "src"), se.PageURL)
+	if !hasRootDomain(src, "w.soundcloud.com") || !strings.HasPrefix(src.Path, "/player") {
+		return nil
+	}
+
+	// The sound URL is either the API URL (e.g. "api.soundcloud.com/tracks/<id>")
+	// or the public URL (e.g. "soundcloud.com/<user>/<track>").
+	params := urlQueryParams(src)
+	soundURL := parseEmbedURL(params["url"], nil)
+	if !hasRootDomain(soundURL, "soundcloud.com") {
+		return nil
+	}
+
+	pathParts := urlPathParts(soundURL)
+	if len(pathParts) < 2 {
+		return nil
+	}
+
+	soundID := pathParts[len(pathParts)-1]
+	if hasRootDomain(soundURL, "api.soundcloud.com") {
+		params["kind"] = strings.TrimSuffix(pathParts[0], "s")
+	}
+
+	logMsg := fmt.Sprintf("SoundCloud embed extracted (ID: %s)", soundID)
+	se.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "soundcloud",
+		ID:      soundID,
+		Params:  params,
+	}
+}
+
+func (se *SoundCloudExtractor) printLog(args ...interface{}) {
+	if se.logger != nil {
+		se.logger.PrintVisibilityInfo(args...)
+	}
+}
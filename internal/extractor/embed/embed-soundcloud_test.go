@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_SoundCloud_Extract(t *testing.T) {
+	soundcloud := dom.CreateElement("iframe")
+	dom.SetAttribute(soundcloud, "src", "https://w.soundcloud.com/player/?"+
+		"url=https%3A//api.soundcloud.com/tracks/293&color=%23ff5500&auto_play=false")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewSoundCloudExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(soundcloud)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "soundcloud", result.Type)
+	assert.Equal(t, "293", result.ID)
+	assert.Equal(t, "track", result.Params["kind"])
+	assert.Equal(t, "false", result.Params["auto_play"])
+	assert.Equal(t, "https://api.soundcloud.com/tracks/293", result.Params["url"])
+
+	// Public URL of the sound
+	public := dom.CreateElement("iframe")
+	dom.SetAttribute(public, "src", "https://w.soundcloud.com/player/?url=https://soundcloud.com/someone/a-song")
+
+	result, _ = (extractor.Extract(public)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "a-song", result.ID)
+
+	// Begin negative test
+	noSound := dom.CreateElement("iframe")
+	dom.SetAttribute(noSound, "src", "https://w.soundcloud.com/player/?color=%23ff5500")
+
+	result, _ = (extractor.Extract(noSound)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// SpotifyExtractor is used for extracting Spotify player, e.g. for track, album,
+// playlist or podcast episode.
+type SpotifyExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewSpotifyExtractor(pageURL *nurl.URL, logger logutil.Logger) *SpotifyExtractor {
+	return &SpotifyExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (se *SpotifyExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantSpotifyTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (se *SpotifyExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantSpotifyTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	src := parseEmbedURL(dom.GetAttribute(node, "src"), se.PageURL)
+	if !hasRootDomain(src, "open.spotify.com") {
+		return nil
+	}
+
+	// The player URL looks like "/embed/<kind>/<id>". There is also
+	// the legacy "/embed-podcast/<kind>/<id>" for podcast.
+	pathParts := urlPathParts(src)
+	if len(pathParts) != 3 || (pathParts[0] != "embed" && pathParts[0] != "embed-podcast") {
+		return nil
+	}
+
+	kind, spotifyID := pathParts[1], pathParts[2]
+	params := urlQueryParams(src)
+	params["kind"] = kind
+	params["url"] = "https://open.spotify.com/" + kind + "/" + spotifyID
+
+	logMsg := fmt.Sprintf("Spotify embed extracted (ID: %s)", spotifyID)
+	se.printLog(logMsg)
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "spotify",
+		ID:      spotifyID,
+		Params:  params,
+	}
+}
+
+func (se *SpotifyExtractor) printLog(args ...interface{}) {
+	if se.logger != nil {
+		se.logger.PrintVisibilityInfo(args...)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_Spotify_Extract(t *testing.T) {
+	spotify := dom.CreateElement("iframe")
+	dom.SetAttribute(spotify, "src", "https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC?utm_source=generator")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewSpotifyExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(spotify)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "spotify", result.Type)
+	assert.Equal(t, "4uLU6hMCjMI75M1A2tKUQC", result.ID)
+	assert.Equal(t, "track", result.Params["kind"])
+	assert.Equal(t, "generator", result.Params["utm_source"])
+	assert.Equal(t, "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC", result.Params["url"])
+
+	// Legacy podcast player
+	podcast := dom.CreateElement("iframe")
+	dom.SetAttribute(podcast, "src", "//open.spotify.com/embed-podcast/episode/0abc")
+
+	result, _ = (extractor.Extract(podcast)).(*webdoc.Embed)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0abc", result.ID)
+	assert.Equal(t, "episode", result.Params["kind"])
+
+	// Begin negative test
+	notPlayer := dom.CreateElement("iframe")
+	dom.SetAttribute(notPlayer, "src", "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC")
+
+	result, _ = (extractor.Extract(notPlayer)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	"fmt"
+	nurl "net/url"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// TikTokExtractor is used to look for TikTok videos, either the unrendered
+// blockquote from the official embed code or the iframe player.
+type TikTokExtractor struct {
+	PageURL *nurl.URL
+	logger  logutil.Logger
+}
+
+func NewTikTokExtractor(pageURL *nurl.URL, logger logutil.Logger) *TikTokExtractor {
+	return &TikTokExtractor{
+		PageURL: pageURL,
+		logger:  logger,
+	}
+}
+
+func (te *TikTokExtractor) RelevantTagNames() []string {
+	tagNames := []string{}
+	for tagName := range relevantTikTokTags {
+		tagNames = append(tagNames, tagName)
+	}
+	return tagNames
+}
+
+func (te *TikTokExtractor) Extract(node *html.Node) webdoc.Element {
+	if node == nil {
+		return nil
+	}
+
+	nodeTagName := dom.TagName(node)
+	if _, exist := relevantTikTokTags[nodeTagName]; !exist {
+		return nil
+	}
+
+	var result *webdoc.Embed
+	if nodeTagName == "blockquote" {
+		result = te.extractNonRendered(node)
+	} else {
+		result = te.extractRendered(node)
+	}
+
+	if result != nil {
+		logMsg := fmt.Sprintf("TikTok embed extracted (ID: %s)", result.ID)
+		te.printLog(logMsg)
+		return result
+	}
+
+	return nil
+}
+
+// extractNonRendered handle a TikTok embed that has not yet been rendered, which
+// is a blockquote that cites the video URL, i.e. "/@<user>/video/<id>".
+func (te *TikTokExtractor) extractNonRendered(node *html.Node) *webdoc.Embed {
+	if !hasClass(node, "tiktok-embed") {
+		return nil
+	}
+
+	videoURL := parseEmbedURL(dom.GetAttribute(node, "cite"), te.PageURL)
+	if !hasRootDomain(videoURL, "tiktok.com") {
+		return nil
+	}
+
+	videoID := dom.GetAttribute(node, "data-video-id")
+	if videoID == "" {
+		videoID = te.getVideoIdFromURL(videoURL)
+	}
+
+	if videoID == "" {
+		return nil
+	}
+
+	params := map[string]string{"url": videoURL.String()}
+	if pathParts := urlPathParts(videoURL); len(pathParts) > 0 && pathParts[0][0] == '@' {
+		params["user"] = pathParts[0][1:]
+	}
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "tiktok",
+		ID:      videoID,
+		Params:  params,
+	}
+}
+
+// extractRendered handle a TikTok player iframe, e.g. "/embed/v2/<id>".
+func (te *TikTokExtractor) extractRendered(node *html.Node) *webdoc.Embed {
+	src := parseEmbedURL(dom.GetAttribute(node, "src"), te.PageURL)
+	if !hasRootDomain(src, "tiktok.com") {
+		return nil
+	}
+
+	pathParts := urlPathParts(src)
+	if len(pathParts) < 2 || (pathParts[0] != "embed" && pathParts[0] != "player") {
+		return nil
+	}
+
+	videoID := te.getVideoIdFromURL(src)
+	if videoID == "" {
+		return nil
+	}
+
+	return &webdoc.Embed{
+		Element: node,
+		Type:    "tiktok",
+		ID:      videoID,
+		Params:  urlQueryParams(src),
+	}
+}
+
+// getVideoIdFromURL returns the last part of the path if it's numeric.
+func (te *TikTokExtractor) getVideoIdFromURL(videoURL *nurl.URL) string {
+	pathParts := urlPathParts(videoURL)
+	if len(pathParts) == 0 {
+		return ""
+	}
+
+	videoID := pathParts[len(pathParts)-1]
+	for _, r := range videoID {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+
+	return videoID
+}
+
+func (te *TikTokExtractor) printLog(args ...interface{}) {
+	if te.logger != nil {
+		te.logger.PrintVisibilityInfo(args...)
+	}
+}
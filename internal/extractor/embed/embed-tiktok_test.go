@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed_test
+
+import (
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/extractor/embed"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Embed_TikTok_ExtractNotRendered(t *testing.T) {
+	video := dom.CreateElement("blockquote")
+	dom.SetAttribute(video, "class", "tiktok-embed")
+	dom.SetAttribute(video, "cite", "https://www.tiktok.com/@someone/video/7012345678901234567")
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com")
+	extractor := embed.NewTikTokExtractor(pageURL, nil)
+	result, _ := (extractor.Extract(video)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "tiktok", result.Type)
+	assert.Equal(t, "7012345678901234567", result.ID)
+	assert.Equal(t, "someone", result.Params["user"])
+	assert.Equal(t, "https://www.tiktok.com/@someone/video/7012345678901234567", result.Params["url"])
+
+	// Begin negative test
+	profile := dom.CreateElement("blockquote")
+	dom.SetAttribute(profile, "class", "tiktok-embed")
+	dom.SetAttribute(profile, "cite", "https://www.tiktok.com/@someone")
+
+	result, _ = (extractor.Extract(profile)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
+
+func Test_Embed_TikTok_ExtractRendered(t *testing.T) {
+	iframe := dom.CreateElement("iframe")
+	dom.SetAttribute(iframe, "src", "https://www.tiktok.com/embed/v2/7012345678901234567?lang=en-US")
+
+	extractor := embed.NewTikTokExtractor(nil, nil)
+	result, _ := (extractor.Extract(iframe)).(*webdoc.Embed)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "tiktok", result.Type)
+	assert.Equal(t, "7012345678901234567", result.ID)
+	assert.Equal(t, "en-US", result.Params["lang"])
+
+	// Begin negative test
+	wrongDomain := dom.CreateElement("iframe")
+	dom.SetAttribute(wrongDomain, "src", "https://example.com/embed/v2/7012345678901234567")
+
+	result, _ = (extractor.Extract(wrongDomain)).(*webdoc.Embed)
+	assert.Nil(t, result)
+}
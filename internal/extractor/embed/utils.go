@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embed
+
+import (
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+	"golang.org/x/net/html"
+)
+
+// parseEmbedURL converts the URL into absolute URL then parses it. Returns nil
+// if the URL is empty or invalid.
+func parseEmbedURL(url string, pageURL *nurl.URL) *nurl.URL {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return nil
+	}
+
+	url = stringutil.CreateAbsoluteURL(url, pageURL)
+	if strings.HasPrefix(url, "//") {
+		url = "http:" + url
+	}
+
+	parsedURL, err := nurl.ParseRequestURI(url)
+	if err != nil || parsedURL.Hostname() == "" {
+		return nil
+	}
+
+	return parsedURL
+}
+
+// hasRootDomain checks if the parsed URL has one of the specified root domains.
+func hasRootDomain(url *nurl.URL, roots ...string) bool {
+	if url == nil {
+		return false
+	}
+
+	host := strings.ToLower(url.Hostname())
+	for _, root := range roots {
+		if host == root || strings.HasSuffix(host, "."+root) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// urlPathParts returns the non empty sections of the URL path.
+func urlPathParts(url *nurl.URL) []string {
+	var parts []string
+	for _, part := range strings.Split(url.Path, "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// urlQueryParams returns parameters of the URL. In case of queries that specified
+// several times, only use the last value. The excluded keys are not returned.
+func urlQueryParams(url *nurl.URL, excludedKeys ...string) map[string]string {
+	params := make(map[string]string)
+	for key, values := range url.Query() {
+		if nValue := len(values); nValue > 0 {
+			params[key] = values[nValue-1]
+		}
+	}
+
+	for _, key := range excludedKeys {
+		delete(params, key)
+	}
+
+	return params
+}
+
+// hasClass checks if the node has the specified class name.
+func hasClass(node *html.Node, className string) bool {
+	for _, name := range strings.Fields(dom.ClassName(node)) {
+		if name == className {
+			return true
+		}
+	}
+	return false
+}
+
+// lastAnchorURL returns href of the last anchor inside the node which has one
+// of the specified root domains. Used by the unrendered embeds that only consist
+// of blockquote with link to the original post.
+func lastAnchorURL(node *html.Node, pageURL *nurl.URL, roots ...string) *nurl.URL {
+	anchors := dom.GetElementsByTagName(node, "a")
+	for i := len(anchors) - 1; i >= 0; i-- {
+		href := parseEmbedURL(dom.GetAttribute(anchors[i], "href"), pageURL)
+		if hasRootDomain(href, roots...) {
+			return href
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extractor
+
+import (
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/imageclass"
+	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+)
+
+// ExtractLeadImage returns the lead image of the article. It's the image that picked by
+// the lead image finder, or the first image in the content if none of the lead image
+// candidates is accepted. If the content doesn't have any image, the first image in the
+// metadata (e.g. og:image) which is not classified as junk is used instead. Returns nil
+// if there are no image at all.
+// Must be called after the content is extracted.
+func (ce *ContentExtractor) ExtractLeadImage() *data.LeadImage {
+	if ce.leadImage != nil {
+		return ce.leadImage
+	}
+
+	classifier := imageclass.NewClassifier(ce.ImageBlocklist, ce.logger)
+	for _, image := range ce.Parser.MarkupInfo().Images {
+		url := image.SecureURL
+		if url == "" {
+			url = image.URL
+		}
+		if url == "" {
+			url = image.Root
+		}
+		if url == "" {
+			continue
+		}
+
+		url = stringutil.CreateAbsoluteURL(url, ce.pageURL)
+		if imageType, _ := classifier.ClassifyURL(url, image.Width, image.Height); imageType != imageclass.Content {
+			continue
+		}
+
+		return &data.LeadImage{
+			ContentImage: data.ContentImage{
+				URL:    url,
+				Alt:    image.Caption,
+				Width:  image.Width,
+				Height: image.Height,
+			},
+			Source:       data.LeadImageFromMarkup,
+			MarkupSource: image.Source,
+		}
+	}
+
+	return nil
+}
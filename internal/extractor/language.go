@@ -0,0 +1,184 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extractor
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/langutil"
+)
+
+const (
+	// minLanguageConfidence is the minimum confidence of the detected language
+	// before it's allowed to override the language declared by the page.
+	minLanguageConfidence = 0.3
+
+	// maxLanguageSampleLength is the maximum length in bytes of text in paragraphs
+	// that used to detect language before the content is extracted.
+	maxLanguageSampleLength = 5000
+)
+
+// ExtractLanguage returns the language of the document. If the language is not specified
+// in ContentExtractor.Language, it's taken from <html lang>, Content-Language and og:locale
+// in that order. The declared language is checked against the language detected from the
+// paragraphs in the page, since many sites use a template with a wrong language. However,
+// the detector only knows a few languages for each script, so the declared language is only
+// overridden if the detector is able to recognize it (e.g. it's kept for Bulgarian page which
+// detected as Russian), or if the text is written in a different script.
+//
+// The detection runs before the content is extracted, since the language is needed by the
+// filters that decide which blocks are content. So, the text in navigation, comments or
+// footer that written in <p> counts as well, which is fine for most pages since they share
+// the language with the content. The language is only detected once, so every filter
+// uses the same language. Use ExtractContentLanguage to check it against the distilled text.
+func (ce *ContentExtractor) ExtractLanguage() data.Language {
+	if ce.language == nil {
+		language := ce.detectLanguage()
+		ce.language = &language
+	}
+	return *ce.language
+}
+
+func (ce *ContentExtractor) detectLanguage() data.Language {
+	if tag := langutil.Normalize(ce.Language); tag != "" {
+		return newLanguage(tag, data.LanguageFromOption)
+	}
+
+	declared, source := ce.declaredLanguage()
+	detected, confidence := langutil.Detect(ce.sampleText())
+	return chooseLanguage(declared, source, detected, confidence)
+}
+
+// ExtractContentLanguage checks the language from ExtractLanguage against the language
+// detected from the distilled text, which is more reliable than the paragraphs of the
+// whole page since it doesn't contain navigation, comments or footer. It follows the
+// same rule, so the detected language only overrides the current one if it's confident
+// enough and the detector is able to recognize the current language.
+func (ce *ContentExtractor) ExtractContentLanguage(text string) data.Language {
+	language := ce.ExtractLanguage()
+	if language.Source == data.LanguageFromOption {
+		return language
+	}
+
+	detected, confidence := langutil.Detect(truncateText(text, maxLanguageSampleLength))
+	return chooseLanguage(language.Tag, language.Source, detected, confidence)
+}
+
+// chooseLanguage decides between the declared and the detected language.
+func chooseLanguage(declared string, source data.LanguageSource, detected string, confidence float64) data.Language {
+	switch {
+	case declared == "" && detected == "":
+		return data.Language{}
+	case declared == "":
+		return newLanguage(detected, data.LanguageFromText)
+	case detected != "" && confidence >= minLanguageConfidence &&
+		langutil.Base(declared) != langutil.Base(detected) &&
+		(langutil.CanDetect(declared) || langutil.Script(declared) != langutil.Script(detected)):
+		return newLanguage(detected, data.LanguageFromText)
+	default:
+		return newLanguage(declared, source)
+	}
+}
+
+// declaredLanguage returns the language that declared by the page.
+func (ce *ContentExtractor) declaredLanguage() (string, data.LanguageSource) {
+	if tag := langutil.Normalize(dom.GetAttribute(ce.documentElement, "lang")); tag != "" {
+		return tag, data.LanguageFromHTML
+	}
+
+	if tag := langutil.Normalize(dom.GetAttribute(ce.documentElement, "xml:lang")); tag != "" {
+		return tag, data.LanguageFromHTML
+	}
+
+	if tag := langutil.Normalize(ce.ContentLanguage); tag != "" {
+		return tag, data.LanguageFromHeader
+	}
+
+	var contentLanguage, ogLocale string
+	for _, meta := range dom.GetElementsByTagName(ce.documentElement, "meta") {
+		switch {
+		case strings.EqualFold(dom.GetAttribute(meta, "http-equiv"), "content-language"):
+			if contentLanguage == "" {
+				contentLanguage = langutil.Normalize(dom.GetAttribute(meta, "content"))
+			}
+		case strings.EqualFold(dom.GetAttribute(meta, "property"), "og:locale"):
+			if ogLocale == "" {
+				ogLocale = langutil.Normalize(dom.GetAttribute(meta, "content"))
+			}
+		}
+	}
+
+	if contentLanguage != "" {
+		return contentLanguage, data.LanguageFromHeader
+	}
+
+	if ogLocale != "" {
+		return ogLocale, data.LanguageFromOpenGraph
+	}
+
+	return "", ""
+}
+
+// sampleText returns the text of paragraphs in the page, which used to detect the
+// language before the content is extracted. If there are no paragraphs, the text
+// of the body is used instead.
+func (ce *ContentExtractor) sampleText() string {
+	var sb strings.Builder
+	for _, p := range dom.GetElementsByTagName(ce.documentElement, "p") {
+		sb.WriteString(dom.TextContent(p))
+		sb.WriteString(" ")
+		if sb.Len() >= maxLanguageSampleLength {
+			break
+		}
+	}
+
+	if strings.TrimSpace(sb.String()) == "" {
+		if body := dom.QuerySelector(ce.documentElement, "body"); body != nil {
+			return truncateText(dom.TextContent(body), maxLanguageSampleLength)
+		}
+	}
+
+	return truncateText(sb.String(), maxLanguageSampleLength)
+}
+
+// truncateText cuts the text to at most maxLength bytes
+// without splitting a multibyte character.
+func truncateText(text string, maxLength int) string {
+	if len(text) <= maxLength {
+		return text
+	}
+
+	for maxLength > 0 && !utf8.RuneStart(text[maxLength]) {
+		maxLength--
+	}
+	return text[:maxLength]
+}
+
+func newLanguage(tag string, source data.LanguageSource) data.Language {
+	return data.Language{
+		Tag:       tag,
+		Direction: langutil.Direction(tag),
+		Source:    source,
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package extractor_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/extractor"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+const (
+	englishText = "The committee said that the report was published for the first time in the " +
+		"morning, and the results are expected to be discussed with the members of the board."
+	germanText = "Die Regierung hat am Dienstag beschlossen, dass die neuen Regeln für den Verkehr " +
+		"in der Stadt ab nächster Woche gelten und mit den Bürgern besprochen werden."
+	bulgarianText = "Правителството обяви във вторник, че новите правила за движението в града ще " +
+		"влязат в сила от следващата седмица и ще бъдат обсъдени с гражданите."
+	urduText = "حکومت نے منگل کو اعلان کیا کہ شہر میں ٹریفک کے نئے قواعد اگلے ہفتے سے نافذ ہوں گے اور " +
+		"شہریوں کے ساتھ ان پر بات چیت کی جائے گی۔"
+	polishText = "Rząd ogłosił we wtorek, że nowe zasady ruchu drogowego w mieście będą obowiązywać " +
+		"od przyszłego tygodnia i zostaną omówione z mieszkańcami oraz radnymi."
+	arabicText = "أعلنت الحكومة يوم الثلاثاء أن القواعد الجديدة للمرور في المدينة ستطبق اعتبارا من الأسبوع المقبل."
+)
+
+func Test_Extractor_Language_HTMLLang(t *testing.T) {
+	doc, body := createHTML()
+	dom.SetAttribute(doc, "lang", "en_us")
+	dom.SetInnerHTML(body, "<p>"+englishText+"</p>")
+
+	assert.Equal(t, data.Language{
+		Tag:       "en-US",
+		Direction: "ltr",
+		Source:    data.LanguageFromHTML,
+	}, extractLanguage(doc, ""))
+}
+
+func Test_Extractor_Language_Fallbacks(t *testing.T) {
+	// Content-Language from HTTP header
+	doc, body := createHTML()
+	dom.RemoveAttribute(doc, "lang")
+	dom.SetInnerHTML(body, "<p>"+germanText+"</p>")
+	ce := extractor.NewContentExtractor(doc, testPageURL(), nil)
+	ce.ContentLanguage = "de-AT, en"
+	assert.Equal(t, "de-AT", ce.ExtractLanguage().Tag)
+	assert.Equal(t, data.LanguageFromHeader, ce.ExtractLanguage().Source)
+
+	// Content-Language from <meta http-equiv>
+	doc, body = createHTML()
+	dom.RemoveAttribute(doc, "lang")
+	dom.SetInnerHTML(body, "<p>"+germanText+"</p>")
+	appendMeta(dom.QuerySelector(doc, "head"), "http-equiv", "Content-Language", "de-CH")
+	createMeta(doc, "og:locale", "de_DE")
+	assert.Equal(t, "de-CH", extractLanguage(doc, "").Tag)
+
+	// og:locale
+	doc, body = createHTML()
+	dom.RemoveAttribute(doc, "lang")
+	dom.SetInnerHTML(body, "<p>"+germanText+"</p>")
+	createMeta(doc, "og:locale", "de_DE")
+	language := extractLanguage(doc, "")
+	assert.Equal(t, "de-DE", language.Tag)
+	assert.Equal(t, data.LanguageFromOpenGraph, language.Source)
+}
+
+func Test_Extractor_Language_Detected(t *testing.T) {
+	// Nothing declared, so the language is detected from text.
+	doc, body := createHTML()
+	dom.RemoveAttribute(doc, "lang")
+	dom.SetInnerHTML(body, "<p>"+arabicText+"</p>")
+	assert.Equal(t, data.Language{
+		Tag:       "ar",
+		Direction: "rtl",
+		Source:    data.LanguageFromText,
+	}, extractLanguage(doc, ""))
+
+	// Declared language is contradicted by the text.
+	doc, body = createHTML()
+	dom.SetAttribute(doc, "lang", "en")
+	dom.SetInnerHTML(body, "<p>"+germanText+"</p>")
+	language := extractLanguage(doc, "")
+	assert.Equal(t, "de", language.Tag)
+	assert.Equal(t, data.LanguageFromText, language.Source)
+
+	// Too short text can't contradict the declared language.
+	doc, body = createHTML()
+	dom.SetAttribute(doc, "lang", "en")
+	dom.SetInnerHTML(body, "<p>Die Regierung</p>")
+	assert.Equal(t, "en", extractLanguage(doc, "").Tag)
+}
+
+func Test_Extractor_Language_UnknownToDetector(t *testing.T) {
+	// The detector doesn't know these languages and detects them as other language
+	// in the same script, so the declared language must be kept.
+	tests := []struct {
+		tag  string
+		text string
+	}{
+		{"bg", bulgarianText},
+		{"ur", urduText},
+		{"pl", polishText},
+	}
+
+	for _, test := range tests {
+		doc, body := createHTML()
+		dom.SetAttribute(doc, "lang", test.tag)
+		dom.SetInnerHTML(body, "<p>"+test.text+"</p>")
+		language := extractLanguage(doc, "")
+		assert.Equal(t, test.tag, language.Tag, test.tag)
+		assert.Equal(t, data.LanguageFromHTML, language.Source, test.tag)
+	}
+
+	// However, it's still overridden if the text is written in different script.
+	doc, body := createHTML()
+	dom.SetAttribute(doc, "lang", "bg")
+	dom.SetInnerHTML(body, "<p>"+arabicText+"</p>")
+	assert.Equal(t, "ar", extractLanguage(doc, "").Tag)
+}
+
+func Test_Extractor_Language_LongParagraph(t *testing.T) {
+	// Only the beginning of a huge paragraph is used for detection
+	doc, body := createHTML()
+	dom.RemoveAttribute(doc, "lang")
+	dom.SetInnerHTML(body, "<p>"+strings.Repeat(germanText+" ", 40)+strings.Repeat(englishText+" ", 120)+"</p>")
+	assert.Equal(t, "de", extractLanguage(doc, "").Tag)
+}
+
+func Test_Extractor_Language_ContentText(t *testing.T) {
+	// Language from the page is checked against the distilled text
+	doc, body := createHTML()
+	dom.SetAttribute(doc, "lang", "en")
+	dom.SetInnerHTML(body, "<p>"+englishText+"</p>")
+	ce := extractor.NewContentExtractor(doc, testPageURL(), nil)
+	ce.ExtractContent()
+	assert.Equal(t, "en", ce.ExtractLanguage().Tag)
+	assert.Equal(t, data.Language{
+		Tag:       "de",
+		Direction: "ltr",
+		Source:    data.LanguageFromText,
+	}, ce.ExtractContentLanguage(germanText))
+	assert.Equal(t, "en", ce.ExtractContentLanguage(englishText).Tag)
+
+	// Language from option is never overridden
+	ce = extractor.NewContentExtractor(doc, testPageURL(), nil)
+	ce.Language = "en"
+	assert.Equal(t, "en", ce.ExtractContentLanguage(germanText).Tag)
+}
+
+func Test_Extractor_Language_Option(t *testing.T) {
+	doc, body := createHTML()
+	dom.SetAttribute(doc, "lang", "en")
+	dom.SetInnerHTML(body, "<p>"+germanText+"</p>")
+	assert.Equal(t, data.Language{
+		Tag:       "fr",
+		Direction: "ltr",
+		Source:    data.LanguageFromOption,
+	}, extractLanguage(doc, "fr"))
+}
+
+func extractLanguage(doc *html.Node, forcedLanguage string) data.Language {
+	ce := extractor.NewContentExtractor(doc, testPageURL(), nil)
+	ce.Language = forcedLanguage
+	ce.ExtractContent()
+	return ce.ExtractLanguage()
+}
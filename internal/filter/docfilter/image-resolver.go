@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package docfilter
+
+import (
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/markusmobius/go-domdistiller/srcset"
+)
+
+// ImageResolver replaces the src of images in the document with the best
+// candidate from their srcset and <picture> sources, so clients that don't
+// support responsive images get the proper image instead of thumbnail.
+type ImageResolver struct {
+	resolver *srcset.Resolver
+}
+
+func NewImageResolver(resolver *srcset.Resolver) *ImageResolver {
+	return &ImageResolver{
+		resolver: resolver,
+	}
+}
+
+func (f *ImageResolver) Process(doc *webdoc.Document) bool {
+	if f.resolver == nil {
+		return false
+	}
+
+	changes := false
+	for _, e := range doc.Elements {
+		switch element := e.(type) {
+		case *webdoc.Image:
+			changes = f.resolveImage(element) || changes
+		case *webdoc.Figure:
+			changes = f.resolveImage(&element.Image) || changes
+		case *webdoc.Table:
+			for _, img := range dom.GetElementsByTagName(element.Element, "img") {
+				changes = f.resolver.Resolve(img) || changes
+			}
+		}
+	}
+
+	return changes
+}
+
+func (f *ImageResolver) resolveImage(image *webdoc.Image) bool {
+	img := domutil.GetFirstElementByTagNameInc(image.Element, "img")
+	if img == nil {
+		return false
+	}
+
+	src := dom.GetAttribute(img, "src")
+	if !f.resolver.Resolve(img) {
+		return false
+	}
+
+	if image.OriginalSrc == "" {
+		image.OriginalSrc = src
+	}
+	return true
+}
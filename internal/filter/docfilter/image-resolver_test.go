@@ -0,0 +1,48 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package docfilter_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/filter/docfilter"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/markusmobius/go-domdistiller/srcset"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Filter_DocFilter_ImageResolver(t *testing.T) {
+	builder := testutil.NewWebDocumentBuilder()
+	image := builder.AddImage()
+	dom.SetAttribute(image.Element, "srcset", "http://www.example.com/foo-800.jpg 800w")
+	builder.AddText("text 1").SetIsContent(true)
+	document := builder.Build()
+
+	// Without resolver nothing changed
+	assert.False(t, docfilter.NewImageResolver(nil).Process(document))
+	assert.Equal(t, "http://www.example.com/foo.jpg", dom.GetAttribute(image.Element, "src"))
+
+	resolver := &srcset.Resolver{TargetWidth: 600}
+	assert.True(t, docfilter.NewImageResolver(resolver).Process(document))
+	assert.Equal(t, "http://www.example.com/foo-800.jpg", dom.GetAttribute(image.Element, "src"))
+	assert.Equal(t, "http://www.example.com/foo.jpg", image.OriginalSrc)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package docfilter
+
+import (
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/imageclass"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"golang.org/x/net/html"
+)
+
+// JunkImageFilter removes images that are not part of the content, e.g. tracking
+// pixels, share button icons, author avatars, emoji and ads. It should be run before
+// the other filters, so those images are never used as content nor lead image.
+type JunkImageFilter struct {
+	classifier *imageclass.Classifier
+}
+
+func NewJunkImageFilter(blocklist []string, logger logutil.Logger) *JunkImageFilter {
+	return &JunkImageFilter{
+		classifier: imageclass.NewClassifier(blocklist, logger),
+	}
+}
+
+func (f *JunkImageFilter) Process(doc *webdoc.Document) bool {
+	changes := false
+	elements := make([]webdoc.Element, 0, len(doc.Elements))
+
+	for _, e := range doc.Elements {
+		switch element := e.(type) {
+		case *webdoc.Image:
+			if f.isJunk(element.Element) {
+				changes = true
+				continue
+			}
+
+		case *webdoc.Figure:
+			if f.isJunk(element.Element) {
+				changes = true
+				continue
+			}
+
+		case *webdoc.Table:
+			// Table is kept as it is, so only remove the junk images inside it.
+			for _, img := range dom.GetElementsByTagName(element.Element, "img") {
+				if img.Parent != nil && f.isJunk(img) {
+					img.Parent.RemoveChild(img)
+					changes = true
+				}
+			}
+		}
+
+		elements = append(elements, e)
+	}
+
+	doc.Elements = elements
+	return changes
+}
+
+func (f *JunkImageFilter) isJunk(node *html.Node) bool {
+	imageType, _ := f.classifier.Classify(node)
+	return imageType != imageclass.Content
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package docfilter_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/filter/docfilter"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Filter_DocFilter_JunkImage(t *testing.T) {
+	builder := testutil.NewWebDocumentBuilder()
+	pixel := builder.AddImage()
+	dom.SetAttribute(pixel.Element, "width", "1")
+	dom.SetAttribute(pixel.Element, "height", "1")
+	leadImage := builder.AddLeadImage()
+	blocked := builder.AddImage()
+	dom.SetAttribute(blocked.Element, "src", "http://ads.example.com/banner.jpg")
+	builder.AddText("text 1").SetIsContent(true)
+
+	document := builder.Build()
+	assert.True(t, docfilter.NewJunkImageFilter([]string{"ads.example.com"}, nil).Process(document))
+	assert.Equal(t, 2, len(document.Elements))
+	assert.Equal(t, leadImage, document.Elements[0])
+
+	// Nothing else to remove
+	assert.False(t, docfilter.NewJunkImageFilter(nil, nil).Process(document))
+}
@@ -30,6 +30,7 @@ import (
 	"fmt"
 
 	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
 	"github.com/markusmobius/go-domdistiller/internal/filter/docfilter/scorer"
 	"github.com/markusmobius/go-domdistiller/internal/logutil"
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
@@ -49,8 +50,19 @@ const imageMinimumAcceptedScore = 13
 // because it would require us to compute the stylesheet (NEED-COMPUTE-CSS):
 // - The ratio of width/height.
 // - The area of the image (width * height) relative to its container.
+//
+// If none of the candidates is accepted, the first image that already marked as content
+// is reported as the lead image, but it's not used to modify the document.
 type LeadImageFinder struct {
-	logger logutil.Logger
+	logger    logutil.Logger
+	leadImage *data.LeadImage
+}
+
+// leadHeuristic is an image scorer along with its name, which used
+// to report the score breakdown of the lead image.
+type leadHeuristic struct {
+	name string
+	scorer.ImageScorer
 }
 
 func NewLeadImageFinder(logger logutil.Logger) *LeadImageFinder {
@@ -59,7 +71,22 @@ func NewLeadImageFinder(logger logutil.Logger) *LeadImageFinder {
 	}
 }
 
+// LeadImage returns the lead image that found in the last Process,
+// along with its score. Returns nil if there are no lead image.
+func (f *LeadImageFinder) LeadImage() *data.LeadImage {
+	return f.leadImage
+}
+
+// Process looks for the lead image in the document, then saves it
+// in the LeadImage of the document.
 func (f *LeadImageFinder) Process(doc *webdoc.Document) bool {
+	f.leadImage = nil
+	changed := f.process(doc)
+	doc.LeadImage = f.leadImage
+	return changed
+}
+
+func (f *LeadImageFinder) process(doc *webdoc.Document) bool {
 	candidates := []webdoc.Element{}
 	var firstContent, lastContent *webdoc.Text
 
@@ -98,7 +125,12 @@ func (f *LeadImageFinder) Process(doc *webdoc.Document) bool {
 		}
 	}
 
-	return f.findLeadImage(candidates, firstContent)
+	if f.findLeadImage(candidates, firstContent) {
+		return true
+	}
+
+	f.findContentImage(doc, firstContent)
+	return false
 }
 
 func (f *LeadImageFinder) findLeadImage(candidates []webdoc.Element, firstContent *webdoc.Text) bool {
@@ -114,13 +146,15 @@ func (f *LeadImageFinder) findLeadImage(candidates []webdoc.Element, firstConten
 	bestScore := 0
 	heuristics := f.getLeadHeuristics(contentElement)
 	var bestImage webdoc.Element
+	var bestScores map[string]int
 
 	for _, candidate := range candidates {
-		currentScore := f.getImageScore(candidate, heuristics)
+		currentScore, currentScores := f.getImageScore(candidate, heuristics)
 		if currentScore > imageMinimumAcceptedScore {
 			if bestImage == nil || bestScore < currentScore {
 				bestImage = candidate
 				bestScore = currentScore
+				bestScores = currentScores
 			}
 		}
 	}
@@ -130,19 +164,67 @@ func (f *LeadImageFinder) findLeadImage(candidates []webdoc.Element, firstConten
 	}
 
 	bestImage.SetIsContent(true)
+	f.setLeadImage(bestImage, bestScore, bestScores)
+	return true
+}
+
+// findContentImage reports the first image which already marked as content as
+// the lead image. It's used when none of the lead image candidates is accepted.
+func (f *LeadImageFinder) findContentImage(doc *webdoc.Document, firstContent *webdoc.Text) {
+	var contentElement *html.Node
+	if firstContent != nil {
+		contentElement = firstContent.FirstNonWhitespaceTextNode()
+	}
+
+	for _, e := range doc.Elements {
+		switch e.(type) {
+		case *webdoc.Image, *webdoc.Figure:
+			if !e.IsContent() {
+				continue
+			}
+
+			score, scores := f.getImageScore(e, f.getLeadHeuristics(contentElement))
+			if f.setLeadImage(e, score, scores) {
+				return
+			}
+		}
+	}
+}
+
+// setLeadImage saves the element as lead image. Returns false if
+// the element doesn't contain any usable image.
+func (f *LeadImageFinder) setLeadImage(e webdoc.Element, score int, scores map[string]int) bool {
+	var image *data.ContentImage
+	switch element := e.(type) {
+	case *webdoc.Image:
+		image = element.ContentImage()
+	case *webdoc.Figure:
+		image = element.ContentImage()
+	}
+
+	if image == nil {
+		return false
+	}
+
+	f.leadImage = &data.LeadImage{
+		ContentImage: *image,
+		Source:       data.LeadImageFromContent,
+		Score:        score,
+		Scores:       scores,
+	}
 	return true
 }
 
-func (f *LeadImageFinder) getImageScore(e webdoc.Element, heuristics []scorer.ImageScorer) int {
+func (f *LeadImageFinder) getImageScore(e webdoc.Element, heuristics []leadHeuristic) (int, map[string]int) {
 	if e == nil || len(heuristics) == 0 {
-		return 0
+		return 0, nil
 	}
 
 	var imgNode *html.Node
 	webImage, isImage := e.(*webdoc.Image)
 	webFigure, isFigure := e.(*webdoc.Figure)
 	if !isImage && !isFigure {
-		return 0
+		return 0, nil
 	} else if isImage {
 		imgNode = webImage.Element
 	} else {
@@ -150,20 +232,23 @@ func (f *LeadImageFinder) getImageScore(e webdoc.Element, heuristics []scorer.Im
 	}
 
 	score := 0
+	scores := make(map[string]int, len(heuristics))
 	for _, ir := range heuristics {
-		score += ir.GetImageScore(imgNode)
+		heuristicScore := ir.GetImageScore(imgNode)
+		scores[ir.name] = heuristicScore
+		score += heuristicScore
 	}
 
 	f.logFinalScore(imgNode, score)
-	return score
+	return score, scores
 }
 
-func (f *LeadImageFinder) getLeadHeuristics(firstContent *html.Node) []scorer.ImageScorer {
-	return []scorer.ImageScorer{
-		// scorer.NewImageAreaScorer(25, 75_000, 200_000),
-		// scorer.NewImageRatioScorer(25),
-		scorer.NewImageDomDistanceScorer(25, firstContent),
-		scorer.NewImageHasFigureScorer(15),
+func (f *LeadImageFinder) getLeadHeuristics(firstContent *html.Node) []leadHeuristic {
+	return []leadHeuristic{
+		// {"area", scorer.NewImageAreaScorer(25, 75_000, 200_000)},
+		// {"ratio", scorer.NewImageRatioScorer(25)},
+		{"dom-distance", scorer.NewImageDomDistanceScorer(25, firstContent)},
+		{"has-figure", scorer.NewImageHasFigureScorer(15)},
 	}
 }
 
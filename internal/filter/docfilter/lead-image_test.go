@@ -29,6 +29,7 @@ package docfilter_test
 import (
 	"testing"
 
+	"github.com/markusmobius/go-domdistiller/data"
 	"github.com/markusmobius/go-domdistiller/internal/filter/docfilter"
 	"github.com/markusmobius/go-domdistiller/internal/testutil"
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
@@ -119,3 +120,52 @@ func Test_Filter_DocFilter_LIF_IrrelevantLeadImageAfterSingleText(t *testing.T)
 	assert.False(t, docfilter.NewLeadImageFinder(nil).Process(document))
 	assert.False(t, wi.IsContent())
 }
+
+func Test_Filter_DocFilter_LIF_LeadImageScores(t *testing.T) {
+	builder := testutil.NewWebDocumentBuilder()
+	builder.AddLeadImage()
+	builder.AddText("text 1").SetIsContent(true)
+
+	document := builder.Build()
+	finder := docfilter.NewLeadImageFinder(nil)
+	assert.True(t, finder.Process(document))
+
+	leadImage := finder.LeadImage()
+	assert.NotNil(t, leadImage)
+	assert.Same(t, leadImage, document.LeadImage)
+	assert.Equal(t, "http://www.example.com/lead.bmp", leadImage.URL)
+	assert.Equal(t, 600, leadImage.Width)
+	assert.Equal(t, 400, leadImage.Height)
+	assert.Equal(t, data.LeadImageFromContent, leadImage.Source)
+	assert.Equal(t, 25, leadImage.Score)
+	assert.Equal(t, map[string]int{"dom-distance": 25, "has-figure": 0}, leadImage.Scores)
+}
+
+func Test_Filter_DocFilter_LIF_ContentImageAsLeadImage(t *testing.T) {
+	builder := testutil.NewWebDocumentBuilder()
+	builder.AddText("text 1").SetIsContent(true)
+	builder.AddImage().SetIsContent(true)
+	builder.AddText("text 2").SetIsContent(true)
+
+	// No candidates, but the content image is reported as lead image
+	document := builder.Build()
+	finder := docfilter.NewLeadImageFinder(nil)
+	assert.False(t, finder.Process(document))
+
+	leadImage := finder.LeadImage()
+	assert.NotNil(t, leadImage)
+	assert.Equal(t, "http://www.example.com/foo.jpg", leadImage.URL)
+	assert.Equal(t, data.LeadImageFromContent, leadImage.Source)
+}
+
+func Test_Filter_DocFilter_LIF_NoLeadImage(t *testing.T) {
+	builder := testutil.NewWebDocumentBuilder()
+	builder.AddText("text 1").SetIsContent(true)
+	builder.AddText("text 2").SetIsContent(true)
+	builder.AddImage()
+
+	document := builder.Build()
+	finder := docfilter.NewLeadImageFinder(nil)
+	assert.False(t, finder.Process(document))
+	assert.Nil(t, finder.LeadImage())
+}
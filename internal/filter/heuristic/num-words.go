@@ -40,7 +40,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package english
+package heuristic
 
 import (
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package localized
+
+// builtinPacks is the phrase packs that registered by default, keyed by language. Sharing
+// buttons (e.g. "shares") are included as well, see crbug.com/692553. English doesn't
+// have a pack since its phrases are always checked by TerminatingBlocksFinder.
+var builtinPacks = map[string]PhrasePack{
+	"de": {
+		Prefixes: []string{"kommentare", "kommentar schreiben", "leserkommentare"},
+		Phrases:  []string{"schreiben sie einen kommentar", "kommentar verfassen", "diskutieren sie mit"},
+		Exact:    []string{"teilen", "ihre meinung ist gefragt"},
+		Links:    []string{"kommentieren", "kommentar"},
+		Patterns: []string{`^\d+\s+kommentare?\b`},
+	},
+	"fr": {
+		Prefixes: []string{"commentaires", "laisser un commentaire", "réagir à cet article"},
+		Phrases: []string{"ajouter un commentaire", "laissez un commentaire", "donnez votre avis",
+			"réagissez à cet article", "vos commentaires"},
+		Exact:    []string{"partager"},
+		Links:    []string{"commenter", "réagir"},
+		Patterns: []string{`^\d+\s+(commentaires?|réactions?)\b`},
+	},
+	"es": {
+		Prefixes: []string{"comentarios", "deja un comentario"},
+		Phrases: []string{"añadir comentario", "deja tu comentario", "escribe un comentario",
+			"opina sobre esta noticia"},
+		Exact:    []string{"compartir", "tu opinión"},
+		Links:    []string{"comentar"},
+		Patterns: []string{`^\d+\s+comentarios?\b`},
+	},
+	"pt": {
+		Prefixes: []string{"comentários", "deixe um comentário"},
+		Phrases: []string{"adicionar comentário", "deixe seu comentário", "deixe o seu comentário",
+			"escreva um comentário"},
+		Exact:    []string{"compartilhar", "partilhar", "sua opinião"},
+		Links:    []string{"comentar"},
+		Patterns: []string{`^\d+\s+comentários?\b`},
+	},
+	"it": {
+		Prefixes: []string{"commenti", "lascia un commento"},
+		Phrases:  []string{"aggiungi un commento", "scrivi un commento", "i vostri commenti"},
+		Exact:    []string{"condividi", "la tua opinione"},
+		Links:    []string{"commenta"},
+		Patterns: []string{`^\d+\s+comment[io]\b`},
+	},
+	"ru": {
+		Prefixes: []string{"комментарии", "оставить комментарий"},
+		Phrases:  []string{"добавить комментарий", "оставьте комментарий", "написать комментарий"},
+		Exact:    []string{"поделиться", "ваше мнение"},
+		Links:    []string{"комментировать"},
+		Patterns: []string{`^\d+\s+комментари(й|я|ев)`},
+	},
+	// The single word headings in Japanese, Chinese and Indonesian are also used to start
+	// ordinary sentences, so they must be the whole block (optionally with the counter).
+	"ja": {
+		Phrases:  []string{"コメントを書く", "コメントを投稿", "コメントする", "ご意見・ご感想"},
+		Exact:    []string{"コメント", "シェア", "共有"},
+		Links:    []string{"コメント"},
+		Patterns: []string{`^\d+\s*件のコメント`, `^コメント\s*[(（]\d+[)）]$`},
+	},
+	"zh": {
+		Phrases:  []string{"发表评论", "發表評論", "我要评论", "我要評論", "相关评论"},
+		Exact:    []string{"评论", "評論", "网友评论", "網友評論", "留言", "分享"},
+		Links:    []string{"评论", "評論"},
+		Patterns: []string{`^\d+\s*条评论`, `^(网友评论|網友評論|评论|評論)\s*[(（]\d+[)）]$`},
+	},
+	"id": {
+		Prefixes: []string{"tinggalkan komentar", "tulis komentar"},
+		Phrases:  []string{"berikan komentar", "kirim komentar", "tambahkan komentar", "beri komentar"},
+		Exact:    []string{"komentar", "bagikan"},
+		Links:    []string{"komentar"},
+		Patterns: []string{`^\d+\s+komentar\b`, `^komentar\s*\(\d+\)$`},
+	},
+	"sv": {
+		Prefixes: []string{"kommentarer"},
+		Phrases:  []string{"skriv en kommentar", "lämna en kommentar"},
+		Exact:    []string{"dela"},
+		Links:    []string{"kommentera"},
+	},
+}
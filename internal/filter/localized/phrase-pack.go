@@ -0,0 +1,151 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package localized
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/markusmobius/go-domdistiller/internal/langutil"
+)
+
+// PhrasePack is the list of phrases in a language that usually found in the block that
+// marks the end of an article, e.g. the heading of comment section or a share button.
+// All phrases are matched case-insensitively.
+type PhrasePack struct {
+	// Prefixes is the phrases that found at the start of the block, e.g. "comments".
+	// Short and generic word that may start an ordinary sentence should be put in
+	// Exact instead, so it only matches when it's the whole block.
+	Prefixes []string
+
+	// Phrases is the phrases that found anywhere in the block, e.g. "add your comment".
+	Phrases []string
+
+	// Exact is the phrases that must be the whole text of the block, e.g. "shares".
+	Exact []string
+
+	// Links is the text of links that must be the whole text of the block, e.g. "comment".
+	// Only used for block which entirely a link.
+	Links []string
+
+	// Patterns is the regular expressions for phrases that can't be expressed in the
+	// fields above, e.g. `^\d+\s+comments` for comment counter.
+	Patterns []string
+}
+
+// compiledPack is PhrasePack that ready to be used by TerminatingBlocksFinder.
+type compiledPack struct {
+	rxPhrases *regexp.Regexp
+	links     []string
+}
+
+var (
+	packsMutex sync.RWMutex
+	packs      = map[string][]*compiledPack{}
+)
+
+func init() {
+	for language, pack := range builtinPacks {
+		if err := RegisterPhrasePack(language, pack); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterPhrasePack registers the phrase pack for the specified language, which only the
+// primary language subtag is used (e.g. "pt" for "pt-BR"). If the language already has a
+// pack, the new pack is used in addition to the old one. Returns error if the language
+// or the patterns are invalid.
+func RegisterPhrasePack(language string, pack PhrasePack) error {
+	base := langutil.Base(language)
+	if base == "" || base == "und" {
+		return fmt.Errorf("invalid language %q", language)
+	}
+
+	compiled, err := compilePack(pack)
+	if err != nil {
+		return fmt.Errorf("invalid phrase pack for %q: %w", language, err)
+	}
+
+	packsMutex.Lock()
+	defer packsMutex.Unlock()
+	packs[base] = append(packs[base], compiled)
+	return nil
+}
+
+// phrasePacks returns the phrase packs for the language. English packs are always included
+// since English phrases are common in sites of any language. If the language is empty,
+// only the English packs are returned.
+func phrasePacks(language string) []*compiledPack {
+	packsMutex.RLock()
+	defer packsMutex.RUnlock()
+
+	result := append([]*compiledPack{}, packs["en"]...)
+	if base := langutil.Base(language); base != "" && base != "en" {
+		result = append(result, packs[base]...)
+	}
+	return result
+}
+
+func compilePack(pack PhrasePack) (*compiledPack, error) {
+	var alternatives []string
+	for _, prefix := range pack.Prefixes {
+		alternatives = append(alternatives, "^"+regexp.QuoteMeta(prefix))
+	}
+
+	for _, phrase := range pack.Phrases {
+		alternatives = append(alternatives, regexp.QuoteMeta(phrase))
+	}
+
+	for _, exact := range pack.Exact {
+		alternatives = append(alternatives, "^"+regexp.QuoteMeta(exact)+"$")
+	}
+
+	for _, pattern := range pack.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, "(?:"+pattern+")")
+	}
+
+	compiled := &compiledPack{links: pack.Links}
+
+	if len(alternatives) > 0 {
+		compiled.rxPhrases = regexp.MustCompile(`(?i)` + strings.Join(alternatives, "|"))
+	}
+
+	return compiled, nil
+}
+
+func (p *compiledPack) matchString(text string) bool {
+	return p.rxPhrases != nil && p.rxPhrases.MatchString(text)
+}
+
+func (p *compiledPack) isLink(text string) bool {
+	for _, link := range p.links {
+		if strings.EqualFold(link, text) {
+			return true
+		}
+	}
+	return false
+}
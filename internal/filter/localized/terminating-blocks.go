@@ -40,7 +40,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package english
+package localized
 
 import (
 	"regexp"
@@ -51,6 +51,8 @@ import (
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
 )
 
+// rxTerminatingBlocks is the original English phrases, which always used regardless
+// of the language of the page.
 var rxTerminatingBlocks = regexp.MustCompile(`(?i)(` +
 	`^(comments|© reuters|please rate this|post a comment|` +
 	`\d+\s+(comments|users responded in)` +
@@ -67,10 +69,15 @@ var rxTerminatingBlocks = regexp.MustCompile(`(?i)(` +
 
 // TerminatingBlocksFinder finds blocks which are potentially indicating the end of
 // an article text and marks them with label.StrictlyNotContent.
-type TerminatingBlocksFinder struct{}
+type TerminatingBlocksFinder struct {
+	packs []*compiledPack
+}
 
-func NewTerminatingBlocksFinder() *TerminatingBlocksFinder {
-	return &TerminatingBlocksFinder{}
+// NewTerminatingBlocksFinder creates TerminatingBlocksFinder that uses the phrase packs
+// for the specified language in addition to the original English phrases. If the language
+// is empty, only the English packs are used.
+func NewTerminatingBlocksFinder(language string) *TerminatingBlocksFinder {
+	return &TerminatingBlocksFinder{packs: phrasePacks(language)}
 }
 
 func (f *TerminatingBlocksFinder) Process(doc *webdoc.TextDocument) bool {
@@ -92,6 +99,26 @@ func (f *TerminatingBlocksFinder) isTerminating(tb *webdoc.TextBlock) bool {
 	}
 
 	text := strings.TrimSpace(tb.Text)
+	if f.isEnglishTerminating(tb, text) {
+		return true
+	}
+
+	for _, pack := range f.packs {
+		if pack.matchString(text) {
+			return true
+		}
+
+		if tb.LinkDensity == 1 && pack.isLink(text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEnglishTerminating checks the block using the original English phrases. Short
+// block is only terminating if it's exactly "Comment" link or "Shares".
+func (f *TerminatingBlocksFinder) isEnglishTerminating(tb *webdoc.TextBlock, text string) bool {
 	if stringutil.CharCount(text) >= 8 {
 		return rxTerminatingBlocks.MatchString(text)
 	} else if tb.LinkDensity == 1 {
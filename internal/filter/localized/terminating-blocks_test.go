@@ -0,0 +1,216 @@
+// ORIGINAL: javatest/TerminatingBlocksFinderTest.java
+
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Copyright 2014 The Chromium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package localized
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Filter_Localized_TerminatingBlocks_Positives(t *testing.T) {
+	texts := []string{
+		// Startswith cases.
+		"comments foo", "© reuters", "© reuters foo bar", "please rate this",
+		"please rate this foo", "post a comment", "post a comment foo", "123 comments",
+		"9 comments foo", "1346213423 users responded in", "1346213423 users responded in foo",
+
+		// Contains cases.
+		"foo what you think... bar", "what you think...", "foo what you think...",
+		"add your comment", "foo add your comment", "add comment bar", "reader views bar",
+		"have your say bar", "foo reader comments", "foo rätta artikeln",
+
+		// Equals cases.
+		"thanks for your comments - this feedback is now closed",
+
+		// Check some case insensitivity.
+		"Thanks for your comments - this feedback is now closed", "Add Comment Bar",
+		"READER VIEWS BAR", "Comments FOO",
+	}
+
+	terminatingBlocksFinder := NewTerminatingBlocksFinder("")
+	builder := testutil.NewTextBlockBuilder(stringutil.FastWordCounter{})
+
+	for _, text := range texts {
+		tb := builder.CreateForText(text)
+		assert.True(t, terminatingBlocksFinder.isTerminating(tb))
+	}
+}
+
+func Test_Filter_Localized_TerminatingBlocks_Negatives(t *testing.T) {
+	texts := []string{
+		// Startswith cases.
+		"lcomments foo", "xd© reuters", "not please rate this", "xx post a comment",
+		"users responded in", "123users responded in foo",
+
+		// Contains cases.
+		"what you think..", "addyour comment", "ad comment", "readerviews",
+
+		// Equals cases.
+		"thanks for your comments - this feedback is now closed foo",
+		"foo thanks for your comments - this feedback is now closed",
+
+		// Long case.
+		"1 2 3 4 5 6 7 8 9 10 11 12 13 14 15",
+	}
+
+	terminatingBlocksFinder := NewTerminatingBlocksFinder("")
+	builder := testutil.NewTextBlockBuilder(stringutil.FastWordCounter{})
+
+	for _, text := range texts {
+		tb := builder.CreateForText(text)
+		assert.False(t, terminatingBlocksFinder.isTerminating(tb))
+	}
+}
+
+func Test_Filter_Localized_TerminatingBlocks_Language(t *testing.T) {
+	texts := map[string][]string{
+		"de":    {"Kommentare (12)", "Schreiben Sie einen Kommentar", "12 Kommentare", "Teilen", "Ihre Meinung ist gefragt"},
+		"fr":    {"Laisser un commentaire", "Réagissez à cet article", "3 commentaires", "Partager"},
+		"es":    {"Deja un comentario", "Opina sobre esta noticia", "5 comentarios"},
+		"pt-BR": {"Deixe seu comentário aqui", "7 comentários", "Compartilhar"},
+		"it":    {"Lascia un commento", "Scrivi un commento qui", "4 commenti"},
+		"ru":    {"Оставить комментарий", "Добавить комментарий", "Поделиться"},
+		"ja":    {"コメントを書く", "コメント", "コメント（12）", "3件のコメント"},
+		"zh-CN": {"发表评论", "评论", "网友评论", "评论(5)", "分享"},
+		"id":    {"Tinggalkan komentar", "Berikan komentar Anda", "Komentar", "Komentar (3)", "Bagikan"},
+		"sv":    {"Skriv en kommentar", "Lämna en kommentar", "Dela"},
+	}
+
+	builder := testutil.NewTextBlockBuilder(stringutil.FullWordCounter{})
+	for language, languageTexts := range texts {
+		finder := NewTerminatingBlocksFinder(language)
+		englishFinder := NewTerminatingBlocksFinder("en")
+		for _, text := range languageTexts {
+			tb := builder.CreateForText(text)
+			assert.True(t, finder.isTerminating(tb), text)
+			assert.False(t, englishFinder.isTerminating(tb), text)
+		}
+	}
+
+	// Ordinary sentences in article are not terminating, even
+	// when they contain the heading of comment section.
+	sentences := map[string][]string{
+		"de": {"Auch die Rentenreform steht weiterhin zur Diskussion.",
+			"Ihre Meinung ist gefragt, sagte der Minister."},
+		"es": {"El ministro dijo que respeta tu opinión sobre la reforma."},
+		"pt": {"Qual é a sua opinião sobre a nova reforma?"},
+		"it": {"Il ministro ha detto che la tua opinione conta molto."},
+		"ru": {"Министр сказал, что ваше мнение очень важно для нас."},
+		"ja": {"コメントは控えたいと大臣は述べた。"},
+		"zh": {"评论家认为这部电影非常成功。", "网友评论称这项政策很有必要。"},
+		"id": {"Komentar menteri itu memicu perdebatan di parlemen."},
+	}
+
+	for language, languageSentences := range sentences {
+		finder := NewTerminatingBlocksFinder(language)
+		for _, text := range languageSentences {
+			tb := builder.CreateForText(text)
+			assert.False(t, finder.isTerminating(tb), text)
+		}
+	}
+
+	// English phrases are used for all languages.
+	tb := builder.CreateForText("add your comment")
+	assert.True(t, NewTerminatingBlocksFinder("de").isTerminating(tb))
+
+	// Comment link in other language.
+	tb = builder.CreateForAnchorText("Kommentieren")
+	assert.True(t, NewTerminatingBlocksFinder("de").isTerminating(tb))
+	assert.False(t, NewTerminatingBlocksFinder("en").isTerminating(tb))
+}
+
+func Test_Filter_Localized_TerminatingBlocks_UnknownLanguage(t *testing.T) {
+	builder := testutil.NewTextBlockBuilder(stringutil.FullWordCounter{})
+	finder := NewTerminatingBlocksFinder("")
+
+	// Short blocks only match the exact "Comment" link and "Shares".
+	assert.True(t, finder.isTerminating(builder.CreateForAnchorText("Comment")))
+	assert.False(t, finder.isTerminating(builder.CreateForAnchorText("comment")))
+	assert.False(t, finder.isTerminating(builder.CreateForText("Comment")))
+	assert.False(t, finder.isTerminating(builder.CreateForText("comment")))
+	assert.False(t, finder.isTerminating(builder.CreateForText("comms")))
+	assert.True(t, finder.isTerminating(builder.CreateForText("Shares")))
+	assert.False(t, finder.isTerminating(builder.CreateForText("shares")))
+
+	// Phrases of other languages are not used.
+	for _, text := range []string{"Teilen", "Dela", "Partager", "Ваше мнение", "評論"} {
+		assert.False(t, finder.isTerminating(builder.CreateForText(text)), text)
+	}
+
+	// Original phrase is still used when the language is known.
+	tb := builder.CreateForText("foo rätta artikeln")
+	assert.True(t, finder.isTerminating(tb))
+	assert.True(t, NewTerminatingBlocksFinder("en").isTerminating(tb))
+}
+
+func Test_Filter_Localized_TerminatingBlocks_CustomPack(t *testing.T) {
+	builder := testutil.NewTextBlockBuilder(stringutil.FastWordCounter{})
+	t.Run("Register", func(t *testing.T) {
+		restorePhrasePacks(t)
+		err := RegisterPhrasePack("nl-BE", PhrasePack{
+			Prefixes: []string{"reacties"},
+			Exact:    []string{"delen"},
+			Patterns: []string{`^\d+\s+reacties?`},
+		})
+		assert.NoError(t, err)
+
+		finder := NewTerminatingBlocksFinder("nl")
+		assert.True(t, finder.isTerminating(builder.CreateForText("Reacties op dit artikel")))
+		assert.True(t, finder.isTerminating(builder.CreateForText("12 reacties")))
+		assert.True(t, finder.isTerminating(builder.CreateForText("Delen")))
+		assert.False(t, finder.isTerminating(builder.CreateForText("De reacties op het nieuws waren positief")))
+	})
+
+	// The pack is removed once the subtest is finished.
+	assert.False(t, NewTerminatingBlocksFinder("nl").isTerminating(builder.CreateForText("Delen")))
+
+	assert.Error(t, RegisterPhrasePack("", PhrasePack{Exact: []string{"foo"}}))
+	assert.Error(t, RegisterPhrasePack("nl", PhrasePack{Patterns: []string{`(`}}))
+}
+
+func Test_Filter_Localized_TerminatingBlocks_CommentsLink(t *testing.T) {
+}
+
+// restorePhrasePacks makes sure the phrase packs that registered
+// within the test are removed once the test is finished.
+func restorePhrasePacks(t *testing.T) {
+	packsMutex.Lock()
+	saved := make(map[string][]*compiledPack, len(packs))
+	for language, languagePacks := range packs {
+		saved[language] = append([]*compiledPack{}, languagePacks...)
+	}
+	packsMutex.Unlock()
+
+	t.Cleanup(func() {
+		packsMutex.Lock()
+		packs = saved
+		packsMutex.Unlock()
+	})
+}
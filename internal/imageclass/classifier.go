@@ -0,0 +1,202 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package imageclass
+
+import (
+	nurl "net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"golang.org/x/net/html"
+)
+
+var rxStyleSize = regexp.MustCompile(`(?i)(?:^|;)\s*(width|height)\s*:\s*(\d+)px`)
+
+// Classifier is object that classifies the image as content or junk, e.g.
+// tracking pixel, icon, avatar, emoji or ad.
+type Classifier struct {
+	logger    logutil.Logger
+	blocklist []string
+}
+
+// NewClassifier returns a new Classifier. The blocklist is list of strings
+// that, when found in the image URL, makes the image treated as junk.
+func NewClassifier(blocklist []string, logger logutil.Logger) *Classifier {
+	lowerBlocklist := []string{}
+	for _, entry := range blocklist {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			lowerBlocklist = append(lowerBlocklist, entry)
+		}
+	}
+
+	return &Classifier{
+		logger:    logger,
+		blocklist: lowerBlocklist,
+	}
+}
+
+// Classify classifies an image element (<img>, <picture> or element that contains them)
+// using these heuristics in order:
+//  1. Image whose URL contains an entry in the blocklist is blocked.
+//  2. Image with declared width or height of 1-2px is tracking pixel, while
+//     image with both width and height declared up to 48px is icon.
+//  3. Image whose URL matched with the well known pattern of ad networks,
+//     trackers, emoji CDNs, avatar services and icons.
+//  4. Image with icon file type (e.g. ".ico") is icon.
+//  5. Image whose class name or ID, or those of its close ancestors, is
+//     commonly used for ad, emoji, avatar and icon.
+//
+// Otherwise, it's a content image.
+func (c *Classifier) Classify(node *html.Node) (Type, Reason) {
+	img := domutil.GetFirstElementByTagNameInc(node, "img")
+	if img == nil {
+		return Content, NoSource
+	}
+
+	urls := []string{}
+	if src := strings.TrimSpace(dom.GetAttribute(img, "src")); src != "" {
+		urls = append(urls, src)
+	}
+	urls = append(urls, domutil.GetAllSrcSetURLs(node)...)
+	if len(urls) == 0 {
+		return Content, NoSource
+	}
+
+	// 1-4) Blocklist, declared size, URL pattern and file type
+	width, height := declaredSize(img)
+	if imageType, reason := c.classifyURLs(urls, width, height); imageType != Content {
+		return c.logAndReturn(urls[0], imageType, reason)
+	}
+
+	// 5) Class name of the image and its ancestors
+	current := img
+	for depth := 0; current != nil && depth <= maxAncestorDepth; depth++ {
+		tagName := dom.TagName(current)
+		if tagName == "body" || tagName == "html" {
+			break
+		}
+
+		matchString := dom.ClassName(current) + " " + dom.ID(current)
+		for _, pattern := range classPatterns {
+			if pattern.rx.MatchString(matchString) {
+				return c.logAndReturn(urls[0], pattern.imageType, ClassName)
+			}
+		}
+
+		current = current.Parent
+	}
+
+	return Content, Default
+}
+
+// ClassifyURL classifies an image which only known by its URL and optional size, e.g.
+// the image from og:image metadata. Only the heuristics that don't need the element
+// are used, i.e. the blocklist, declared size, URL pattern and file type.
+func (c *Classifier) ClassifyURL(url string, width, height int) (Type, Reason) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return Content, NoSource
+	}
+
+	if imageType, reason := c.classifyURLs([]string{url}, width, height); imageType != Content {
+		return c.logAndReturn(url, imageType, reason)
+	}
+
+	return Content, Default
+}
+
+// classifyURLs classifies image using its URLs and declared size. Returns Content
+// if none of the heuristics matched.
+func (c *Classifier) classifyURLs(urls []string, width, height int) (Type, Reason) {
+	// 1) Blocklist
+	for _, url := range urls {
+		lowerURL := strings.ToLower(url)
+		for _, entry := range c.blocklist {
+			if strings.Contains(lowerURL, entry) {
+				return Blocked, Blocklist
+			}
+		}
+	}
+
+	// 2) Declared size
+	if (width > 0 && width <= maxTinySize) || (height > 0 && height <= maxTinySize) {
+		return Tracking, TinySize
+	}
+
+	if width > 0 && height > 0 && width <= maxIconSize && height <= maxIconSize {
+		return Icon, SmallSize
+	}
+
+	// 3) URL pattern
+	for _, pattern := range urlPatterns {
+		for _, url := range urls {
+			if pattern.rx.MatchString(url) {
+				return pattern.imageType, URLPattern
+			}
+		}
+	}
+
+	// 4) File type
+	if _, isIcon := iconExtensions[fileExtension(urls[0])]; isIcon {
+		return Icon, FileType
+	}
+
+	return Content, Default
+}
+
+func (c *Classifier) logAndReturn(src string, imageType Type, reason Reason) (Type, Reason) {
+	if c.logger != nil && imageType != Content {
+		c.logger.PrintVisibilityInfo("Image:", reason, "=>", imageType, src)
+	}
+	return imageType, reason
+}
+
+// declaredSize returns the width and height of image which declared in
+// its attributes or inline style. Zero if it's not declared.
+func declaredSize(img *html.Node) (int, int) {
+	width, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(dom.GetAttribute(img, "width")), "px"))
+	height, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(dom.GetAttribute(img, "height")), "px"))
+
+	for _, match := range rxStyleSize.FindAllStringSubmatch(dom.GetAttribute(img, "style"), -1) {
+		value, _ := strconv.Atoi(match[2])
+		if strings.EqualFold(match[1], "width") {
+			width = value
+		} else {
+			height = value
+		}
+	}
+
+	return width, height
+}
+
+// fileExtension returns the lowercase file extension in the URL path.
+func fileExtension(url string) string {
+	parsedURL, err := nurl.Parse(url)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(path.Ext(parsedURL.Path))
+}
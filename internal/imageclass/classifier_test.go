@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package imageclass_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/imageclass"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func createImage(rawHTML string) *html.Node {
+	div := dom.CreateElement("div")
+	dom.SetInnerHTML(div, rawHTML)
+	return dom.QuerySelector(div, "img")
+}
+
+func Test_ImageClass_Classify(t *testing.T) {
+	tests := []struct {
+		html      string
+		imageType imageclass.Type
+		reason    imageclass.Reason
+	}{
+		// Content image
+		{`<img src="https://example.com/photos/city.jpg" width="800" height="600">`, imageclass.Content, imageclass.Default},
+		{`<img src="https://example.com/google-pixel-8-review.jpg">`, imageclass.Content, imageclass.Default},
+		{`<img>`, imageclass.Content, imageclass.NoSource},
+
+		// Declared size
+		{`<img src="https://example.com/t.gif" width="1" height="1">`, imageclass.Tracking, imageclass.TinySize},
+		{`<img src="https://example.com/t.gif" style="width:1px;height:1px">`, imageclass.Tracking, imageclass.TinySize},
+		{`<img src="https://example.com/twitter.png" width="24" height="24">`, imageclass.Icon, imageclass.SmallSize},
+
+		// URL pattern
+		{`<img src="https://ad.doubleclick.net/ddm/ad/creative.jpg">`, imageclass.Ad, imageclass.URLPattern},
+		{`<img src="https://www.facebook.com/tr?id=123&ev=PageView">`, imageclass.Tracking, imageclass.URLPattern},
+		{`<img src="https://example.com/beacon.gif?page=1">`, imageclass.Tracking, imageclass.URLPattern},
+		{`<img src="https://s.w.org/images/core/emoji/14.0.0/72x72/1f600.png">`, imageclass.Emoji, imageclass.URLPattern},
+		{`<img src="https://secure.gravatar.com/avatar/abc?s=96">`, imageclass.Avatar, imageclass.URLPattern},
+		{`<img src="https://example.com/assets/icons/facebook.png">`, imageclass.Icon, imageclass.URLPattern},
+
+		// File type
+		{`<img src="https://example.com/favicon-32.ico">`, imageclass.Icon, imageclass.URLPattern},
+		{`<img src="https://example.com/brand.ico">`, imageclass.Icon, imageclass.FileType},
+
+		// Class names
+		{`<img class="emoji" src="https://example.com/smile.png">`, imageclass.Emoji, imageclass.ClassName},
+		{`<div class="author-avatar"><img src="https://example.com/jane.jpg"></div>`, imageclass.Avatar, imageclass.ClassName},
+		{`<div class="ad-slot"><a href="#"><img src="https://example.com/promo.jpg"></a></div>`, imageclass.Ad, imageclass.ClassName},
+		{`<div class="share-buttons"><a><span><img src="https://example.com/fb.png"></span></a></div>`, imageclass.Icon, imageclass.ClassName},
+		{`<div class="header-ad"><div><div><div><img src="https://example.com/far.jpg"></div></div></div></div>`, imageclass.Content, imageclass.Default},
+	}
+
+	classifier := imageclass.NewClassifier(nil, nil)
+	for _, test := range tests {
+		imageType, reason := classifier.Classify(createImage(test.html))
+		assert.Equal(t, test.imageType, imageType, test.html)
+		assert.Equal(t, test.reason, reason, test.html)
+	}
+}
+
+func Test_ImageClass_Blocklist(t *testing.T) {
+	classifier := imageclass.NewClassifier([]string{" Promo.Example.com ", "/sponsored-"}, nil)
+
+	imageType, reason := classifier.Classify(createImage(`<img src="https://promo.example.com/a.jpg">`))
+	assert.Equal(t, imageclass.Blocked, imageType)
+	assert.Equal(t, imageclass.Blocklist, reason)
+
+	imageType, _ = classifier.Classify(createImage(`<img src="a.jpg" srcset="https://example.com/sponsored-800.jpg 800w">`))
+	assert.Equal(t, imageclass.Blocked, imageType)
+
+	imageType, _ = classifier.Classify(createImage(`<img src="https://example.com/a.jpg">`))
+	assert.Equal(t, imageclass.Content, imageType)
+}
+
+func Test_ImageClass_ClassifyURL(t *testing.T) {
+	classifier := imageclass.NewClassifier([]string{"promo.example.com"}, nil)
+
+	imageType, reason := classifier.ClassifyURL("https://promo.example.com/a.jpg", 0, 0)
+	assert.Equal(t, imageclass.Blocked, imageType)
+	assert.Equal(t, imageclass.Blocklist, reason)
+
+	imageType, reason = classifier.ClassifyURL("https://www.gravatar.com/avatar/abc.jpg", 0, 0)
+	assert.Equal(t, imageclass.Avatar, imageType)
+	assert.Equal(t, imageclass.URLPattern, reason)
+
+	imageType, reason = classifier.ClassifyURL("https://example.com/logo.png", 32, 32)
+	assert.Equal(t, imageclass.Icon, imageType)
+	assert.Equal(t, imageclass.SmallSize, reason)
+
+	imageType, _ = classifier.ClassifyURL("https://example.com/a.jpg", 1200, 630)
+	assert.Equal(t, imageclass.Content, imageType)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package imageclass
+
+import "regexp"
+
+const (
+	// maxTinySize is the maximum width or height of tracking pixel.
+	maxTinySize = 2
+
+	// maxIconSize is the maximum width and height of icon.
+	maxIconSize = 48
+
+	// maxAncestorDepth is how many ancestors whose class names are checked.
+	maxAncestorDepth = 3
+)
+
+// urlPatterns is the patterns of junk image URL. They are checked in order, so
+// the more specific patterns (e.g. ad network) must be put first.
+var urlPatterns = []struct {
+	imageType Type
+	rx        *regexp.Regexp
+}{{
+	imageType: Ad,
+	rx: regexp.MustCompile(`(?i)(doubleclick\.net|googlesyndication\.com|googleadservices\.com|adservice\.google\.|` +
+		`amazon-adsystem\.com|adnxs\.com|adsrvr\.org|criteo\.(com|net)|taboola\.com|outbrain\.com|moatads\.com|` +
+		`serving-sys\.com|advertising\.com|/(ads|adserver|adverts?|banners?|sponsors?)/)`),
+}, {
+	imageType: Tracking,
+	rx: regexp.MustCompile(`(?i)(facebook\.com/tr\b|google-analytics\.com|scorecardresearch\.com|quantserve\.com|` +
+		`pixel\.wp\.com|stats\.wp\.com|bat\.bing\.com|analytics\.twitter\.com|/(pixel|beacon|tracking|tracker|track|1x1)` +
+		`(\.(gif|png|jpe?g))?([/?#]|$))`),
+}, {
+	imageType: Emoji,
+	rx: regexp.MustCompile(`(?i)(s\.w\.org/images/core/emoji|twemoji|emojione|joypixels|abs\.twimg\.com/emoji|` +
+		`static\.xx\.fbcdn\.net/images/emoji|/emojis?/)`),
+}, {
+	imageType: Avatar,
+	rx:        regexp.MustCompile(`(?i)(gravatar\.com|/profile_images/|/avatars?[/_.-])`),
+}, {
+	imageType: Icon,
+	rx:        regexp.MustCompile(`(?i)(favicon|/icons?/|[/_.-]sprites?[/_.-]|[/_-]share[-_]?(button|icon)|/social/)`),
+}}
+
+// iconExtensions is the file extensions of icon.
+var iconExtensions = map[string]struct{}{
+	".ico": {},
+	".cur": {},
+}
+
+// classPatterns is the patterns of class name or ID of junk image and its container.
+var classPatterns = []struct {
+	imageType Type
+	rx        *regexp.Regexp
+}{{
+	imageType: Ad,
+	rx:        regexp.MustCompile(`(?i)(^|[\s_-])(ad|ads|adsbygoogle|advert|advertisement|sponsor|sponsored|dfp|gpt-ad)([\s_-]|$)`),
+}, {
+	imageType: Emoji,
+	rx:        regexp.MustCompile(`(?i)(^|[\s_-])(emoji|wp-smiley|smiley)([\s_-]|$)`),
+}, {
+	imageType: Avatar,
+	rx:        regexp.MustCompile(`(?i)(^|[\s_-])(avatar|gravatar|author-(image|photo|avatar)|profile-(pic|photo|image))([\s_-]|$)`),
+}, {
+	imageType: Icon,
+	rx:        regexp.MustCompile(`(?i)(^|[\s_-])(icon|icons|share|sharing|social|badge|sprite)([\s_-]|$)`),
+}}
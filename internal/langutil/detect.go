@@ -0,0 +1,303 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package langutil
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// minDetectionLetters is the minimum number of letters in text
+	// before the language detection is attempted.
+	minDetectionLetters = 20
+
+	// minScriptRatio is the minimum ratio of letters in a script before
+	// the language is decided from the script alone.
+	minScriptRatio = 0.3
+
+	// reliableTrigramCount is the number of trigrams in text that needed before
+	// the detection for Latin script is fully trusted. Shorter text will have
+	// lower confidence.
+	reliableTrigramCount = 100
+)
+
+// latinProfiles is the most common trigrams of languages that use Latin script. Space is
+// used to mark the word boundary. The profiles are small, but enough to tell apart the
+// languages in text with a few sentences.
+var latinProfiles = map[string][]string{
+	"en": {" th", "the", "he ", "and", " an", "nd ", " of", "of ", " to", "ing", "ng ", " in",
+		"to ", "ed ", "is ", " is", "ion", " a ", "in ", "tio", "re ", "er ", " co", "on ",
+		"at ", "ent", "hat", "tha", " wh", "es ", " be", "for", " fo", "or ", "her", "ter",
+		"was", " wa", "as ", "ly "},
+	"de": {"en ", "er ", "der", " de", "ie ", "ch ", "die", " di", "sch", "ein", "ich", "nd ",
+		"und", " un", "den", " ei", "in ", "te ", "cht", "ine", "gen", "ung", "es ", "ten",
+		" zu", "ber", " be", "das", " da", "nde", "ige", "che", "eit", " ge", "ist", " is",
+		"auf", "mit", " mi", "ver"},
+	"fr": {"es ", " de", "de ", "ent", "le ", " le", "nt ", "la ", " la", "les", "ion", " pa",
+		"que", "re ", " co", "ue ", "on ", "tio", "des", " qu", "ait", " et", "et ", " un",
+		"ne ", "men", "ans", " da", "dan", " pr", "par", "our", "pou", " po", "est", " es",
+		"une", " sa", "eur", "ux "},
+	"es": {" de", "de ", "os ", "la ", " la", "el ", "es ", " qu", "que", "ue ", " el", "ent",
+		" en", "en ", "as ", "do ", "ión", "ció", " co", "los", " lo", "ado", "con", "nte",
+		"ra ", "del", " se", "ien", "ara", "par", " pa", "por", " po", "las", "una", " un",
+		"est", " es", "ero", "ida"},
+	"pt": {" de", "de ", "os ", "do ", "ão ", "ção", " qu", "que", "ue ", "da ", "ent", "es ",
+		" co", "em ", "ar ", "nte", " pa", "par", "ra ", "ado", "com", "uma", " um", "um ",
+		"não", " nã", "dos", " do", "to ", "men", "são", "ões", "est", "as ", "mos", "tem",
+		"pel", " pe", "ela", " da"},
+	"it": {" di", "di ", "la ", "to ", " de", "ell", "lla", "del", "re ", " la", "che", " ch",
+		"he ", "ent", "one", "ne ", "no ", "ion", "zio", "ato", "per", " pe", "er ", "ere",
+		" co", "con", "ta ", "il ", " il", "nte", " in", "gli", " gl", "ono", "non", " no",
+		"una", " un", "ia ", "are"},
+	"nl": {"en ", "de ", " de", "an ", "het", " he", "et ", "van", " va", "een", " ee", "der",
+		"ing", "ij ", "aar", "sch", "ver", " ve", "oor", "cht", "dat", " da", "nie", "iet",
+		" ni", "ijk", "te ", "ter", "den", "ie ", "in ", "zij", "ook", " oo", "voo", "gen",
+		"nde", "wor", "ord", " wo"},
+	"sv": {"en ", "och", " oc", "ch ", "er ", "att", " at", "för", " fö", "de ", "ar ", "an ",
+		"som", " so", "det", " de", "ing", "lig", " ti", "til", "ill", "är ", " är", "med",
+		" me", "nde", "ten", "av ", " av", "var", " va", "den", "har", " ha", "ade", "ska",
+		"kan", "int", "nte", "oss"},
+	"id": {"an ", "ang", " me", "kan", "ng ", "yan", "nga", " ya", "men", "ada", "ber", " be",
+		"dan", " da", "ah ", "eng", "ala", "ter", " di", "ak ", "per", "aka", "ini", "ata",
+		" pe", "era", "dak", "uk ", "tuk", "ntu", "unt", " un", "pad", "nya", "ya ", "den",
+		" ke", "asi", "lah", "ela"},
+}
+
+// scriptLanguages is the languages that can be returned by Detect, grouped by their script.
+// Detect only knows a few languages for each script, so any other language that written
+// in the same script will be detected as one of these languages.
+var scriptLanguages = map[string][]string{
+	"Latn": {"en", "de", "fr", "es", "pt", "it", "nl", "sv", "id"},
+	"Cyrl": {"ru", "uk", "be", "sr"},
+	"Arab": {"ar", "fa"},
+	"Hans": {"zh"},
+	"Hant": {"zh"},
+	"Jpan": {"ja"},
+	"Kore": {"ko"},
+	"Hebr": {"he"},
+	"Grek": {"el"},
+	"Thai": {"th"},
+	"Deva": {"hi"},
+	"Beng": {"bn"},
+	"Taml": {"ta"},
+	"Geor": {"ka"},
+	"Armn": {"hy"},
+}
+
+// latinTrigrams maps each trigram into the languages which use it.
+var latinTrigrams = func() map[string][]string {
+	trigrams := make(map[string][]string)
+	for lang, profile := range latinProfiles {
+		for _, trigram := range profile {
+			trigrams[trigram] = append(trigrams[trigram], lang)
+		}
+	}
+	return trigrams
+}()
+
+// Detect guesses the language of the text. The script of the text is checked first, which
+// enough for languages with unique script like Japanese or Greek. For Latin script, the
+// language is detected by comparing the trigrams in text with the profile of each language.
+// Returns the language tag and the confidence between 0 and 1, or empty string if the
+// language can't be detected.
+func Detect(text string) (string, float64) {
+	var nLetters, nLatin, nKana int
+	scriptCounts := make(map[string]int)
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		nLetters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			nLatin++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			nKana++
+			scriptCounts["ja"]++
+		default:
+			if lang := scriptLanguage(r); lang != "" {
+				scriptCounts[lang]++
+			}
+		}
+	}
+
+	if nLetters < minDetectionLetters {
+		return "", 0
+	}
+
+	// Japanese text mixes kana and Han characters, so they are counted together.
+	if nKana > 0 {
+		scriptCounts["ja"] += scriptCounts["zh"]
+		delete(scriptCounts, "zh")
+	}
+
+	// Check the non-Latin scripts
+	bestLang, bestCount := "", 0
+	for lang, count := range scriptCounts {
+		if count > bestCount || (count == bestCount && lang < bestLang) {
+			bestLang, bestCount = lang, count
+		}
+	}
+
+	if bestCount > nLatin && float64(bestCount)/float64(nLetters) >= minScriptRatio {
+		if bestLang == "ru" {
+			bestLang = cyrillicLanguage(text)
+		} else if bestLang == "ar" {
+			bestLang = arabicLanguage(text)
+		}
+		return bestLang, float64(bestCount) / float64(nLetters)
+	}
+
+	if float64(nLatin)/float64(nLetters) < minScriptRatio {
+		return "", 0
+	}
+
+	return detectLatin(text)
+}
+
+// CanDetect returns true if Detect is able to tell the language of the tag apart from
+// the other languages that written in the same script. For example, it returns false
+// for "bg" since Bulgarian text will be detected as Russian.
+func CanDetect(tag string) bool {
+	base := Base(tag)
+	for _, lang := range scriptLanguages[Script(tag)] {
+		if lang == base {
+			return true
+		}
+	}
+	return false
+}
+
+// detectLatin detects the language of text in Latin script using trigram profiles.
+func detectLatin(text string) (string, float64) {
+	scores := make(map[string]int)
+	total := 0
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), isNotLetter) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			total++
+			for _, lang := range latinTrigrams[string(runes[i:i+3])] {
+				scores[lang]++
+			}
+		}
+	}
+
+	if total == 0 || len(scores) == 0 {
+		return "", 0
+	}
+
+	langs := make([]string, 0, len(scores))
+	for lang := range scores {
+		langs = append(langs, lang)
+	}
+
+	sort.Slice(langs, func(i, j int) bool {
+		if scores[langs[i]] != scores[langs[j]] {
+			return scores[langs[i]] > scores[langs[j]]
+		}
+		return langs[i] < langs[j]
+	})
+
+	// Confidence is decided by how much the best language is ahead of the
+	// runner-up, reduced for short text.
+	best := scores[langs[0]]
+	runnerUp := 0
+	if len(langs) > 1 {
+		runnerUp = scores[langs[1]]
+	}
+
+	confidence := float64(best-runnerUp) / float64(best)
+	if total < reliableTrigramCount {
+		confidence *= float64(total) / reliableTrigramCount
+	}
+
+	return langs[0], confidence
+}
+
+// scriptLanguage returns the most likely language for the non-Latin letter.
+func scriptLanguage(r rune) string {
+	switch {
+	case unicode.Is(unicode.Han, r):
+		return "zh"
+	case unicode.Is(unicode.Hangul, r):
+		return "ko"
+	case unicode.Is(unicode.Cyrillic, r):
+		return "ru"
+	case unicode.Is(unicode.Arabic, r):
+		return "ar"
+	case unicode.Is(unicode.Hebrew, r):
+		return "he"
+	case unicode.Is(unicode.Greek, r):
+		return "el"
+	case unicode.Is(unicode.Thai, r):
+		return "th"
+	case unicode.Is(unicode.Devanagari, r):
+		return "hi"
+	case unicode.Is(unicode.Bengali, r):
+		return "bn"
+	case unicode.Is(unicode.Tamil, r):
+		return "ta"
+	case unicode.Is(unicode.Georgian, r):
+		return "ka"
+	case unicode.Is(unicode.Armenian, r):
+		return "hy"
+	default:
+		return ""
+	}
+}
+
+// cyrillicLanguage tells apart the common languages in Cyrillic
+// script using the letters which unique for each language. Letter
+// "і" is used by both Ukrainian and Belarusian, so Belarusian is
+// told apart by "ы" and "э" which don't exist in Ukrainian.
+func cyrillicLanguage(text string) string {
+	switch {
+	case strings.ContainsAny(text, "ў"):
+		return "be"
+	case strings.ContainsAny(text, "ґєї"):
+		return "uk"
+	case strings.ContainsAny(text, "і"):
+		if strings.ContainsAny(text, "ыэ") {
+			return "be"
+		}
+		return "uk"
+	case strings.ContainsAny(text, "ђћџ"):
+		return "sr"
+	default:
+		return "ru"
+	}
+}
+
+// arabicLanguage tells apart Arabic and Persian using
+// the letters which only exist in Persian alphabet.
+func arabicLanguage(text string) string {
+	if strings.ContainsAny(text, "پچژگ") {
+		return "fa"
+	}
+	return "ar"
+}
+
+func isNotLetter(r rune) bool {
+	return !unicode.IsLetter(r)
+}
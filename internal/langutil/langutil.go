@@ -0,0 +1,104 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package langutil
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Text direction of a language.
+const (
+	LeftToRight = "ltr"
+	RightToLeft = "rtl"
+)
+
+var rtlScripts = map[string]struct{}{
+	"Adlm": {},
+	"Arab": {},
+	"Hebr": {},
+	"Mand": {},
+	"Nkoo": {},
+	"Rohg": {},
+	"Syrc": {},
+	"Thaa": {},
+}
+
+// Normalize converts the language tag into its canonical BCP-47 form, e.g. "en_us"
+// into "en-US". Only the first tag is used if the string contains several tags
+// like in Content-Language header. Returns empty string if the tag is invalid.
+func Normalize(tag string) string {
+	if idx := strings.IndexAny(tag, ",;"); idx >= 0 {
+		tag = tag[:idx]
+	}
+
+	tag = strings.TrimSpace(strings.ReplaceAll(tag, "_", "-"))
+	if tag == "" {
+		return ""
+	}
+
+	parsed, err := language.Parse(tag)
+	if err != nil || parsed == language.Und {
+		return ""
+	}
+
+	return parsed.String()
+}
+
+// Base returns the primary language subtag of the tag, e.g. "pt" for "pt-BR".
+func Base(tag string) string {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return ""
+	}
+
+	base, _ := parsed.Base()
+	return base.String()
+}
+
+// Script returns the script subtag of the tag, e.g. "Cyrl" for "bg". If the tag doesn't
+// specify the script, the most likely script for the language is returned instead.
+func Script(tag string) string {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return ""
+	}
+
+	script, _ := parsed.Script()
+	return script.String()
+}
+
+// Direction returns the text direction of the language, i.e. LeftToRight
+// or RightToLeft. The direction is decided by the script of the language.
+func Direction(tag string) string {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return LeftToRight
+	}
+
+	script, _ := parsed.Script()
+	if _, isRTL := rtlScripts[script.String()]; isRTL {
+		return RightToLeft
+	}
+
+	return LeftToRight
+}
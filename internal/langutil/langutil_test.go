@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package langutil_test
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/internal/langutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LangUtil_Normalize(t *testing.T) {
+	tests := map[string]string{
+		"en":               "en",
+		"EN-us":            "en-US",
+		"pt_BR":            "pt-BR",
+		"zh-hans-cn":       "zh-Hans-CN",
+		"de-DE, en;q=0.8":  "de-DE",
+		"":                 "",
+		"not a valid tag!": "",
+	}
+
+	for tag, expected := range tests {
+		assert.Equal(t, expected, langutil.Normalize(tag), tag)
+	}
+}
+
+func Test_LangUtil_Direction(t *testing.T) {
+	assert.Equal(t, langutil.LeftToRight, langutil.Direction("en-US"))
+	assert.Equal(t, langutil.LeftToRight, langutil.Direction("ja"))
+	assert.Equal(t, langutil.RightToLeft, langutil.Direction("ar"))
+	assert.Equal(t, langutil.RightToLeft, langutil.Direction("he-IL"))
+	assert.Equal(t, langutil.RightToLeft, langutil.Direction("fa"))
+	assert.Equal(t, langutil.RightToLeft, langutil.Direction("az-Arab"))
+}
+
+func Test_LangUtil_CanDetect(t *testing.T) {
+	assert.True(t, langutil.CanDetect("en-GB"))
+	assert.True(t, langutil.CanDetect("ru"))
+	assert.True(t, langutil.CanDetect("fa"))
+	assert.True(t, langutil.CanDetect("zh-Hant"))
+	assert.False(t, langutil.CanDetect("bg"))
+	assert.False(t, langutil.CanDetect("ur"))
+	assert.False(t, langutil.CanDetect("mr"))
+	assert.False(t, langutil.CanDetect("pl"))
+	assert.False(t, langutil.CanDetect("sr-Latn"))
+}
+
+func Test_LangUtil_Detect(t *testing.T) {
+	tests := map[string]string{
+		"en": "The committee said that the report was published for the first time in the morning, and the results are expected to be discussed with the members.",
+		"de": "Die Regierung hat am Dienstag beschlossen, dass die neuen Regeln für den Verkehr in der Stadt ab nächster Woche gelten und mit den Bürgern besprochen werden.",
+		"fr": "Le gouvernement a annoncé mardi que les nouvelles règles pour la circulation dans la ville seront appliquées dès la semaine prochaine pour tous les habitants.",
+		"es": "El gobierno anunció el martes que las nuevas reglas para el tráfico en la ciudad se aplicarán desde la próxima semana para todos los habitantes de la región.",
+		"pt": "O governo anunciou na terça-feira que as novas regras para o trânsito na cidade serão aplicadas a partir da próxima semana para todos os moradores da região.",
+		"it": "Il governo ha annunciato martedì che le nuove regole per il traffico nella città saranno applicate dalla prossima settimana per tutti gli abitanti della regione.",
+		"nl": "De regering heeft dinsdag aangekondigd dat de nieuwe regels voor het verkeer in de stad vanaf volgende week gelden voor alle inwoners van de regio.",
+		"id": "Pemerintah mengumumkan pada hari Selasa bahwa peraturan baru untuk lalu lintas di kota akan diberlakukan mulai minggu depan untuk semua penduduk yang tinggal di daerah.",
+		"ru": "Правительство объявило во вторник, что новые правила дорожного движения в городе начнут действовать со следующей недели.",
+		"uk": "Уряд оголосив у вівторок, що нові правила дорожнього руху в місті почнуть діяти з наступного тижня.",
+		"be": "Урад абвясціў у аўторак, што новыя правілы дарожнага руху ў горадзе пачнуць дзейнічаць з наступнага тыдня.",
+		"ja": "政府は火曜日、市内の交通に関する新しい規則が来週から適用されると発表しました。",
+		"zh": "政府周二宣布，城市交通的新规定将从下周开始对所有居民实施，并将与市民进行讨论。",
+		"ko": "정부는 화요일 시내 교통에 관한 새로운 규칙이 다음 주부터 적용된다고 발표했습니다.",
+		"ar": "أعلنت الحكومة يوم الثلاثاء أن القواعد الجديدة للمرور في المدينة ستطبق اعتبارا من الأسبوع المقبل.",
+		"he": "הממשלה הודיעה ביום שלישי כי הכללים החדשים לתנועה בעיר ייכנסו לתוקף בשבוע הבא.",
+	}
+
+	for expected, text := range tests {
+		lang, confidence := langutil.Detect(text)
+		assert.Equal(t, expected, lang, text)
+		assert.Greater(t, confidence, 0.0, text)
+	}
+
+	// Belarusian text without "ў" is still told apart from Ukrainian
+	lang, _ := langutil.Detect("Гэта навіны пра новыя правілы руху для жыхарства горада і вёскі.")
+	assert.Equal(t, "be", lang)
+
+	lang, _ = langutil.Detect("Hello")
+	assert.Equal(t, "", lang)
+}
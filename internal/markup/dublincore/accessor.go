@@ -0,0 +1,146 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package dublincore
+
+import (
+	"strings"
+
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"golang.org/x/net/html"
+)
+
+// Parser recognizes and parses the Dublin Core metadata, i.e. <meta name="DC.*"> and
+// <meta name="dcterms.*">, according to https://www.dublincore.org/specifications/dublin-core/dcq-html/.
+// The refinement of element is written after the element name, e.g. "DC.date.modified",
+// or as its own term, e.g. "dcterms.modified". Both are supported. It implements markup.Accessor.
+type Parser struct {
+	properties map[string][]string
+}
+
+func NewParser(root *html.Node) *Parser {
+	metaContents := domutil.GetMetaContents(root, "dc.", "dc:", "dcterms.", "dcterms:")
+
+	// Normalize the name, e.g. "dcterms:date.issued" into "date.issued"
+	properties := make(map[string][]string)
+	for name, contents := range metaContents {
+		idx := strings.IndexAny(name, ".:")
+		name = name[idx+1:]
+		properties[name] = append(properties[name], contents...)
+	}
+
+	return &Parser{properties: properties}
+}
+
+// DublinCore returns all Dublin Core properties in the document.
+func (ps *Parser) DublinCore() data.MarkupDublinCore {
+	return data.MarkupDublinCore{
+		Title:        ps.get(TitleProp),
+		Creators:     ps.getAll(CreatorProp),
+		Contributors: ps.getAll(ContributorProp),
+		Subject:      strings.Join(ps.getAll(SubjectProp), ", "),
+		Description:  ps.Description(),
+		Publisher:    ps.get(PublisherProp),
+		Date:         ps.date(),
+		Modified:     ps.get(ModifiedProp, DateProp+"."+ModifiedProp),
+		Type:         ps.get(TypeProp),
+		Format:       ps.get(FormatProp),
+		Identifier:   ps.get(IdentifierProp),
+		Source:       ps.get(SourceProp),
+		Language:     ps.get(LanguageProp),
+		Rights:       ps.Copyright(),
+	}
+}
+
+func (ps *Parser) Title() string {
+	return ps.get(TitleProp)
+}
+
+func (ps *Parser) Type() string {
+	return ""
+}
+
+// URL returns the identifier of the document if it's an URL.
+func (ps *Parser) URL() string {
+	identifier := ps.get(IdentifierProp)
+	if strings.HasPrefix(identifier, "http://") || strings.HasPrefix(identifier, "https://") {
+		return identifier
+	}
+	return ""
+}
+
+func (ps *Parser) Images() []data.MarkupImage {
+	return nil
+}
+
+func (ps *Parser) Description() string {
+	return ps.get(DescriptionProp, AbstractProp)
+}
+
+func (ps *Parser) Publisher() string {
+	return ps.get(PublisherProp)
+}
+
+func (ps *Parser) Copyright() string {
+	return ps.get(RightsProp, LicenseProp)
+}
+
+func (ps *Parser) Author() string {
+	return ps.get(CreatorProp)
+}
+
+func (ps *Parser) Article() *data.MarkupArticle {
+	article := data.MarkupArticle{
+		PublishedTime: ps.date(),
+		ModifiedTime:  ps.get(ModifiedProp, DateProp+"."+ModifiedProp),
+		Authors:       ps.getAll(CreatorProp),
+	}
+
+	if article.PublishedTime == "" && article.ModifiedTime == "" && len(article.Authors) == 0 {
+		return nil
+	}
+
+	return &article
+}
+
+func (ps *Parser) OptOut() bool {
+	return false
+}
+
+// date returns the publication date of the document. The issued date is preferred
+// over the generic date, which might be the date the document is created.
+func (ps *Parser) date() string {
+	return ps.get(IssuedProp, DateProp+"."+IssuedProp, DateProp, CreatedProp, DateProp+"."+CreatedProp)
+}
+
+// get returns the first value of the first property that exists.
+func (ps *Parser) get(props ...string) string {
+	for _, prop := range props {
+		if values := ps.properties[prop]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func (ps *Parser) getAll(prop string) []string {
+	return append([]string{}, ps.properties[prop]...)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package dublincore_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/markup/dublincore"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func Test_DublinCore_Properties(t *testing.T) {
+	doc := createDocWithMeta(
+		"DC.title", "Dublin Core title",
+		"DC.creator", "Jane Doe",
+		"DC.creator", "John Smith",
+		"DC.date.issued", "2020-05-01",
+		"dcterms.modified", "2020-06-01",
+		"DC.description", "Dublin Core description",
+		"DC.publisher", "Dummy Press",
+		"DC.rights", "CC BY 4.0",
+		"DC.identifier", "http://dummy/article.html",
+		"DC.language", "en",
+		"DC.subject", "History",
+		"DC.subject", "Science",
+	)
+
+	parser := dublincore.NewParser(doc)
+	assert.Equal(t, "Dublin Core title", parser.Title())
+	assert.Equal(t, "Dublin Core description", parser.Description())
+	assert.Equal(t, "Dummy Press", parser.Publisher())
+	assert.Equal(t, "CC BY 4.0", parser.Copyright())
+	assert.Equal(t, "Jane Doe", parser.Author())
+	assert.Equal(t, "http://dummy/article.html", parser.URL())
+
+	article := parser.Article()
+	assert.NotNil(t, article)
+	assert.Equal(t, "2020-05-01", article.PublishedTime)
+	assert.Equal(t, "2020-06-01", article.ModifiedTime)
+	assert.Equal(t, []string{"Jane Doe", "John Smith"}, article.Authors)
+
+	dc := parser.DublinCore()
+	assert.Equal(t, []string{"Jane Doe", "John Smith"}, dc.Creators)
+	assert.Equal(t, "History, Science", dc.Subject)
+	assert.Equal(t, "en", dc.Language)
+	assert.Equal(t, "2020-05-01", dc.Date)
+}
+
+func Test_DublinCore_TermsPrefix(t *testing.T) {
+	doc := createDocWithMeta(
+		"dcterms.title", "Terms title",
+		"dcterms:creator", "Jane Doe",
+		"dcterms.date", "2019-01-01",
+		"dcterms.abstract", "Terms abstract",
+		"dc.identifier", "urn:isbn:0-000-00000-0",
+	)
+
+	parser := dublincore.NewParser(doc)
+	assert.Equal(t, "Terms title", parser.Title())
+	assert.Equal(t, "Terms abstract", parser.Description())
+	assert.Equal(t, "Jane Doe", parser.Author())
+	assert.Equal(t, "2019-01-01", parser.Article().PublishedTime)
+	assert.Equal(t, "", parser.URL())
+	assert.Equal(t, "urn:isbn:0-000-00000-0", parser.DublinCore().Identifier)
+}
+
+func Test_DublinCore_NoMetadata(t *testing.T) {
+	parser := dublincore.NewParser(testutil.CreateHTML())
+	assert.Equal(t, "", parser.Title())
+	assert.Nil(t, parser.Article())
+}
+
+// createDocWithMeta creates document with meta tags from pairs of name and content.
+func createDocWithMeta(pairs ...string) *html.Node {
+	doc := testutil.CreateHTML()
+	head := dom.QuerySelector(doc, "head")
+	for i := 0; i+1 < len(pairs); i += 2 {
+		meta := dom.CreateElement("meta")
+		dom.SetAttribute(meta, "name", pairs[i])
+		dom.SetAttribute(meta, "content", pairs[i+1])
+		dom.AppendChild(head, meta)
+	}
+	return doc
+}
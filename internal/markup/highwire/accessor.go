@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package highwire
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"golang.org/x/net/html"
+)
+
+var rxKeywordSeparator = regexp.MustCompile(`\s*[;,]\s*`)
+
+// Parser recognizes and parses the Highwire Press citation meta tags, i.e.
+// <meta name="citation_*">, which are used by scholarly publishers and indexed
+// by Google Scholar (https://scholar.google.com/intl/en/scholar/inclusion.html#indexing).
+// It implements markup.Accessor.
+type Parser struct {
+	properties map[string][]string
+}
+
+func NewParser(root *html.Node) *Parser {
+	return &Parser{
+		properties: domutil.GetMetaContents(root, "citation_"),
+	}
+}
+
+// Citation returns all bibliographic properties in the document.
+func (ps *Parser) Citation() data.MarkupCitation {
+	var keywords []string
+	for _, value := range ps.properties[KeywordsProp] {
+		for _, keyword := range rxKeywordSeparator.Split(value, -1) {
+			if keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+	}
+
+	return data.MarkupCitation{
+		Title:           ps.get(TitleProp),
+		Authors:         ps.getAll(AuthorProp),
+		JournalTitle:    ps.get(JournalTitleProp, ConferenceTitleProp),
+		Publisher:       ps.get(PublisherProp, DissertationInstProp),
+		PublicationDate: ps.get(PublicationDateProp, DateProp),
+		OnlineDate:      ps.get(OnlineDateProp),
+		DOI:             ps.get(DOIProp),
+		PDFURL:          ps.get(PDFURLProp),
+		AbstractURL:     ps.get(AbstractURLProp),
+		Volume:          ps.get(VolumeProp),
+		Issue:           ps.get(IssueProp),
+		FirstPage:       ps.get(FirstPageProp),
+		LastPage:        ps.get(LastPageProp),
+		ISSN:            ps.get(ISSNProp),
+		ISBN:            ps.get(ISBNProp),
+		Keywords:        keywords,
+		Language:        ps.get(LanguageProp),
+	}
+}
+
+func (ps *Parser) Title() string {
+	return ps.get(TitleProp)
+}
+
+func (ps *Parser) Type() string {
+	return ""
+}
+
+func (ps *Parser) URL() string {
+	return ps.get(AbstractURLProp, FullTextURLProp)
+}
+
+func (ps *Parser) Images() []data.MarkupImage {
+	return nil
+}
+
+func (ps *Parser) Description() string {
+	return ps.get(AbstractProp)
+}
+
+// Publisher returns the publisher of the article. If it's not specified,
+// the name of the journal is used instead.
+func (ps *Parser) Publisher() string {
+	return ps.get(PublisherProp, JournalTitleProp, ConferenceTitleProp)
+}
+
+func (ps *Parser) Copyright() string {
+	return ""
+}
+
+func (ps *Parser) Author() string {
+	if authors := ps.authors(); len(authors) > 0 {
+		return authors[0]
+	}
+	return ""
+}
+
+func (ps *Parser) Article() *data.MarkupArticle {
+	article := data.MarkupArticle{
+		PublishedTime: ps.get(PublicationDateProp, DateProp, OnlineDateProp),
+		Authors:       ps.authors(),
+	}
+
+	if article.PublishedTime == "" && len(article.Authors) == 0 {
+		return nil
+	}
+
+	return &article
+}
+
+func (ps *Parser) OptOut() bool {
+	return false
+}
+
+// authors returns the name of authors in natural order. Citation commonly writes
+// the author in "Last, First" format, which would be mistaken as two names.
+func (ps *Parser) authors() []string {
+	var authors []string
+	for _, author := range ps.properties[AuthorProp] {
+		if parts := strings.Split(author, ","); len(parts) == 2 {
+			author = strings.TrimSpace(parts[1]) + " " + strings.TrimSpace(parts[0])
+		}
+		authors = append(authors, strings.TrimSpace(author))
+	}
+	return authors
+}
+
+// get returns the first value of the first property that exists.
+func (ps *Parser) get(props ...string) string {
+	for _, prop := range props {
+		if values := ps.properties[prop]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func (ps *Parser) getAll(prop string) []string {
+	return append([]string{}, ps.properties[prop]...)
+}
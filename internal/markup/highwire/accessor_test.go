@@ -0,0 +1,96 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package highwire_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/markup/highwire"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func Test_Highwire_Citation(t *testing.T) {
+	doc := createDocWithMeta(
+		"citation_title", "A study of something",
+		"citation_author", "Doe, Jane",
+		"citation_author", "Smith, John",
+		"citation_journal_title", "Journal of Dummy",
+		"citation_publication_date", "2018/03/15",
+		"citation_online_date", "2018/02/01",
+		"citation_doi", "10.1000/xyz123",
+		"citation_pdf_url", "http://dummy/article.pdf",
+		"citation_abstract_html_url", "http://dummy/abstract.html",
+		"citation_volume", "12",
+		"citation_issue", "3",
+		"citation_firstpage", "100",
+		"citation_lastpage", "120",
+		"citation_issn", "1234-5678",
+		"citation_keywords", "dummy; testing, metadata",
+	)
+
+	parser := highwire.NewParser(doc)
+	assert.Equal(t, "A study of something", parser.Title())
+	assert.Equal(t, "Jane Doe", parser.Author())
+	assert.Equal(t, "Journal of Dummy", parser.Publisher())
+	assert.Equal(t, "http://dummy/abstract.html", parser.URL())
+
+	article := parser.Article()
+	assert.NotNil(t, article)
+	assert.Equal(t, "2018/03/15", article.PublishedTime)
+	assert.Equal(t, []string{"Jane Doe", "John Smith"}, article.Authors)
+
+	citation := parser.Citation()
+	assert.Equal(t, []string{"Doe, Jane", "Smith, John"}, citation.Authors)
+	assert.Equal(t, "Journal of Dummy", citation.JournalTitle)
+	assert.Equal(t, "", citation.Publisher)
+	assert.Equal(t, "2018/02/01", citation.OnlineDate)
+	assert.Equal(t, "10.1000/xyz123", citation.DOI)
+	assert.Equal(t, "http://dummy/article.pdf", citation.PDFURL)
+	assert.Equal(t, "12", citation.Volume)
+	assert.Equal(t, "3", citation.Issue)
+	assert.Equal(t, "100", citation.FirstPage)
+	assert.Equal(t, "120", citation.LastPage)
+	assert.Equal(t, "1234-5678", citation.ISSN)
+	assert.Equal(t, []string{"dummy", "testing", "metadata"}, citation.Keywords)
+}
+
+func Test_Highwire_NoMetadata(t *testing.T) {
+	parser := highwire.NewParser(testutil.CreateHTML())
+	assert.Equal(t, "", parser.Title())
+	assert.Nil(t, parser.Article())
+	assert.Nil(t, parser.Citation().Keywords)
+}
+
+// createDocWithMeta creates document with meta tags from pairs of name and content.
+func createDocWithMeta(pairs ...string) *html.Node {
+	doc := testutil.CreateHTML()
+	head := dom.QuerySelector(doc, "head")
+	for i := 0; i+1 < len(pairs); i += 2 {
+		meta := dom.CreateElement("meta")
+		dom.SetAttribute(meta, "name", pairs[i])
+		dom.SetAttribute(meta, "content", pairs[i+1])
+		dom.AppendChild(head, meta)
+	}
+	return doc
+}
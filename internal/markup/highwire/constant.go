@@ -0,0 +1,47 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package highwire
+
+// Name of properties, in lowercase.
+const (
+	TitleProp            = "citation_title"
+	AuthorProp           = "citation_author"
+	JournalTitleProp     = "citation_journal_title"
+	ConferenceTitleProp  = "citation_conference_title"
+	PublisherProp        = "citation_publisher"
+	PublicationDateProp  = "citation_publication_date"
+	DateProp             = "citation_date"
+	OnlineDateProp       = "citation_online_date"
+	DOIProp              = "citation_doi"
+	PDFURLProp           = "citation_pdf_url"
+	AbstractURLProp      = "citation_abstract_html_url"
+	FullTextURLProp      = "citation_fulltext_html_url"
+	VolumeProp           = "citation_volume"
+	IssueProp            = "citation_issue"
+	FirstPageProp        = "citation_firstpage"
+	LastPageProp         = "citation_lastpage"
+	ISSNProp             = "citation_issn"
+	ISBNProp             = "citation_isbn"
+	KeywordsProp         = "citation_keywords"
+	LanguageProp         = "citation_language"
+	AbstractProp         = "citation_abstract"
+	DissertationInstProp = "citation_dissertation_institution"
+)
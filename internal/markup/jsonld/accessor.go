@@ -0,0 +1,184 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jsonld
+
+import "github.com/markusmobius/go-domdistiller/data"
+
+func (ps *Parser) Title() string {
+	for _, item := range ps.getArticleItems() {
+		title := ps.getStringProperty(item, HeadlineProp)
+		if title == "" {
+			title = ps.getStringProperty(item, NameProp)
+		}
+
+		if title != "" {
+			return title
+		}
+	}
+
+	return ""
+}
+
+func (ps *Parser) Type() string {
+	// Returns Article if there's an article.
+	if len(ps.getArticleItems()) > 0 {
+		return "Article"
+	}
+
+	return ""
+}
+
+func (ps *Parser) URL() string {
+	articles := ps.getArticleItems()
+	if len(articles) == 0 {
+		return ""
+	}
+
+	// If "url" is not specified, use "mainEntityOfPage" which could
+	// be either a plain URL or a WebPage object with "@id".
+	url := ps.getStringProperty(articles[0], URLProp)
+	if url == "" {
+		switch v := articles[0][MainEntityOfPageProp].(type) {
+		case string:
+			url = v
+		case map[string]interface{}:
+			url = ps.stringValue(v[IDKey])
+			if url == "" {
+				url = ps.getStringProperty(v, URLProp)
+			}
+		}
+	}
+
+	return url
+}
+
+func (ps *Parser) Images() []data.MarkupImage {
+	// Images are ordered as follows:
+	// 1) the "image" property of each article, then "thumbnailUrl" as fallback,
+	// 2) then, the list of top-level ImageObject's.
+	images := []data.MarkupImage{}
+	for _, item := range ps.getArticleItems() {
+		articleImages := ps.getImages(item, ImageProp)
+		if len(articleImages) == 0 {
+			articleImages = ps.getImages(item, ThumbnailURLProp)
+		}
+		images = append(images, articleImages...)
+	}
+
+	for _, item := range ps.getImageItems() {
+		if image := ps.getImage(item); image != nil {
+			images = append(images, *image)
+		}
+	}
+
+	return images
+}
+
+func (ps *Parser) Description() string {
+	articles := ps.getArticleItems()
+	if len(articles) > 0 {
+		return ps.getStringProperty(articles[0], DescriptionProp)
+	}
+
+	return ""
+}
+
+func (ps *Parser) Publisher() string {
+	// Returns either the "publisher" or "copyrightHolder" property
+	// of the first article.
+	var publisher string
+
+	if articles := ps.getArticleItems(); len(articles) > 0 {
+		publisher = ps.getPersonOrOrganizationName(articles[0], PublisherProp)
+		if publisher == "" {
+			publisher = ps.getPersonOrOrganizationName(articles[0], CopyrightHolderProp)
+		}
+	}
+
+	return publisher
+}
+
+func (ps *Parser) Copyright() string {
+	articles := ps.getArticleItems()
+	if len(articles) == 0 {
+		return ""
+	}
+
+	copyright := ps.getStringProperty(articles[0], CopyrightYearProp)
+	copyrightHolder := ps.getPersonOrOrganizationName(articles[0], CopyrightHolderProp)
+	if copyright != "" && copyrightHolder != "" {
+		copyright += " "
+	}
+	copyright += copyrightHolder
+
+	if copyright != "" {
+		return "Copyright " + copyright
+	}
+	return ""
+}
+
+func (ps *Parser) Author() string {
+	if authors := ps.getAuthors(); len(authors) > 0 {
+		return authors[0]
+	}
+	return ""
+}
+
+func (ps *Parser) Article() *data.MarkupArticle {
+	articles := ps.getArticleItems()
+	if len(articles) == 0 {
+		return nil
+	}
+
+	item := articles[0]
+	publishedTime := ps.getStringProperty(item, DatePublishedProp)
+	if publishedTime == "" {
+		publishedTime = ps.getStringProperty(item, DateCreatedProp)
+	}
+
+	return &data.MarkupArticle{
+		PublishedTime:  publishedTime,
+		ModifiedTime:   ps.getStringProperty(item, DateModifiedProp),
+		ExpirationTime: ps.getStringProperty(item, ExpiresProp),
+		Section:        ps.getStringProperty(item, SectionProp),
+		Authors:        ps.getAuthors(),
+	}
+}
+
+func (ps *Parser) OptOut() bool {
+	return false
+}
+
+// getAuthors returns names in "author" property of the first
+// article, or its "creator" property if there are no authors.
+func (ps *Parser) getAuthors() []string {
+	articles := ps.getArticleItems()
+	if len(articles) == 0 {
+		return nil
+	}
+
+	authors := ps.getNames(articles[0], AuthorProp)
+	if len(authors) == 0 {
+		authors = ps.getNames(articles[0], CreatorProp)
+	}
+
+	return authors
+}
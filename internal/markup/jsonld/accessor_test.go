@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jsonld_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/markup/jsonld"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func Test_JsonLd_ArticleWithNestedObjects(t *testing.T) {
+	doc := createDocWithJsonLd(`{
+		"@context": "https://schema.org",
+		"@type": "NewsArticle",
+		"headline": "Testing JSON-LD article",
+		"description": "Article with nested author, publisher and image",
+		"url": "http://dummy/article.html",
+		"datePublished": "2021-03-01T10:00:00Z",
+		"dateModified": "2021-03-02T11:00:00Z",
+		"articleSection": ["World", "Europe"],
+		"copyrightYear": 2021,
+		"author": [
+			{"@type": "Person", "name": "Jane Doe"},
+			{"@type": "Person", "givenName": "John", "familyName": "Smith"}
+		],
+		"publisher": {
+			"@type": "Organization",
+			"name": "Dummy Times",
+			"logo": {"@type": "ImageObject", "url": "http://dummy/logo.png"}
+		},
+		"copyrightHolder": {"@type": "Organization", "name": "Dummy Corp"},
+		"image": [
+			{"@type": "ImageObject", "url": "http://dummy/image1.jpeg", "width": 600, "height": "400", "caption": "First image"},
+			"http://dummy/image2.jpeg"
+		]
+	}`)
+
+	parser := jsonld.NewParser(doc, nil)
+	assert.Equal(t, "Article", parser.Type())
+	assert.Equal(t, "Testing JSON-LD article", parser.Title())
+	assert.Equal(t, "Article with nested author, publisher and image", parser.Description())
+	assert.Equal(t, "http://dummy/article.html", parser.URL())
+	assert.Equal(t, "Dummy Times", parser.Publisher())
+	assert.Equal(t, "Copyright 2021 Dummy Corp", parser.Copyright())
+	assert.Equal(t, "Jane Doe", parser.Author())
+	assert.False(t, parser.OptOut())
+
+	article := parser.Article()
+	assert.NotNil(t, article)
+	assert.Equal(t, "2021-03-01T10:00:00Z", article.PublishedTime)
+	assert.Equal(t, "2021-03-02T11:00:00Z", article.ModifiedTime)
+	assert.Equal(t, "World", article.Section)
+	assert.Equal(t, []string{"Jane Doe", "John Smith"}, article.Authors)
+
+	images := parser.Images()
+	assert.Equal(t, 2, len(images))
+	assert.Equal(t, "http://dummy/image1.jpeg", images[0].URL)
+	assert.Equal(t, "First image", images[0].Caption)
+	assert.Equal(t, 600, images[0].Width)
+	assert.Equal(t, 400, images[0].Height)
+	assert.Equal(t, "http://dummy/image2.jpeg", images[1].URL)
+}
+
+func Test_JsonLd_GraphWithReferences(t *testing.T) {
+	doc := createDocWithJsonLd(`{
+		"@context": "https://schema.org",
+		"@graph": [
+			{"@type": "WebSite", "@id": "http://dummy/#website", "name": "Dummy Site"},
+			{"@type": "Organization", "@id": "http://dummy/#org", "name": "Dummy Org"},
+			{"@type": "Person", "@id": "http://dummy/#author", "name": "Graph Author"},
+			{"@type": "ImageObject", "@id": "http://dummy/#image", "contentUrl": "http://dummy/graph.jpeg"},
+			{"@type": "WebPage", "@id": "http://dummy/graph.html"},
+			{
+				"@type": ["Article", "BlogPosting"],
+				"headline": "Article in graph",
+				"mainEntityOfPage": {"@id": "http://dummy/graph.html"},
+				"author": {"@id": "http://dummy/#author"},
+				"publisher": {"@id": "http://dummy/#org"},
+				"image": {"@id": "http://dummy/#image"},
+				"datePublished": "2022-01-01"
+			}
+		]
+	}`)
+
+	parser := jsonld.NewParser(doc, nil)
+	assert.Equal(t, "Article", parser.Type())
+	assert.Equal(t, "Article in graph", parser.Title())
+	assert.Equal(t, "http://dummy/graph.html", parser.URL())
+	assert.Equal(t, "Graph Author", parser.Author())
+	assert.Equal(t, "Dummy Org", parser.Publisher())
+	assert.Equal(t, "2022-01-01", parser.Article().PublishedTime)
+
+	// The referenced image is used by the article and also declared as
+	// top-level ImageObject, so it appears twice.
+	images := parser.Images()
+	assert.Equal(t, 2, len(images))
+	assert.Equal(t, "http://dummy/graph.jpeg", images[0].URL)
+	assert.Equal(t, "http://dummy/graph.jpeg", images[1].URL)
+}
+
+func Test_JsonLd_ArrayInMultipleScripts(t *testing.T) {
+	doc := createDocWithJsonLd(
+		`[{"@type": "BreadcrumbList", "name": "Breadcrumb"}]`,
+		`<!-- {invalid json} -->`,
+		`[{"@type": "http://schema.org/BlogPosting", "name": "Blog title", "creator": "Blog Creator"}]`,
+	)
+
+	parser := jsonld.NewParser(doc, nil)
+	assert.Equal(t, "Article", parser.Type())
+	assert.Equal(t, "Blog title", parser.Title())
+	assert.Equal(t, "Blog Creator", parser.Author())
+	assert.Equal(t, []string{"Blog Creator"}, parser.Article().Authors)
+}
+
+func Test_JsonLd_NoArticle(t *testing.T) {
+	doc := createDocWithJsonLd(`{"@type": "WebSite", "name": "Dummy Site", "url": "http://dummy/"}`)
+
+	parser := jsonld.NewParser(doc, nil)
+	assert.Equal(t, "", parser.Type())
+	assert.Equal(t, "", parser.Title())
+	assert.Equal(t, "", parser.URL())
+	assert.Equal(t, "", parser.Author())
+	assert.Nil(t, parser.Article())
+	assert.Equal(t, 0, len(parser.Images()))
+}
+
+func createDocWithJsonLd(scripts ...string) *html.Node {
+	doc := testutil.CreateHTML()
+	head := dom.QuerySelector(doc, "head")
+	for _, content := range scripts {
+		script := dom.CreateElement("script")
+		dom.SetAttribute(script, "type", "application/ld+json")
+		dom.SetTextContent(script, content)
+		dom.AppendChild(head, script)
+	}
+	return doc
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jsonld
+
+const (
+	TypeKey  = "@type"
+	IDKey    = "@id"
+	GraphKey = "@graph"
+	ValueKey = "@value"
+
+	NameProp             = "name"
+	URLProp              = "url"
+	DescriptionProp      = "description"
+	ImageProp            = "image"
+	ThumbnailURLProp     = "thumbnailUrl"
+	HeadlineProp         = "headline"
+	PublisherProp        = "publisher"
+	CopyrightHolderProp  = "copyrightHolder"
+	CopyrightYearProp    = "copyrightYear"
+	ContentURLProp       = "contentUrl"
+	EncodingFormatProp   = "encodingFormat"
+	CaptionProp          = "caption"
+	WidthProp            = "width"
+	HeightProp           = "height"
+	DatePublishedProp    = "datePublished"
+	DateCreatedProp      = "dateCreated"
+	DateModifiedProp     = "dateModified"
+	ExpiresProp          = "expires"
+	AuthorProp           = "author"
+	CreatorProp          = "creator"
+	SectionProp          = "articleSection"
+	MainEntityOfPageProp = "mainEntityOfPage"
+	FamilyNameProp       = "familyName"
+	GivenNameProp        = "givenName"
+	LegalNameProp        = "legalName"
+
+	// Properties for structured data
+	EmbedURLProp            = "embedUrl"
+	UploadDateProp          = "uploadDate"
+	DurationProp            = "duration"
+	PrepTimeProp            = "prepTime"
+	CookTimeProp            = "cookTime"
+	TotalTimeProp           = "totalTime"
+	RecipeYieldProp         = "recipeYield"
+	RecipeCategoryProp      = "recipeCategory"
+	RecipeCuisineProp       = "recipeCuisine"
+	RecipeIngredientProp    = "recipeIngredient"
+	IngredientsProp         = "ingredients"
+	RecipeInstructionsProp  = "recipeInstructions"
+	ItemListElementProp     = "itemListElement"
+	NutritionProp           = "nutrition"
+	CaloriesProp            = "calories"
+	StartDateProp           = "startDate"
+	EndDateProp             = "endDate"
+	EventStatusProp         = "eventStatus"
+	EventAttendanceModeProp = "eventAttendanceMode"
+	LocationProp            = "location"
+	OrganizerProp           = "organizer"
+	PerformerProp           = "performer"
+	AddressProp             = "address"
+	StreetAddressProp       = "streetAddress"
+	AddressLocalityProp     = "addressLocality"
+	AddressRegionProp       = "addressRegion"
+	PostalCodeProp          = "postalCode"
+	AddressCountryProp      = "addressCountry"
+	BrandProp               = "brand"
+	SKUProp                 = "sku"
+	MPNProp                 = "mpn"
+	OffersProp              = "offers"
+	PriceProp               = "price"
+	LowPriceProp            = "lowPrice"
+	HighPriceProp           = "highPrice"
+	PriceCurrencyProp       = "priceCurrency"
+	AvailabilityProp        = "availability"
+	SellerProp              = "seller"
+	ValidFromProp           = "validFrom"
+	ReviewProp              = "review"
+	ReviewBodyProp          = "reviewBody"
+	ItemReviewedProp        = "itemReviewed"
+	ReviewRatingProp        = "reviewRating"
+	AggregateRatingProp     = "aggregateRating"
+	RatingValueProp         = "ratingValue"
+	BestRatingProp          = "bestRating"
+	WorstRatingProp         = "worstRating"
+	RatingCountProp         = "ratingCount"
+	ReviewCountProp         = "reviewCount"
+	MainEntityProp          = "mainEntity"
+	AcceptedAnswerProp      = "acceptedAnswer"
+	SuggestedAnswerProp     = "suggestedAnswer"
+	TextProp                = "text"
+	SupplyProp              = "supply"
+	ToolProp                = "tool"
+	StepProp                = "step"
+)
+
+// gtinProps is the properties for product's GTIN, ordered by priority.
+var gtinProps = []string{"gtin", "gtin13", "gtin12", "gtin14", "gtin8"}
+
+type SchemaType uint
+
+const (
+	Unsupported SchemaType = iota
+	Image
+	Article
+	Person
+	Organization
+	Video
+	Audio
+	Recipe
+	Event
+	Product
+	Review
+	FAQPage
+	HowTo
+)
+
+// schemaTypes maps the value of "@type" into the type that we support. Unlike
+// microdata, JSON-LD commonly uses the bare type name (e.g. "NewsArticle"), so
+// the schema.org URL prefix is stripped before looking up this map.
+var schemaTypes = map[string]SchemaType{
+	"ImageObject":              Image,
+	"Article":                  Article,
+	"AdvertiserContentArticle": Article,
+	"AnalysisNewsArticle":      Article,
+	"BackgroundNewsArticle":    Article,
+	"BlogPosting":              Article,
+	"LiveBlogPosting":          Article,
+	"NewsArticle":              Article,
+	"OpinionNewsArticle":       Article,
+	"ReportageNewsArticle":     Article,
+	"ReviewNewsArticle":        Article,
+	"Report":                   Article,
+	"SatiricalArticle":         Article,
+	"ScholarlyArticle":         Article,
+	"SocialMediaPosting":       Article,
+	"TechArticle":              Article,
+	"Person":                   Person,
+	"Organization":             Organization,
+	"Corporation":              Organization,
+	"EducationalOrganization":  Organization,
+	"GovernmentOrganization":   Organization,
+	"NewsMediaOrganization":    Organization,
+	"NGO":                      Organization,
+}
+
+// structuredTypes maps the value of "@type" into the type that used for structured
+// data. It's separated from schemaTypes so an item that also declared as article
+// (e.g. ["Recipe", "Article"]) is still treated as an article by the accessor.
+var structuredTypes = map[string]SchemaType{
+	"VideoObject":     Video,
+	"AudioObject":     Audio,
+	"PodcastEpisode":  Audio,
+	"Recipe":          Recipe,
+	"Event":           Event,
+	"BusinessEvent":   Event,
+	"ComedyEvent":     Event,
+	"EducationEvent":  Event,
+	"ExhibitionEvent": Event,
+	"Festival":        Event,
+	"MusicEvent":      Event,
+	"SportsEvent":     Event,
+	"TheaterEvent":    Event,
+	"Product":         Product,
+	"Review":          Review,
+	"FAQPage":         FAQPage,
+	"HowTo":           HowTo,
+}
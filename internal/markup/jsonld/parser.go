@@ -0,0 +1,371 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jsonld
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"golang.org/x/net/html"
+)
+
+var schemaPrefixes = []string{
+	"http://schema.org/",
+	"https://schema.org/",
+	"schema:",
+}
+
+// Parser recognizes and parses JSON-LD structured data, i.e. the content of
+// <script type="application/ld+json"> elements, and returns the properties that
+// matter to distilled content. The vocabulary is schema.org, so the supported types
+// and properties are the same as the ones in schemaorg.Parser:
+//   - Article: headline (i.e. title), name, url, description, image, publisher,
+//     copyright year, copyright holder, date published, date modified,
+//     expires, author, creator, article section
+//   - ImageObject: url, content url, encoding format, caption, width, height
+//   - Person: name, family name, given name
+//   - Organization: name, legal name.
+//
+// A script may contain a single object, an array of objects or an object with "@graph"
+// property. Objects inside "@graph" may refer to each other using "@id", e.g. the author
+// of an article may be declared as {"@id": "#author"}, so those references are resolved
+// before the value is used. It implements markup.Accessor.
+type Parser struct {
+	items []map[string]interface{}
+	idMap map[string]map[string]interface{}
+}
+
+func NewParser(root *html.Node, timingInfo *data.TimingInfo) *Parser {
+	// Initiate parser
+	ps := &Parser{}
+	ps.idMap = make(map[string]map[string]interface{})
+
+	start := time.Now()
+	ps.parse(root)
+	timingInfo.AddEntry(start, "JsonLd.parse")
+
+	return ps
+}
+
+func (ps *Parser) parse(root *html.Node) {
+	for _, script := range dom.GetElementsByTagName(root, "script") {
+		scriptType := dom.GetAttribute(script, "type")
+		scriptType = strings.ToLower(strings.TrimSpace(scriptType))
+		if scriptType != "application/ld+json" {
+			continue
+		}
+
+		// Some sites wrap their JSON-LD within HTML comment or CDATA,
+		// so clean it up before decoding.
+		content := strings.TrimSpace(dom.TextContent(script))
+		content = strings.TrimPrefix(content, "<!--")
+		content = strings.TrimSuffix(content, "-->")
+		content = strings.TrimPrefix(content, "//<![CDATA[")
+		content = strings.TrimSuffix(content, "//]]>")
+		if content == "" {
+			continue
+		}
+
+		// Invalid JSON is common enough in the wild, so just skip it.
+		var value interface{}
+		if err := json.Unmarshal([]byte(content), &value); err != nil {
+			continue
+		}
+
+		ps.collectItems(value)
+	}
+}
+
+// collectItems flattens arrays and "@graph" into a list of top-level items,
+// and registers every object that has an "@id" so it can be referenced later.
+func (ps *Parser) collectItems(value interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, entry := range v {
+			ps.collectItems(entry)
+		}
+
+	case map[string]interface{}:
+		if graph, exist := v[GraphKey]; exist {
+			ps.collectItems(graph)
+		}
+
+		if _, hasType := v[TypeKey]; hasType {
+			ps.items = append(ps.items, v)
+		}
+
+		ps.registerIDs(v)
+	}
+}
+
+// registerIDs walks through the object and its nested objects, then saves every
+// object with "@id" and other properties into the ID map.
+func (ps *Parser) registerIDs(value interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, entry := range v {
+			ps.registerIDs(entry)
+		}
+
+	case map[string]interface{}:
+		if id, isString := v[IDKey].(string); isString && id != "" && len(v) > 1 {
+			if _, exist := ps.idMap[id]; !exist {
+				ps.idMap[id] = v
+			}
+		}
+
+		for key, entry := range v {
+			if key != GraphKey {
+				ps.registerIDs(entry)
+			}
+		}
+	}
+}
+
+// resolve returns the full object that referenced by value
+// if the value is only a reference (i.e. only has "@id").
+func (ps *Parser) resolve(value interface{}) interface{} {
+	obj, isObject := value.(map[string]interface{})
+	if !isObject {
+		return value
+	}
+
+	id, isString := obj[IDKey].(string)
+	if !isString || len(obj) > 1 {
+		return value
+	}
+
+	if target, exist := ps.idMap[id]; exist {
+		return target
+	}
+
+	return value
+}
+
+func (ps *Parser) getArticleItems() []map[string]interface{} {
+	return ps.getItems(Article)
+}
+
+func (ps *Parser) getImageItems() []map[string]interface{} {
+	return ps.getItems(Image)
+}
+
+func (ps *Parser) getItems(schemaType SchemaType) []map[string]interface{} {
+	var items []map[string]interface{}
+	for _, item := range ps.items {
+		if getType(item) == schemaType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getStringProperty returns the string value of the specified property.
+// If the property has several values, the first non-empty one is used.
+func (ps *Parser) getStringProperty(item map[string]interface{}, propertyName string) string {
+	return ps.stringValue(item[propertyName])
+}
+
+func (ps *Parser) stringValue(value interface{}) string {
+	switch v := ps.resolve(value).(type) {
+	case string:
+		return strings.TrimSpace(v)
+
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+
+	case []interface{}:
+		for _, entry := range v {
+			if str := ps.stringValue(entry); str != "" {
+				return str
+			}
+		}
+
+	case map[string]interface{}:
+		if entry, exist := v[ValueKey]; exist {
+			return ps.stringValue(entry)
+		}
+	}
+
+	return ""
+}
+
+// getNames returns the names of every person or organization in the
+// specified property. The property value could be a plain string, an
+// object, or an array of both.
+func (ps *Parser) getNames(item map[string]interface{}, propertyName string) []string {
+	var names []string
+	var collect func(interface{})
+	collect = func(value interface{}) {
+		switch v := ps.resolve(value).(type) {
+		case []interface{}:
+			for _, entry := range v {
+				collect(entry)
+			}
+
+		case string:
+			if name := strings.TrimSpace(v); name != "" {
+				names = append(names, name)
+			}
+
+		case map[string]interface{}:
+			if name := ps.getName(v); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	collect(item[propertyName])
+	return names
+}
+
+// getPersonOrOrganizationName returns the first name in the specified property.
+func (ps *Parser) getPersonOrOrganizationName(item map[string]interface{}, propertyName string) string {
+	if names := ps.getNames(item, propertyName); len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+func (ps *Parser) getName(item map[string]interface{}) string {
+	if name := ps.getStringProperty(item, NameProp); name != "" {
+		return name
+	}
+
+	switch getType(item) {
+	case Person:
+		givenName := ps.getStringProperty(item, GivenNameProp)
+		familyName := ps.getStringProperty(item, FamilyNameProp)
+		return strings.TrimSpace(givenName + " " + familyName)
+
+	case Organization:
+		return ps.getStringProperty(item, LegalNameProp)
+	}
+
+	return ""
+}
+
+// getImages returns the images in the specified property. The property value
+// could be a plain URL, an ImageObject, or an array of both.
+func (ps *Parser) getImages(item map[string]interface{}, propertyName string) []data.MarkupImage {
+	var images []data.MarkupImage
+	var collect func(interface{})
+	collect = func(value interface{}) {
+		switch v := ps.resolve(value).(type) {
+		case []interface{}:
+			for _, entry := range v {
+				collect(entry)
+			}
+
+		case string:
+			if url := strings.TrimSpace(v); url != "" {
+				images = append(images, data.MarkupImage{URL: url})
+			}
+
+		case map[string]interface{}:
+			if image := ps.getImage(v); image != nil {
+				images = append(images, *image)
+			}
+		}
+	}
+
+	collect(item[propertyName])
+	return images
+}
+
+func (ps *Parser) getImage(item map[string]interface{}) *data.MarkupImage {
+	url := ps.getStringProperty(item, URLProp)
+	if url == "" {
+		url = ps.getStringProperty(item, ContentURLProp)
+	}
+
+	if url == "" {
+		return nil
+	}
+
+	return &data.MarkupImage{
+		URL:     url,
+		Type:    ps.getStringProperty(item, EncodingFormatProp),
+		Caption: ps.getStringProperty(item, CaptionProp),
+		Width:   ps.getIntProperty(item, WidthProp),
+		Height:  ps.getIntProperty(item, HeightProp),
+	}
+}
+
+// getIntProperty returns the integer value of the specified property. The value
+// might be declared as number, string (e.g. "600" or "600px") or QuantitativeValue.
+func (ps *Parser) getIntProperty(item map[string]interface{}, propertyName string) int {
+	value := ps.resolve(item[propertyName])
+	if obj, isObject := value.(map[string]interface{}); isObject {
+		value = obj["value"]
+	}
+
+	str := ps.stringValue(value)
+	str = strings.TrimSuffix(str, "px")
+	number, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	if err != nil {
+		return 0
+	}
+
+	return int(number)
+}
+
+// getType returns the first supported schema type from "@type" of the item.
+func getType(item map[string]interface{}) SchemaType {
+	return lookupType(item, schemaTypes)
+}
+
+// getStructuredType returns the first type from "@type" of the item
+// that used for structured data.
+func getStructuredType(item map[string]interface{}) SchemaType {
+	return lookupType(item, structuredTypes)
+}
+
+func lookupType(item map[string]interface{}, types map[string]SchemaType) SchemaType {
+	var typeNames []string
+	switch v := item[TypeKey].(type) {
+	case string:
+		typeNames = []string{v}
+	case []interface{}:
+		for _, entry := range v {
+			if typeName, isString := entry.(string); isString {
+				typeNames = append(typeNames, typeName)
+			}
+		}
+	}
+
+	for _, typeName := range typeNames {
+		typeName = strings.TrimSpace(typeName)
+		for _, prefix := range schemaPrefixes {
+			typeName = strings.TrimPrefix(typeName, prefix)
+		}
+
+		if schemaType, exist := types[typeName]; exist {
+			return schemaType
+		}
+	}
+
+	return Unsupported
+}
@@ -0,0 +1,405 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jsonld
+
+import (
+	"strings"
+
+	"github.com/markusmobius/go-domdistiller/data"
+)
+
+// StructuredData returns the top-level items of the types that matter to the
+// structured data, e.g. recipes and events, in the order they are declared.
+func (ps *Parser) StructuredData() data.StructuredData {
+	var sd data.StructuredData
+	for _, item := range ps.items {
+		switch getStructuredType(item) {
+		case Video:
+			sd.Videos = append(sd.Videos, ps.getVideo(item))
+		case Audio:
+			sd.Audios = append(sd.Audios, ps.getAudio(item))
+		case Recipe:
+			sd.Recipes = append(sd.Recipes, ps.getRecipe(item))
+		case Event:
+			sd.Events = append(sd.Events, ps.getEvent(item))
+		case Product:
+			sd.Products = append(sd.Products, ps.getProduct(item))
+		case Review:
+			sd.Reviews = append(sd.Reviews, ps.getReview(item))
+		case FAQPage:
+			sd.FAQs = append(sd.FAQs, ps.getFAQPage(item))
+		case HowTo:
+			sd.HowTos = append(sd.HowTos, ps.getHowTo(item))
+		}
+	}
+	return sd
+}
+
+func (ps *Parser) getVideo(item map[string]interface{}) data.VideoObject {
+	return data.VideoObject{
+		Name:         ps.getStringProperty(item, NameProp),
+		Description:  ps.getStringProperty(item, DescriptionProp),
+		ThumbnailURL: ps.getImageURL(item, ThumbnailURLProp),
+		ContentURL:   ps.getStringProperty(item, ContentURLProp),
+		EmbedURL:     ps.getStringProperty(item, EmbedURLProp),
+		UploadDate:   ps.getStringProperty(item, UploadDateProp),
+		Duration:     ps.getStringProperty(item, DurationProp),
+	}
+}
+
+func (ps *Parser) getAudio(item map[string]interface{}) data.AudioObject {
+	return data.AudioObject{
+		Name:           ps.getStringProperty(item, NameProp),
+		Description:    ps.getStringProperty(item, DescriptionProp),
+		ContentURL:     ps.getStringProperty(item, ContentURLProp),
+		EmbedURL:       ps.getStringProperty(item, EmbedURLProp),
+		EncodingFormat: ps.getStringProperty(item, EncodingFormatProp),
+		UploadDate:     ps.getStringProperty(item, UploadDateProp),
+		Duration:       ps.getStringProperty(item, DurationProp),
+	}
+}
+
+func (ps *Parser) getRecipe(item map[string]interface{}) data.Recipe {
+	// Old recipes use "ingredients" property, which is superseded by "recipeIngredient".
+	ingredients := ps.getStrings(item, RecipeIngredientProp)
+	if len(ingredients) == 0 {
+		ingredients = ps.getStrings(item, IngredientsProp)
+	}
+
+	var calories string
+	if nutritions := ps.getObjects(item, NutritionProp); len(nutritions) > 0 {
+		calories = ps.getStringProperty(nutritions[0], CaloriesProp)
+	}
+
+	return data.Recipe{
+		Name:         ps.getStringProperty(item, NameProp),
+		Description:  ps.getStringProperty(item, DescriptionProp),
+		Image:        ps.getImageURL(item, ImageProp),
+		Author:       ps.getPersonOrOrganizationName(item, AuthorProp),
+		PublishedAt:  ps.getStringProperty(item, DatePublishedProp),
+		PrepTime:     ps.getStringProperty(item, PrepTimeProp),
+		CookTime:     ps.getStringProperty(item, CookTimeProp),
+		TotalTime:    ps.getStringProperty(item, TotalTimeProp),
+		Yield:        ps.getStringProperty(item, RecipeYieldProp),
+		Category:     ps.getStringProperty(item, RecipeCategoryProp),
+		Cuisine:      ps.getStringProperty(item, RecipeCuisineProp),
+		Calories:     calories,
+		Ingredients:  ingredients,
+		Instructions: ps.getInstructions(item[RecipeInstructionsProp]),
+		Rating:       ps.getRating(item, AggregateRatingProp),
+	}
+}
+
+// getInstructions returns the text of recipe instructions. The instructions might be a
+// plain text, a list of HowToStep, or a list of HowToSection which contains the steps.
+func (ps *Parser) getInstructions(value interface{}) []string {
+	var instructions []string
+	switch v := ps.resolve(value).(type) {
+	case string:
+		if text := strings.TrimSpace(v); text != "" {
+			instructions = append(instructions, text)
+		}
+
+	case []interface{}:
+		for _, entry := range v {
+			instructions = append(instructions, ps.getInstructions(entry)...)
+		}
+
+	case map[string]interface{}:
+		if steps, exist := v[ItemListElementProp]; exist {
+			return ps.getInstructions(steps)
+		}
+
+		text := ps.getStringProperty(v, TextProp)
+		if text == "" {
+			text = ps.getStringProperty(v, NameProp)
+		}
+
+		if text != "" {
+			instructions = append(instructions, text)
+		}
+	}
+	return instructions
+}
+
+func (ps *Parser) getEvent(item map[string]interface{}) data.Event {
+	// Location might be a plain text, a Place with address, or a VirtualLocation.
+	var location, address string
+	switch v := ps.resolve(item[LocationProp]).(type) {
+	case string:
+		location = strings.TrimSpace(v)
+	case []interface{}, map[string]interface{}:
+		if places := ps.getObjects(item, LocationProp); len(places) > 0 {
+			location = ps.getStringProperty(places[0], NameProp)
+			if location == "" {
+				location = ps.getStringProperty(places[0], URLProp)
+			}
+			address = ps.getAddress(places[0][AddressProp])
+		}
+	}
+
+	return data.Event{
+		Name:           ps.getStringProperty(item, NameProp),
+		Description:    ps.getStringProperty(item, DescriptionProp),
+		Image:          ps.getImageURL(item, ImageProp),
+		URL:            ps.getStringProperty(item, URLProp),
+		StartDate:      ps.getStringProperty(item, StartDateProp),
+		EndDate:        ps.getStringProperty(item, EndDateProp),
+		Status:         ps.getStringProperty(item, EventStatusProp),
+		AttendanceMode: ps.getStringProperty(item, EventAttendanceModeProp),
+		Location:       location,
+		Address:        address,
+		Organizer:      ps.getPersonOrOrganizationName(item, OrganizerProp),
+		Performers:     ps.getNames(item, PerformerProp),
+		Offers:         ps.getOffers(item, OffersProp),
+	}
+}
+
+// getAddress returns the address as a plain text. The address might
+// be a plain text or a PostalAddress.
+func (ps *Parser) getAddress(value interface{}) string {
+	switch v := ps.resolve(value).(type) {
+	case string:
+		return strings.TrimSpace(v)
+
+	case map[string]interface{}:
+		var parts []string
+		for _, prop := range []string{StreetAddressProp, AddressLocalityProp,
+			AddressRegionProp, PostalCodeProp, AddressCountryProp} {
+			part := ps.getStringProperty(v, prop)
+			if part == "" {
+				// Country might be declared as Country object
+				if country, isObject := ps.resolve(v[prop]).(map[string]interface{}); isObject {
+					part = ps.getStringProperty(country, NameProp)
+				}
+			}
+
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	return ""
+}
+
+func (ps *Parser) getProduct(item map[string]interface{}) data.Product {
+	var gtin string
+	for _, prop := range gtinProps {
+		if gtin = ps.getStringProperty(item, prop); gtin != "" {
+			break
+		}
+	}
+
+	var reviews []data.Review
+	for _, review := range ps.getObjects(item, ReviewProp) {
+		reviews = append(reviews, ps.getReview(review))
+	}
+
+	return data.Product{
+		Name:        ps.getStringProperty(item, NameProp),
+		Description: ps.getStringProperty(item, DescriptionProp),
+		Image:       ps.getImageURL(item, ImageProp),
+		URL:         ps.getStringProperty(item, URLProp),
+		Brand:       ps.getPersonOrOrganizationName(item, BrandProp),
+		SKU:         ps.getStringProperty(item, SKUProp),
+		GTIN:        gtin,
+		MPN:         ps.getStringProperty(item, MPNProp),
+		Offers:      ps.getOffers(item, OffersProp),
+		Rating:      ps.getRating(item, AggregateRatingProp),
+		Reviews:     reviews,
+	}
+}
+
+// getOffers returns the Offer and AggregateOffer in the specified property.
+func (ps *Parser) getOffers(item map[string]interface{}, propertyName string) []data.Offer {
+	var offers []data.Offer
+	for _, offer := range ps.getObjects(item, propertyName) {
+		price := ps.getStringProperty(offer, PriceProp)
+		if price == "" {
+			price = ps.getStringProperty(offer, LowPriceProp)
+		}
+
+		offers = append(offers, data.Offer{
+			Price:         price,
+			HighPrice:     ps.getStringProperty(offer, HighPriceProp),
+			PriceCurrency: ps.getStringProperty(offer, PriceCurrencyProp),
+			Availability:  ps.getStringProperty(offer, AvailabilityProp),
+			URL:           ps.getStringProperty(offer, URLProp),
+			Seller:        ps.getPersonOrOrganizationName(offer, SellerProp),
+			ValidFrom:     ps.getStringProperty(offer, ValidFromProp),
+		})
+	}
+	return offers
+}
+
+func (ps *Parser) getReview(item map[string]interface{}) data.Review {
+	return data.Review{
+		Name:         ps.getStringProperty(item, NameProp),
+		Author:       ps.getPersonOrOrganizationName(item, AuthorProp),
+		PublishedAt:  ps.getStringProperty(item, DatePublishedProp),
+		Body:         ps.getStringProperty(item, ReviewBodyProp),
+		ItemReviewed: ps.getPersonOrOrganizationName(item, ItemReviewedProp),
+		Rating:       ps.getRating(item, ReviewRatingProp),
+	}
+}
+
+// getRating returns the Rating or AggregateRating in the specified property.
+func (ps *Parser) getRating(item map[string]interface{}, propertyName string) *data.Rating {
+	ratings := ps.getObjects(item, propertyName)
+	if len(ratings) == 0 {
+		return nil
+	}
+
+	return &data.Rating{
+		Value:       ps.getStringProperty(ratings[0], RatingValueProp),
+		Best:        ps.getStringProperty(ratings[0], BestRatingProp),
+		Worst:       ps.getStringProperty(ratings[0], WorstRatingProp),
+		RatingCount: ps.getStringProperty(ratings[0], RatingCountProp),
+		ReviewCount: ps.getStringProperty(ratings[0], ReviewCountProp),
+	}
+}
+
+func (ps *Parser) getFAQPage(item map[string]interface{}) data.FAQPage {
+	var questions []data.Question
+	for _, question := range ps.getObjects(item, MainEntityProp) {
+		// The question is usually put in "name", while "text" is used for the longer
+		// version. The accepted answer is preferred over the suggested one.
+		text := ps.getStringProperty(question, NameProp)
+		if text == "" {
+			text = ps.getStringProperty(question, TextProp)
+		}
+
+		if text == "" {
+			continue
+		}
+
+		var answer string
+		for _, prop := range []string{AcceptedAnswerProp, SuggestedAnswerProp} {
+			if answers := ps.getObjects(question, prop); len(answers) > 0 {
+				if answer = ps.getStringProperty(answers[0], TextProp); answer != "" {
+					break
+				}
+			}
+		}
+
+		questions = append(questions, data.Question{
+			Question: text,
+			Answer:   answer,
+		})
+	}
+
+	return data.FAQPage{
+		Name:      ps.getStringProperty(item, NameProp),
+		Questions: questions,
+	}
+}
+
+func (ps *Parser) getHowTo(item map[string]interface{}) data.HowTo {
+	// The steps might be a plain text, a list of HowToStep, or a
+	// list of HowToSection which contains the steps.
+	var steps []data.HowToStep
+	var collect func(interface{})
+	collect = func(value interface{}) {
+		switch v := ps.resolve(value).(type) {
+		case string:
+			if text := strings.TrimSpace(v); text != "" {
+				steps = append(steps, data.HowToStep{Text: text})
+			}
+
+		case []interface{}:
+			for _, entry := range v {
+				collect(entry)
+			}
+
+		case map[string]interface{}:
+			if entries, exist := v[ItemListElementProp]; exist {
+				collect(entries)
+				return
+			}
+
+			steps = append(steps, data.HowToStep{
+				Name:  ps.getStringProperty(v, NameProp),
+				Text:  ps.getStringProperty(v, TextProp),
+				URL:   ps.getStringProperty(v, URLProp),
+				Image: ps.getImageURL(v, ImageProp),
+			})
+		}
+	}
+	collect(item[StepProp])
+
+	return data.HowTo{
+		Name:        ps.getStringProperty(item, NameProp),
+		Description: ps.getStringProperty(item, DescriptionProp),
+		Image:       ps.getImageURL(item, ImageProp),
+		TotalTime:   ps.getStringProperty(item, TotalTimeProp),
+		Supplies:    ps.getNames(item, SupplyProp),
+		Tools:       ps.getNames(item, ToolProp),
+		Steps:       steps,
+	}
+}
+
+// getObjects returns the objects in the specified property, which
+// might be a single object or an array of objects.
+func (ps *Parser) getObjects(item map[string]interface{}, propertyName string) []map[string]interface{} {
+	var objects []map[string]interface{}
+	var collect func(interface{})
+	collect = func(value interface{}) {
+		switch v := ps.resolve(value).(type) {
+		case []interface{}:
+			for _, entry := range v {
+				collect(entry)
+			}
+		case map[string]interface{}:
+			objects = append(objects, v)
+		}
+	}
+
+	collect(item[propertyName])
+	return objects
+}
+
+// getStrings returns every string values in the specified property.
+func (ps *Parser) getStrings(item map[string]interface{}, propertyName string) []string {
+	var values []string
+	switch v := ps.resolve(item[propertyName]).(type) {
+	case []interface{}:
+		for _, entry := range v {
+			if str := ps.stringValue(entry); str != "" {
+				values = append(values, str)
+			}
+		}
+	default:
+		if str := ps.stringValue(v); str != "" {
+			values = append(values, str)
+		}
+	}
+	return values
+}
+
+// getImageURL returns the URL of the first image in the specified property.
+func (ps *Parser) getImageURL(item map[string]interface{}, propertyName string) string {
+	if images := ps.getImages(item, propertyName); len(images) > 0 {
+		return images[0].URL
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package jsonld_test
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/markup/jsonld"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JsonLd_StructuredData_Recipe(t *testing.T) {
+	doc := createDocWithJsonLd(`{
+		"@context": "https://schema.org",
+		"@type": "Recipe",
+		"name": "Pancakes",
+		"image": ["http://dummy/pancakes.jpeg"],
+		"author": {"@type": "Person", "name": "Jane Doe"},
+		"prepTime": "PT10M",
+		"totalTime": "PT30M",
+		"recipeYield": ["4", "4 servings"],
+		"recipeIngredient": ["2 eggs", "1 cup flour"],
+		"recipeInstructions": [
+			{"@type": "HowToSection", "name": "Batter", "itemListElement": [
+				{"@type": "HowToStep", "text": "Mix everything."}
+			]},
+			{"@type": "HowToStep", "text": "Fry it."}
+		],
+		"nutrition": {"@type": "NutritionInformation", "calories": "250 calories"},
+		"aggregateRating": {"@type": "AggregateRating", "ratingValue": 4.5, "ratingCount": "12"}
+	}`)
+
+	parser := jsonld.NewParser(doc, nil)
+	sd := parser.StructuredData()
+	assert.Equal(t, 1, len(sd.Recipes))
+
+	recipe := sd.Recipes[0]
+	assert.Equal(t, "Pancakes", recipe.Name)
+	assert.Equal(t, "http://dummy/pancakes.jpeg", recipe.Image)
+	assert.Equal(t, "Jane Doe", recipe.Author)
+	assert.Equal(t, "PT10M", recipe.PrepTime)
+	assert.Equal(t, "4", recipe.Yield)
+	assert.Equal(t, "250 calories", recipe.Calories)
+	assert.Equal(t, []string{"2 eggs", "1 cup flour"}, recipe.Ingredients)
+	assert.Equal(t, []string{"Mix everything.", "Fry it."}, recipe.Instructions)
+	assert.Equal(t, &data.Rating{Value: "4.5", RatingCount: "12"}, recipe.Rating)
+
+	// Recipe is not an article, so it's not used as the page metadata.
+	assert.Equal(t, "", parser.Type())
+}
+
+func Test_JsonLd_StructuredData_EventAndProduct(t *testing.T) {
+	doc := createDocWithJsonLd(`[{
+		"@type": "MusicEvent",
+		"name": "Summer Concert",
+		"startDate": "2023-07-01T19:00",
+		"eventStatus": "https://schema.org/EventScheduled",
+		"location": {
+			"@type": "Place",
+			"name": "City Hall",
+			"address": {"@type": "PostalAddress", "streetAddress": "1 Main St", "addressLocality": "Springfield"}
+		},
+		"performer": [{"@type": "MusicGroup", "name": "The Dummies"}, "Jane Doe"],
+		"offers": {"@type": "Offer", "price": 25, "priceCurrency": "USD"}
+	}, {
+		"@type": "Product",
+		"name": "Dummy Phone",
+		"brand": {"@type": "Brand", "name": "Dummy"},
+		"gtin13": "0000000000001",
+		"offers": {"@type": "AggregateOffer", "lowPrice": "199", "highPrice": "299"},
+		"review": [{
+			"@type": "Review",
+			"author": {"@type": "Person", "name": "John Smith"},
+			"reviewBody": "Works well.",
+			"reviewRating": {"@type": "Rating", "ratingValue": "5"}
+		}]
+	}]`)
+
+	sd := jsonld.NewParser(doc, nil).StructuredData()
+	assert.Equal(t, 1, len(sd.Events))
+	assert.Equal(t, 1, len(sd.Products))
+	assert.Equal(t, 0, len(sd.Reviews))
+
+	event := sd.Events[0]
+	assert.Equal(t, "Summer Concert", event.Name)
+	assert.Equal(t, "https://schema.org/EventScheduled", event.Status)
+	assert.Equal(t, "City Hall", event.Location)
+	assert.Equal(t, "1 Main St, Springfield", event.Address)
+	assert.Equal(t, []string{"The Dummies", "Jane Doe"}, event.Performers)
+	assert.Equal(t, []data.Offer{{Price: "25", PriceCurrency: "USD"}}, event.Offers)
+
+	product := sd.Products[0]
+	assert.Equal(t, "Dummy", product.Brand)
+	assert.Equal(t, "0000000000001", product.GTIN)
+	assert.Equal(t, []data.Offer{{Price: "199", HighPrice: "299"}}, product.Offers)
+	assert.Equal(t, []data.Review{{
+		Author: "John Smith",
+		Body:   "Works well.",
+		Rating: &data.Rating{Value: "5"},
+	}}, product.Reviews)
+}
+
+func Test_JsonLd_StructuredData_FAQAndHowTo(t *testing.T) {
+	doc := createDocWithJsonLd(`{
+		"@context": "https://schema.org",
+		"@graph": [{
+			"@type": "FAQPage",
+			"mainEntity": [
+				{"@type": "Question", "name": "What is it?", "acceptedAnswer": {"@type": "Answer", "text": "A dummy."}},
+				{"@type": "Question", "name": "Is it free?", "acceptedAnswer": {"@id": "#free"}}
+			]
+		}, {
+			"@id": "#free", "@type": "Answer", "text": "Yes."
+		}, {
+			"@type": "HowTo",
+			"name": "Change a tire",
+			"tool": [{"@type": "HowToTool", "name": "Jack"}],
+			"supply": "Spare tire",
+			"step": [{"@type": "HowToStep", "name": "Loosen", "text": "Loosen the nuts.", "url": "#step1"}]
+		}, {
+			"@type": "VideoObject",
+			"name": "Tire video",
+			"thumbnailUrl": ["http://dummy/thumb.jpeg"],
+			"uploadDate": "2023-01-01"
+		}, {
+			"@type": "https://schema.org/PodcastEpisode",
+			"name": "Episode 1",
+			"contentUrl": "http://dummy/episode1.mp3"
+		}]
+	}`)
+
+	sd := jsonld.NewParser(doc, nil).StructuredData()
+	assert.Equal(t, []data.FAQPage{{Questions: []data.Question{
+		{Question: "What is it?", Answer: "A dummy."},
+		{Question: "Is it free?", Answer: "Yes."},
+	}}}, sd.FAQs)
+
+	assert.Equal(t, []data.HowTo{{
+		Name:     "Change a tire",
+		Supplies: []string{"Spare tire"},
+		Tools:    []string{"Jack"},
+		Steps:    []data.HowToStep{{Name: "Loosen", Text: "Loosen the nuts.", URL: "#step1"}},
+	}}, sd.HowTos)
+
+	assert.Equal(t, []data.VideoObject{{
+		Name:         "Tire video",
+		ThumbnailURL: "http://dummy/thumb.jpeg",
+		UploadDate:   "2023-01-01",
+	}}, sd.Videos)
+
+	assert.Equal(t, []data.AudioObject{{
+		Name:       "Episode 1",
+		ContentURL: "http://dummy/episode1.mp3",
+	}}, sd.Audios)
+}
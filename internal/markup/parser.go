@@ -27,24 +27,34 @@
 package markup
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/authorutil"
+	"github.com/markusmobius/go-domdistiller/internal/markup/dublincore"
+	"github.com/markusmobius/go-domdistiller/internal/markup/highwire"
 	"github.com/markusmobius/go-domdistiller/internal/markup/iereader"
+	"github.com/markusmobius/go-domdistiller/internal/markup/jsonld"
 	"github.com/markusmobius/go-domdistiller/internal/markup/opengraph"
 	"github.com/markusmobius/go-domdistiller/internal/markup/schemaorg"
+	"github.com/markusmobius/go-domdistiller/internal/markup/twittercard"
+	"github.com/markusmobius/go-domdistiller/internal/timeutil"
 	"golang.org/x/net/html"
 )
 
 // Parser loads the different parsers that are based on different markup specifications, and
 // allows retrieval of different distillation-related markup properties from a document. It retrieves
-// the requested properties from one or more parsers.  If necessary, it may merge the information
-// from multiple parsers.
+// the requested properties from one or more parsers, and records which parser supplied each of them.
 //
-// Currently, three markup format are supported: OpenGraphProtocol, IEReadingView and SchemaOrg.
-// For now, OpenGraphProtocolParser takes precedence because it uses specific meta tags and hence
-// extracts information the fastest; it also demands conformance to rules. If the rules are broken
-// or the properties retrieved are null or empty, we try with SchemaOrg then IEReadingView.
+// Currently, seven markup format are supported: OpenGraphProtocol, SchemaOrg, JSON-LD, Twitter Card,
+// Dublin Core, Highwire citation and IEReadingView. By default, OpenGraphProtocolParser takes precedence
+// because it uses specific meta tags and hence extracts information the fastest; it also demands
+// conformance to rules. If the rules are broken or the properties retrieved are null or empty, we try
+// with SchemaOrg, JSON-LD, Twitter Card, Dublin Core, Highwire then IEReadingView. The order can be
+// changed using SetPrecedence. The properties that only exist in a specific format (e.g. DOI in
+// Highwire) are returned as is in MarkupInfo.
 //
 // The properties that matter to distilled content are:
 // - individual properties: title, page type, page url, description, publisher, author, copyright
@@ -52,38 +62,113 @@ import (
 // - article and its properties: section name, published time, modified time, expiration time,
 //   authors.
 //
-// TODO: for some properties, e.g. dominant and inline images, we might want to retrieve from
-// multiple parsers; IEReadingViewParser provides more information as it scans all images in the
-// document.  If we do so, we would need to merge the multiple versions in a meaningful way.
+// Individual properties and article properties are taken from the first parser that has them, while
+// images and structured data (e.g. recipes and events) are merged from all parsers. The duplicate
+// images are merged into one, whose missing properties are completed by the images from the parsers
+// with lower precedence.
 type Parser struct {
-	accessors []Accessor
+	accessors []sourcedAccessor
+
+	twitterCard *twittercard.Parser
+	dublinCore  *dublincore.Parser
+	highwire    *highwire.Parser
+}
+
+// sourcedAccessor is an accessor along with the markup specification it parses.
+type sourcedAccessor struct {
+	Accessor
+	source data.MarkupSource
+}
+
+// structuredDataSource is the accessor that also extracts structured data,
+// i.e. schemaorg.Parser and jsonld.Parser.
+type structuredDataSource interface {
+	StructuredData() data.StructuredData
+}
+
+// DefaultPrecedence is the order of markup sources that used by Parser by default.
+var DefaultPrecedence = []data.MarkupSource{
+	data.MarkupFromOpenGraph,
+	data.MarkupFromSchemaOrg,
+	data.MarkupFromJSONLD,
+	data.MarkupFromTwitterCard,
+	data.MarkupFromDublinCore,
+	data.MarkupFromHighwire,
+	data.MarkupFromIEReader,
 }
 
 func NewParser(root *html.Node, timingInfo *data.TimingInfo) *Parser {
 	// Initiate parser
 	ps := &Parser{}
-	ps.accessors = make([]Accessor, 0)
+	ps.accessors = make([]sourcedAccessor, 0)
 
 	// Add accessors
 	start := time.Now()
 	ogParser, err := opengraph.NewParser(root, timingInfo)
 	if err == nil && ogParser != nil {
-		ps.accessors = append(ps.accessors, ogParser)
+		ps.addAccessor(ogParser, data.MarkupFromOpenGraph)
 	}
 	timingInfo.AddEntry(start, "OpenGraphProtocolParser")
 
 	start = time.Now()
-	ps.accessors = append(ps.accessors, schemaorg.NewParser(root, timingInfo))
+	ps.addAccessor(schemaorg.NewParser(root, timingInfo), data.MarkupFromSchemaOrg)
 	timingInfo.AddEntry(start, "SchemaOrgParserAccessor")
 
+	start = time.Now()
+	ps.addAccessor(jsonld.NewParser(root, timingInfo), data.MarkupFromJSONLD)
+	timingInfo.AddEntry(start, "JsonLdParserAccessor")
+
+	start = time.Now()
+	ps.twitterCard = twittercard.NewParser(root)
+	ps.addAccessor(ps.twitterCard, data.MarkupFromTwitterCard)
+	timingInfo.AddEntry(start, "TwitterCardParserAccessor")
+
+	start = time.Now()
+	ps.dublinCore = dublincore.NewParser(root)
+	ps.addAccessor(ps.dublinCore, data.MarkupFromDublinCore)
+	timingInfo.AddEntry(start, "DublinCoreParserAccessor")
+
+	start = time.Now()
+	ps.highwire = highwire.NewParser(root)
+	ps.addAccessor(ps.highwire, data.MarkupFromHighwire)
+	timingInfo.AddEntry(start, "HighwireParserAccessor")
+
 	start = time.Now()
 	// TODO: Use eager evaluation in IEReadingViewParser, but only for profiling.
-	ps.accessors = append(ps.accessors, iereader.NewParser(root))
+	ps.addAccessor(iereader.NewParser(root), data.MarkupFromIEReader)
 	timingInfo.AddEntry(start, "SchemaOrgParserAccessor")
 
 	return ps
 }
 
+// SetPrecedence changes the order in which the markup sources are consulted. The sources
+// that not listed are consulted after the listed ones, following DefaultPrecedence.
+func (ps *Parser) SetPrecedence(sources []data.MarkupSource) {
+	ranks := make(map[data.MarkupSource]int)
+	for _, source := range sources {
+		if _, exist := ranks[source]; !exist {
+			ranks[source] = len(ranks)
+		}
+	}
+
+	for _, source := range DefaultPrecedence {
+		if _, exist := ranks[source]; !exist {
+			ranks[source] = len(ranks)
+		}
+	}
+
+	sort.SliceStable(ps.accessors, func(i, j int) bool {
+		return ranks[ps.accessors[i].source] < ranks[ps.accessors[j].source]
+	})
+}
+
+func (ps *Parser) addAccessor(accessor Accessor, source data.MarkupSource) {
+	ps.accessors = append(ps.accessors, sourcedAccessor{
+		Accessor: accessor,
+		source:   source,
+	})
+}
+
 func (ps *Parser) Title() string {
 	for _, accessor := range ps.accessors {
 		if title := accessor.Title(); title != "" {
@@ -112,13 +197,42 @@ func (ps *Parser) URL() string {
 	return ""
 }
 
+// Images returns the images from all accessors, ordered by the precedence of the
+// accessors. The images with the same URL are merged into one.
 func (ps *Parser) Images() []data.MarkupImage {
+	var images []data.MarkupImage
+	indexes := make(map[string]int)
+
 	for _, accessor := range ps.accessors {
-		if images := accessor.Images(); len(images) > 0 {
-			return images
+		for _, image := range accessor.Images() {
+			image.URL = strings.TrimSpace(image.URL)
+			image.SecureURL = strings.TrimSpace(image.SecureURL)
+			if image.URL == "" && image.SecureURL == "" {
+				continue
+			}
+
+			idx, exist := indexes[image.URL]
+			if !exist && image.SecureURL != "" {
+				idx, exist = indexes[image.SecureURL]
+			}
+
+			if exist {
+				mergeImage(&images[idx], image)
+			} else {
+				idx = len(images)
+				image.Source = accessor.source
+				images = append(images, image)
+			}
+
+			for _, url := range []string{images[idx].URL, images[idx].SecureURL} {
+				if url != "" {
+					indexes[url] = idx
+				}
+			}
 		}
 	}
-	return nil
+
+	return images
 }
 
 func (ps *Parser) Description() string {
@@ -157,13 +271,45 @@ func (ps *Parser) Author() string {
 	return ""
 }
 
+// Article returns the article properties, each of them taken from the first accessor
+// that has it. Returns nil if none of accessors has any article properties.
 func (ps *Parser) Article() *data.MarkupArticle {
+	var article data.MarkupArticle
+	var found bool
+
 	for _, accessor := range ps.accessors {
-		if article := accessor.Article(); article != nil {
-			return article
+		acArticle := accessor.Article()
+		if acArticle == nil {
+			continue
 		}
+
+		found = true
+		if article.PublishedTime == "" {
+			article.PublishedTime = acArticle.PublishedTime
+		}
+		if article.ModifiedTime == "" {
+			article.ModifiedTime = acArticle.ModifiedTime
+		}
+		if article.ExpirationTime == "" {
+			article.ExpirationTime = acArticle.ExpirationTime
+		}
+		if article.Section == "" {
+			article.Section = acArticle.Section
+		}
+		if len(article.Authors) == 0 {
+			article.Authors = append([]string{}, acArticle.Authors...)
+		}
+		if len(article.AuthorNames) == 0 {
+			article.AuthorNames = authorutil.Normalize(acArticle.Authors)
+		}
+	}
+
+	if !found {
+		return nil
 	}
-	return nil
+
+	parseArticleTimes(&article)
+	return &article
 }
 
 func (ps *Parser) OptOut() bool {
@@ -181,36 +327,156 @@ func (ps *Parser) MarkupInfo() data.MarkupInfo {
 	}
 
 	info := data.MarkupInfo{
-		Title:       ps.Title(),
-		Type:        ps.Type(),
-		URL:         ps.URL(),
-		Description: ps.Description(),
-		Publisher:   ps.Publisher(),
-		Copyright:   ps.Copyright(),
-		Author:      ps.Author(),
+		TwitterCard: ps.twitterCard.Card(),
+		DublinCore:  ps.dublinCore.DublinCore(),
+		Citation:    ps.highwire.Citation(),
+		Sources:     make(map[data.MarkupField]data.MarkupSource),
+		Candidates:  make(map[data.MarkupField][]data.MarkupCandidate),
+	}
+
+	// Collect the individual properties
+	info.Title = ps.collect(&info, data.MarkupFieldTitle, Accessor.Title)
+	info.Type = ps.collect(&info, data.MarkupFieldType, Accessor.Type)
+	info.URL = ps.collect(&info, data.MarkupFieldURL, Accessor.URL)
+	info.Description = ps.collect(&info, data.MarkupFieldDescription, Accessor.Description)
+	info.Publisher = ps.collect(&info, data.MarkupFieldPublisher, Accessor.Publisher)
+	info.Copyright = ps.collect(&info, data.MarkupFieldCopyright, Accessor.Copyright)
+	info.Author = ps.collect(&info, data.MarkupFieldAuthor, Accessor.Author)
+
+	// Collect the article properties. Article is fetched once for each accessor since
+	// some of them build it on the fly.
+	articles := make(map[Accessor]*data.MarkupArticle)
+	for _, accessor := range ps.accessors {
+		if article := accessor.Article(); article != nil {
+			articles[accessor.Accessor] = article
+		}
+	}
+
+	articleProp := func(getter func(*data.MarkupArticle) string) func(Accessor) string {
+		return func(accessor Accessor) string {
+			if article := articles[accessor]; article != nil {
+				return getter(article)
+			}
+			return ""
+		}
+	}
+
+	info.Article.PublishedTime = ps.collect(&info, data.MarkupFieldPublishedTime,
+		articleProp(func(a *data.MarkupArticle) string { return a.PublishedTime }))
+	info.Article.ModifiedTime = ps.collect(&info, data.MarkupFieldModifiedTime,
+		articleProp(func(a *data.MarkupArticle) string { return a.ModifiedTime }))
+	info.Article.ExpirationTime = ps.collect(&info, data.MarkupFieldExpirationTime,
+		articleProp(func(a *data.MarkupArticle) string { return a.ExpirationTime }))
+	info.Article.Section = ps.collect(&info, data.MarkupFieldSection,
+		articleProp(func(a *data.MarkupArticle) string { return a.Section }))
+
+	for _, accessor := range ps.accessors {
+		if article := articles[accessor.Accessor]; article != nil && len(article.Authors) > 0 {
+			info.Article.Authors = append([]string{}, article.Authors...)
+			info.Sources[data.MarkupFieldAuthors] = accessor.source
+			break
+		}
 	}
 
-	article := ps.Article()
-	if article != nil {
-		info.Article = data.MarkupArticle{
-			PublishedTime:  article.PublishedTime,
-			ModifiedTime:   article.ModifiedTime,
-			ExpirationTime: article.ExpirationTime,
-			Section:        article.Section,
-			Authors:        append([]string{}, article.Authors...),
+	// Some accessors use URL instead of name for authors, so the normalized names
+	// are taken from the first accessor whose authors are valid names. If there
+	// are none, use the single author instead.
+	for _, accessor := range ps.accessors {
+		if article := articles[accessor.Accessor]; article != nil {
+			if names := authorutil.Normalize(article.Authors); len(names) > 0 {
+				info.Article.AuthorNames = names
+				break
+			}
 		}
 	}
 
-	for _, image := range ps.Images() {
-		info.Images = append(info.Images, data.MarkupImage{
-			URL:       image.URL,
-			SecureURL: image.SecureURL,
-			Type:      image.Type,
-			Caption:   image.Caption,
-			Width:     image.Width,
-			Height:    image.Height,
-		})
+	if len(info.Article.AuthorNames) == 0 {
+		for _, candidate := range info.Candidates[data.MarkupFieldAuthor] {
+			if names := authorutil.Split(candidate.Value); len(names) > 0 {
+				info.Article.AuthorNames = names
+				break
+			}
+		}
 	}
 
+	parseArticleTimes(&info.Article)
+
+	info.Images = ps.Images()
 	return info
 }
+
+// collect puts the non-empty values of the field from all accessors into the candidates
+// of MarkupInfo, then returns the value with the highest precedence.
+func (ps *Parser) collect(info *data.MarkupInfo, field data.MarkupField, getter func(Accessor) string) string {
+	for _, accessor := range ps.accessors {
+		if value := getter(accessor.Accessor); value != "" {
+			info.Candidates[field] = append(info.Candidates[field], data.MarkupCandidate{
+				Value:  value,
+				Source: accessor.source,
+			})
+		}
+	}
+
+	candidates := info.Candidates[field]
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	info.Sources[field] = candidates[0].Source
+	return candidates[0].Value
+}
+
+// StructuredData returns the typed schema.org items that declared using microdata
+// and JSON-LD, ordered by the precedence of their source.
+func (ps *Parser) StructuredData() data.StructuredData {
+	var sd data.StructuredData
+	if ps.OptOut() {
+		return sd
+	}
+
+	for _, accessor := range ps.accessors {
+		source, ok := accessor.Accessor.(structuredDataSource)
+		if !ok {
+			continue
+		}
+
+		items := source.StructuredData()
+		sd.Videos = append(sd.Videos, items.Videos...)
+		sd.Audios = append(sd.Audios, items.Audios...)
+		sd.Recipes = append(sd.Recipes, items.Recipes...)
+		sd.Events = append(sd.Events, items.Events...)
+		sd.Products = append(sd.Products, items.Products...)
+		sd.Reviews = append(sd.Reviews, items.Reviews...)
+		sd.FAQs = append(sd.FAQs, items.FAQs...)
+		sd.HowTos = append(sd.HowTos, items.HowTos...)
+	}
+
+	return sd
+}
+
+// parseArticleTimes parses the raw times of the article.
+func parseArticleTimes(article *data.MarkupArticle) {
+	article.Published, _ = timeutil.ParseOrFind(article.PublishedTime)
+	article.Modified, _ = timeutil.ParseOrFind(article.ModifiedTime)
+	article.Expiration, _ = timeutil.ParseOrFind(article.ExpirationTime)
+}
+
+// mergeImage fills the empty properties of the image using the duplicate image.
+func mergeImage(image *data.MarkupImage, duplicate data.MarkupImage) {
+	if image.URL == "" {
+		image.URL = duplicate.URL
+	}
+	if image.SecureURL == "" {
+		image.SecureURL = duplicate.SecureURL
+	}
+	if image.Type == "" {
+		image.Type = duplicate.Type
+	}
+	if image.Caption == "" {
+		image.Caption = duplicate.Caption
+	}
+	if image.Width == 0 && image.Height == 0 {
+		image.Width = duplicate.Width
+		image.Height = duplicate.Height
+	}
+}
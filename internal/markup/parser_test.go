@@ -28,8 +28,10 @@ package markup_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
 	"github.com/markusmobius/go-domdistiller/internal/markup"
 	"github.com/markusmobius/go-domdistiller/internal/testutil"
 	"github.com/stretchr/testify/assert"
@@ -163,3 +165,225 @@ func createMeta(doc *html.Node, property, content string) {
 		dom.AppendChild(head, meta)
 	}
 }
+
+func Test_Markup_JsonLdOnly(t *testing.T) {
+	doc := testutil.CreateHTML()
+	head := dom.QuerySelector(doc, "head")
+
+	script := dom.CreateElement("script")
+	dom.SetAttribute(script, "type", "application/ld+json")
+	dom.SetTextContent(script, `{
+		"@context": "https://schema.org",
+		"@type": "NewsArticle",
+		"headline": "JSON-LD title",
+		"datePublished": "2021-03-01T10:00:00Z",
+		"author": [{"@type": "Person", "name": "Jane Doe"}, {"@type": "Person", "name": "John Doe"}],
+		"publisher": {"@type": "Organization", "name": "Dummy Times"},
+		"image": "http://dummy/image.jpeg"
+	}`)
+	dom.AppendChild(head, script)
+
+	parser := markup.NewParser(doc, nil)
+	markupInfo := parser.MarkupInfo()
+	assert.Equal(t, "JSON-LD title", markupInfo.Title)
+	assert.Equal(t, "Article", markupInfo.Type)
+	assert.Equal(t, "Dummy Times", markupInfo.Publisher)
+	assert.Equal(t, "Jane Doe", markupInfo.Author)
+	assert.Equal(t, "2021-03-01T10:00:00Z", markupInfo.Article.PublishedTime)
+	assert.Equal(t, []string{"Jane Doe", "John Doe"}, markupInfo.Article.Authors)
+	assert.Equal(t, 1, len(markupInfo.Images))
+	assert.Equal(t, "http://dummy/image.jpeg", markupInfo.Images[0].URL)
+}
+
+func Test_Markup_MetaTagsFallback(t *testing.T) {
+	doc := testutil.CreateHTML()
+	head := dom.QuerySelector(doc, "head")
+	for _, pair := range [][2]string{
+		{"twitter:card", "summary"},
+		{"twitter:title", "Twitter title"},
+		{"twitter:image", "http://dummy/card.jpeg"},
+		{"DC.title", "Dublin Core title"},
+		{"DC.creator", "Jane Doe"},
+		{"DC.publisher", "Dummy Press"},
+		{"citation_title", "Citation title"},
+		{"citation_author", "Doe, Jane"},
+		{"citation_doi", "10.1000/xyz123"},
+		{"citation_publication_date", "2018/03/15"},
+	} {
+		meta := dom.CreateElement("meta")
+		dom.SetAttribute(meta, "name", pair[0])
+		dom.SetAttribute(meta, "content", pair[1])
+		dom.AppendChild(head, meta)
+	}
+
+	parser := markup.NewParser(doc, nil)
+	markupInfo := parser.MarkupInfo()
+
+	// Twitter Card is checked before Dublin Core and Highwire.
+	assert.Equal(t, "Twitter title", markupInfo.Title)
+	assert.Equal(t, "Jane Doe", markupInfo.Author)
+	assert.Equal(t, "Dummy Press", markupInfo.Publisher)
+	assert.Equal(t, []string{"Jane Doe"}, markupInfo.Article.Authors)
+	assert.Equal(t, 1, len(markupInfo.Images))
+	assert.Equal(t, "http://dummy/card.jpeg", markupInfo.Images[0].URL)
+
+	// Format-specific properties are kept as is.
+	assert.Equal(t, "summary", markupInfo.TwitterCard.Card)
+	assert.Equal(t, "Dublin Core title", markupInfo.DublinCore.Title)
+	assert.Equal(t, "Citation title", markupInfo.Citation.Title)
+	assert.Equal(t, "10.1000/xyz123", markupInfo.Citation.DOI)
+	assert.Equal(t, "2018/03/15", markupInfo.Citation.PublicationDate)
+}
+
+func Test_Markup_Provenance(t *testing.T) {
+	doc := testutil.CreateHTML()
+	createDefaultOGTitle(doc)
+	createDefaultOGUrl(doc)
+	createDefaultOGType(doc)
+	createDefaultOGImage(doc)
+	appendNamedMeta(doc, "twitter:title", "Twitter title")
+	appendNamedMeta(doc, "twitter:description", "Twitter description")
+	appendNamedMeta(doc, "DC.title", "Dublin Core title")
+
+	parser := markup.NewParser(doc, nil)
+	markupInfo := parser.MarkupInfo()
+	assert.Equal(t, "dummy title", markupInfo.Title)
+	assert.Equal(t, data.MarkupFromOpenGraph, markupInfo.Sources[data.MarkupFieldTitle])
+	assert.Equal(t, data.MarkupFromTwitterCard, markupInfo.Sources[data.MarkupFieldDescription])
+	assert.Equal(t, []data.MarkupCandidate{
+		{Value: "dummy title", Source: data.MarkupFromOpenGraph},
+		{Value: "Twitter title", Source: data.MarkupFromTwitterCard},
+		{Value: "Dublin Core title", Source: data.MarkupFromDublinCore},
+	}, markupInfo.Candidates[data.MarkupFieldTitle])
+
+	_, exist := markupInfo.Sources[data.MarkupFieldCopyright]
+	assert.False(t, exist)
+}
+
+func Test_Markup_Precedence(t *testing.T) {
+	doc := testutil.CreateHTML()
+	createDefaultOGTitle(doc)
+	createDefaultOGUrl(doc)
+	createDefaultOGType(doc)
+	createDefaultOGImage(doc)
+	appendNamedMeta(doc, "twitter:title", "Twitter title")
+	appendNamedMeta(doc, "DC.title", "Dublin Core title")
+	appendNamedMeta(doc, "DC.publisher", "Dummy Press")
+
+	parser := markup.NewParser(doc, nil)
+	parser.SetPrecedence([]data.MarkupSource{data.MarkupFromDublinCore, data.MarkupFromTwitterCard})
+
+	markupInfo := parser.MarkupInfo()
+	assert.Equal(t, "Dublin Core title", markupInfo.Title)
+	assert.Equal(t, data.MarkupFromDublinCore, markupInfo.Sources[data.MarkupFieldTitle])
+	assert.Equal(t, "Dummy Press", markupInfo.Publisher)
+
+	// Sources that are not listed still work as fallback.
+	assert.Equal(t, "http://dummy/url.html", markupInfo.URL)
+	assert.Equal(t, data.MarkupFromOpenGraph, markupInfo.Sources[data.MarkupFieldURL])
+	assert.Equal(t, 3, len(markupInfo.Candidates[data.MarkupFieldTitle]))
+	assert.Equal(t, data.MarkupFromOpenGraph, markupInfo.Candidates[data.MarkupFieldTitle][2].Source)
+}
+
+func Test_Markup_MergedImages(t *testing.T) {
+	doc := testutil.CreateHTML()
+	createDefaultOGTitle(doc)
+	createDefaultOGUrl(doc)
+	createDefaultOGType(doc)
+	createMeta(doc, "og:image", "http://dummy/image.jpeg")
+	createMeta(doc, "og:image:secure_url", "https://dummy/image.jpeg")
+	appendNamedMeta(doc, "twitter:image", "https://dummy/image.jpeg")
+	appendNamedMeta(doc, "twitter:image:alt", "Image caption")
+	appendNamedMeta(doc, "twitter:image:src", "http://dummy/other.jpeg")
+
+	head := dom.QuerySelector(doc, "head")
+	script := dom.CreateElement("script")
+	dom.SetAttribute(script, "type", "application/ld+json")
+	dom.SetTextContent(script, `{
+		"@type": "NewsArticle",
+		"headline": "JSON-LD title",
+		"image": ["http://dummy/image.jpeg", "http://dummy/second.jpeg"]
+	}`)
+	dom.AppendChild(head, script)
+
+	parser := markup.NewParser(doc, nil)
+	images := parser.MarkupInfo().Images
+	assert.Equal(t, 2, len(images))
+
+	// The duplicate images are merged, and their missing properties completed.
+	assert.Equal(t, "http://dummy/image.jpeg", images[0].URL)
+	assert.Equal(t, "https://dummy/image.jpeg", images[0].SecureURL)
+	assert.Equal(t, "Image caption", images[0].Caption)
+	assert.Equal(t, data.MarkupFromOpenGraph, images[0].Source)
+
+	assert.Equal(t, "http://dummy/second.jpeg", images[1].URL)
+	assert.Equal(t, data.MarkupFromJSONLD, images[1].Source)
+}
+
+func appendNamedMeta(doc *html.Node, name, content string) {
+	meta := dom.CreateElement("meta")
+	dom.SetAttribute(meta, "name", name)
+	dom.SetAttribute(meta, "content", content)
+	dom.AppendChild(dom.QuerySelector(doc, "head"), meta)
+}
+
+func Test_Markup_StructuredData(t *testing.T) {
+	doc := testutil.CreateHTML()
+	body := dom.QuerySelector(doc, "body")
+	dom.SetInnerHTML(body, `<div itemscope itemtype="https://schema.org/Recipe">`+
+		`<span itemprop="name">Microdata recipe</span></div>`)
+
+	head := dom.QuerySelector(doc, "head")
+	script := dom.CreateElement("script")
+	dom.SetAttribute(script, "type", "application/ld+json")
+	dom.SetTextContent(script, `{"@type": "Recipe", "name": "JSON-LD recipe"}`)
+	dom.AppendChild(head, script)
+
+	parser := markup.NewParser(doc, nil)
+	sd := parser.StructuredData()
+	assert.Equal(t, 2, len(sd.Recipes))
+	assert.Equal(t, "Microdata recipe", sd.Recipes[0].Name)
+	assert.Equal(t, "JSON-LD recipe", sd.Recipes[1].Name)
+
+	parser.SetPrecedence([]data.MarkupSource{data.MarkupFromJSONLD})
+	sd = parser.StructuredData()
+	assert.Equal(t, "JSON-LD recipe", sd.Recipes[0].Name)
+}
+
+func Test_Markup_ParsedArticle(t *testing.T) {
+	doc := testutil.CreateHTML()
+	createDefaultOGTitle(doc)
+	createDefaultOGUrl(doc)
+	createDefaultOGImage(doc)
+	createMeta(doc, "og:type", "article")
+	createMeta(doc, "article:published_time", "2014-04-01T01:23:59+07:00")
+	createMeta(doc, "article:modified_time", "1396400639")
+	createMeta(doc, "article:author", "http://blah/author1.html")
+
+	head := dom.QuerySelector(doc, "head")
+	script := dom.CreateElement("script")
+	dom.SetAttribute(script, "type", "application/ld+json")
+	dom.SetTextContent(script, `{
+		"@type": "NewsArticle",
+		"headline": "JSON-LD title",
+		"expires": "Thu, 03 Apr 2014 03:23:59 EST",
+		"author": ["By Jane Doe and John Smith", "jane doe", "jane@example.com"]
+	}`)
+	dom.AppendChild(head, script)
+
+	parser := markup.NewParser(doc, nil)
+	article := parser.MarkupInfo().Article
+
+	// Raw values are kept as they are
+	assert.Equal(t, "2014-04-01T01:23:59+07:00", article.PublishedTime)
+	assert.Equal(t, []string{"http://blah/author1.html"}, article.Authors)
+
+	// Parsed times
+	jakarta := time.FixedZone("", 7*60*60)
+	assert.True(t, time.Date(2014, 4, 1, 1, 23, 59, 0, jakarta).Equal(article.Published))
+	assert.True(t, time.Date(2014, 4, 2, 1, 3, 59, 0, time.UTC).Equal(article.Modified))
+	assert.True(t, time.Date(2014, 4, 3, 8, 23, 59, 0, time.UTC).Equal(article.Expiration))
+
+	// OpenGraph only has profile URL, so the names are taken from JSON-LD
+	assert.Equal(t, []string{"Jane Doe", "John Smith"}, article.AuthorNames)
+}
@@ -52,6 +52,77 @@ const (
 	GivenNameProp       = "givenName"
 	LegalNameProp       = "legalName"
 	AuthorRel           = "author"
+
+	// Media properties, used by VideoObject and AudioObject
+	ThumbnailURLProp = "thumbnailUrl"
+	EmbedURLProp     = "embedUrl"
+	UploadDateProp   = "uploadDate"
+	DurationProp     = "duration"
+
+	// Recipe properties
+	PrepTimeProp           = "prepTime"
+	CookTimeProp           = "cookTime"
+	TotalTimeProp          = "totalTime"
+	RecipeYieldProp        = "recipeYield"
+	RecipeCategoryProp     = "recipeCategory"
+	RecipeCuisineProp      = "recipeCuisine"
+	RecipeIngredientProp   = "recipeIngredient"
+	IngredientsProp        = "ingredients"
+	RecipeInstructionsProp = "recipeInstructions"
+	NutritionProp          = "nutrition"
+	CaloriesProp           = "calories"
+
+	// Event properties
+	StartDateProp           = "startDate"
+	EndDateProp             = "endDate"
+	EventStatusProp         = "eventStatus"
+	EventAttendanceModeProp = "eventAttendanceMode"
+	LocationProp            = "location"
+	OrganizerProp           = "organizer"
+	PerformerProp           = "performer"
+	AddressProp             = "address"
+	StreetAddressProp       = "streetAddress"
+	AddressLocalityProp     = "addressLocality"
+	AddressRegionProp       = "addressRegion"
+	PostalCodeProp          = "postalCode"
+	AddressCountryProp      = "addressCountry"
+
+	// Product, offer, review and rating properties
+	BrandProp           = "brand"
+	SKUProp             = "sku"
+	GTINProp            = "gtin"
+	GTIN8Prop           = "gtin8"
+	GTIN12Prop          = "gtin12"
+	GTIN13Prop          = "gtin13"
+	GTIN14Prop          = "gtin14"
+	MPNProp             = "mpn"
+	OffersProp          = "offers"
+	PriceProp           = "price"
+	LowPriceProp        = "lowPrice"
+	HighPriceProp       = "highPrice"
+	PriceCurrencyProp   = "priceCurrency"
+	AvailabilityProp    = "availability"
+	SellerProp          = "seller"
+	ValidFromProp       = "validFrom"
+	ReviewProp          = "review"
+	ReviewBodyProp      = "reviewBody"
+	ItemReviewedProp    = "itemReviewed"
+	ReviewRatingProp    = "reviewRating"
+	AggregateRatingProp = "aggregateRating"
+	RatingValueProp     = "ratingValue"
+	BestRatingProp      = "bestRating"
+	WorstRatingProp     = "worstRating"
+	RatingCountProp     = "ratingCount"
+	ReviewCountProp     = "reviewCount"
+
+	// FAQ and how-to properties
+	MainEntityProp      = "mainEntity"
+	AcceptedAnswerProp  = "acceptedAnswer"
+	SuggestedAnswerProp = "suggestedAnswer"
+	TextProp            = "text"
+	SupplyProp          = "supply"
+	ToolProp            = "tool"
+	StepProp            = "step"
 )
 
 type SchemaType uint
@@ -62,21 +133,82 @@ const (
 	Article
 	Person
 	Organization
+	Video
+	Audio
+	Recipe
+	Nutrition
+	Event
+	Place
+	PostalAddress
+	Product
+	Offer
+	Review
+	Rating
+	FAQPage
+	Question
+	Answer
+	HowTo
+	HowToStep
+	Thing
 )
 
-var schemaTypeURLs = map[string]SchemaType{
-	"http://schema.org/ImageObject":             Image,
-	"http://schema.org/Article":                 Article,
-	"http://schema.org/BlogPosting":             Article,
-	"http://schema.org/NewsArticle":             Article,
-	"http://schema.org/ScholarlyArticle":        Article,
-	"http://schema.org/TechArticle":             Article,
-	"http://schema.org/Person":                  Person,
-	"http://schema.org/Organization":            Organization,
-	"http://schema.org/Corporation":             Organization,
-	"http://schema.org/EducationalOrganization": Organization,
-	"http://schema.org/GovernmentOrganization":  Organization,
-	"http://schema.org/NGO":                     Organization,
+// schemaTypePrefixes is the prefixes of schema.org type URL. The type could be
+// written with "http://" or "https://" scheme, or even without any prefix.
+var schemaTypePrefixes = []string{
+	"http://schema.org/",
+	"https://schema.org/",
+	"http://www.schema.org/",
+	"https://www.schema.org/",
+	"schema:",
+}
+
+// schemaTypes maps the name of schema.org type, i.e. the value of "itemtype" after
+// its prefix is removed, into the type that we support.
+var schemaTypes = map[string]SchemaType{
+	"ImageObject":             Image,
+	"Article":                 Article,
+	"BlogPosting":             Article,
+	"NewsArticle":             Article,
+	"ScholarlyArticle":        Article,
+	"TechArticle":             Article,
+	"Person":                  Person,
+	"Organization":            Organization,
+	"Corporation":             Organization,
+	"EducationalOrganization": Organization,
+	"GovernmentOrganization":  Organization,
+	"NGO":                     Organization,
+	"VideoObject":             Video,
+	"AudioObject":             Audio,
+	"PodcastEpisode":          Audio,
+	"Recipe":                  Recipe,
+	"NutritionInformation":    Nutrition,
+	"Event":                   Event,
+	"BusinessEvent":           Event,
+	"ComedyEvent":             Event,
+	"EducationEvent":          Event,
+	"ExhibitionEvent":         Event,
+	"Festival":                Event,
+	"MusicEvent":              Event,
+	"SportsEvent":             Event,
+	"TheaterEvent":            Event,
+	"Place":                   Place,
+	"VirtualLocation":         Place,
+	"PostalAddress":           PostalAddress,
+	"Product":                 Product,
+	"Offer":                   Offer,
+	"AggregateOffer":          Offer,
+	"Review":                  Review,
+	"Rating":                  Rating,
+	"AggregateRating":         Rating,
+	"FAQPage":                 FAQPage,
+	"Question":                Question,
+	"Answer":                  Answer,
+	"HowTo":                   HowTo,
+	"HowToStep":               HowToStep,
+	"HowToDirection":          HowToStep,
+	"Brand":                   Thing,
+	"HowToSupply":             Thing,
+	"HowToTool":               Thing,
 }
 
 // The key for `tagAttributeMap` is the tag name, while the entry value is an
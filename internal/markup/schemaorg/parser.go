@@ -48,11 +48,16 @@ import (
 // - Person: family name, given name
 // - Organization: legal name.
 //
+// The other supported types are only used for the structured data, i.e. VideoObject, AudioObject,
+// Recipe, Event, Product, Offer, Review, Rating, FAQPage and HowTo, along with the types that used
+// as their properties like Place, PostalAddress, Question, Answer and HowToStep.
+//
 // The value of a Schema.Org property can be a Schema.Org type, i.e. embedded. E.g., the author or
 // publisher of article or publisher of image could be a Schema.Org Person or Organization type;
 // in fact, this is the reason we support Person and Organization types.
 type Parser struct {
 	itemScopes    []ThingItem
+	topItems      []ThingItem
 	itemElement   map[*html.Node]ThingItem
 	authorFromRel string
 }
@@ -104,10 +109,22 @@ func (ps *Parser) parseElement(element *html.Node, parentItem ThingItem) {
 		//    parent, based on the rule that an item is a top-level item if its element doesn't
 		//    have an itemprop attribute.
 		newItem = ps.createItemForElement(element)
-		if newItem != nil && newItem.isSupported() &&
-			(parentItem == nil || parentItem.isSupported() || len(propertyNames) == 0) {
-			ps.itemScopes = append(ps.itemScopes, newItem)
-			ps.itemElement[element] = newItem
+		if newItem != nil && newItem.isSupported() {
+			isItemScope := parentItem == nil || parentItem.isSupported() || len(propertyNames) == 0
+			if isItemScope {
+				ps.itemScopes = append(ps.itemScopes, newItem)
+			}
+
+			// For structured data, the item is top-level if it's not a property of
+			// another supported item, e.g. a recipe as "mainEntity" of a WebPage.
+			isTopItem := parentItem == nil || !parentItem.isSupported() || len(propertyNames) == 0
+			if isTopItem {
+				ps.topItems = append(ps.topItems, newItem)
+			}
+
+			if isItemScope || isTopItem {
+				ps.itemElement[element] = newItem
+			}
 		}
 	}
 
@@ -158,6 +175,38 @@ func (ps *Parser) createItemForElement(element *html.Node) ThingItem {
 		return NewPersonItem(element)
 	case Organization:
 		return NewOrganizationItem(element)
+	case Video, Audio:
+		return NewMediaItem(ps.getItemType(element), element)
+	case Recipe:
+		return NewRecipeItem(element)
+	case Nutrition:
+		return NewNutritionItem(element)
+	case Event:
+		return NewEventItem(element)
+	case Place:
+		return NewPlaceItem(element)
+	case PostalAddress:
+		return NewPostalAddressItem(element)
+	case Product:
+		return NewProductItem(element)
+	case Offer:
+		return NewOfferItem(element)
+	case Review:
+		return NewReviewItem(element)
+	case Rating:
+		return NewRatingItem(element)
+	case FAQPage:
+		return NewFAQPageItem(element)
+	case Question:
+		return NewQuestionItem(element)
+	case Answer:
+		return NewAnswerItem(element)
+	case HowTo:
+		return NewHowToItem(element)
+	case HowToStep:
+		return NewHowToStepItem(element)
+	case Thing:
+		return NewGenericItem(element)
 	case Unsupported:
 		return NewUnsupportedItem(element)
 	default:
@@ -179,9 +228,21 @@ func (ps *Parser) getItemProp(element *html.Node) []string {
 	return strings.Fields(itemProp)
 }
 
+// getItemType returns the first supported type in "itemtype" attribute. The type might
+// be written as full URL (with either "http" or "https" scheme) or only its name.
 func (ps *Parser) getItemType(element *html.Node) SchemaType {
-	schemaType := dom.GetAttribute(element, "itemtype")
-	return schemaTypeURLs[schemaType]
+	itemTypes := strings.Fields(dom.GetAttribute(element, "itemtype"))
+	for _, itemType := range itemTypes {
+		for _, prefix := range schemaTypePrefixes {
+			itemType = strings.TrimPrefix(itemType, prefix)
+		}
+
+		itemType = strings.TrimSuffix(itemType, "/")
+		if schemaType, exist := schemaTypes[itemType]; exist {
+			return schemaType
+		}
+	}
+	return Unsupported
 }
 
 // Extracts the property value from `element`. For some tags, the value
@@ -0,0 +1,52 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package schemaorg
+
+import "github.com/markusmobius/go-domdistiller/data"
+
+// StructuredData returns the top-level items of the types that matter to the
+// structured data, e.g. recipes and events, in the order they are declared.
+func (ps *Parser) StructuredData() data.StructuredData {
+	var sd data.StructuredData
+	for _, item := range ps.topItems {
+		switch v := item.(type) {
+		case *MediaItem:
+			if v.getType() == Video {
+				sd.Videos = append(sd.Videos, v.getVideo())
+			} else {
+				sd.Audios = append(sd.Audios, v.getAudio())
+			}
+		case *RecipeItem:
+			sd.Recipes = append(sd.Recipes, v.getRecipe())
+		case *EventItem:
+			sd.Events = append(sd.Events, v.getEvent())
+		case *ProductItem:
+			sd.Products = append(sd.Products, v.getProduct())
+		case *ReviewItem:
+			sd.Reviews = append(sd.Reviews, v.getReview())
+		case *FAQPageItem:
+			sd.FAQs = append(sd.FAQs, v.getFAQPage())
+		case *HowToItem:
+			sd.HowTos = append(sd.HowTos, v.getHowTo())
+		}
+	}
+	return sd
+}
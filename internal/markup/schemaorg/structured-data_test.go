@@ -0,0 +1,225 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package schemaorg_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/markup/schemaorg"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func Test_SchemaOrg_StructuredData_Recipe(t *testing.T) {
+	doc := createDocWithMicrodata(`
+		<div itemscope itemtype="WebPage">
+			<div itemprop="mainEntity" itemscope itemtype="https://schema.org/Recipe">
+				<h1 itemprop="name">Pancakes</h1>
+				<span itemprop="author" itemscope itemtype="https://schema.org/Person">
+					<span itemprop="name">Jane Doe</span>
+				</span>
+				<img itemprop="image" src="http://dummy/pancakes.jpeg">
+				<meta itemprop="prepTime" content="PT10M">
+				<meta itemprop="cookTime" content="PT20M">
+				<span itemprop="recipeYield">4 servings</span>
+				<ul>
+					<li itemprop="recipeIngredient">2 eggs</li>
+					<li itemprop="recipeIngredient">1 cup flour</li>
+				</ul>
+				<ol>
+					<li itemprop="recipeInstructions" itemscope itemtype="HowToStep">
+						<span itemprop="text">Mix everything.</span>
+					</li>
+					<li itemprop="recipeInstructions" itemscope itemtype="HowToStep">
+						<span itemprop="text">Fry it.</span>
+					</li>
+				</ol>
+				<div itemprop="nutrition" itemscope itemtype="https://schema.org/NutritionInformation">
+					<span itemprop="calories">250 calories</span>
+				</div>
+				<div itemprop="aggregateRating" itemscope itemtype="https://schema.org/AggregateRating">
+					<span itemprop="ratingValue">4.5</span>
+					<span itemprop="ratingCount">12</span>
+				</div>
+			</div>
+		</div>`)
+
+	sd := schemaorg.NewParser(doc, nil).StructuredData()
+	assert.Equal(t, 1, len(sd.Recipes))
+
+	recipe := sd.Recipes[0]
+	assert.Equal(t, "Pancakes", recipe.Name)
+	assert.Equal(t, "Jane Doe", recipe.Author)
+	assert.Equal(t, "http://dummy/pancakes.jpeg", recipe.Image)
+	assert.Equal(t, "PT10M", recipe.PrepTime)
+	assert.Equal(t, "PT20M", recipe.CookTime)
+	assert.Equal(t, "4 servings", recipe.Yield)
+	assert.Equal(t, "250 calories", recipe.Calories)
+	assert.Equal(t, []string{"2 eggs", "1 cup flour"}, recipe.Ingredients)
+	assert.Equal(t, []string{"Mix everything.", "Fry it."}, recipe.Instructions)
+	assert.Equal(t, &data.Rating{Value: "4.5", RatingCount: "12"}, recipe.Rating)
+}
+
+func Test_SchemaOrg_StructuredData_EventAndProduct(t *testing.T) {
+	doc := createDocWithMicrodata(`
+		<div itemscope itemtype="http://schema.org/MusicEvent">
+			<span itemprop="name">Summer Concert</span>
+			<time itemprop="startDate" datetime="2023-07-01T19:00">July 1</time>
+			<div itemprop="location" itemscope itemtype="http://schema.org/Place">
+				<span itemprop="name">City Hall</span>
+				<div itemprop="address" itemscope itemtype="http://schema.org/PostalAddress">
+					<span itemprop="streetAddress">1 Main St</span>
+					<span itemprop="addressLocality">Springfield</span>
+				</div>
+			</div>
+			<div itemprop="offers" itemscope itemtype="http://schema.org/Offer">
+				<meta itemprop="price" content="25.00">
+				<meta itemprop="priceCurrency" content="USD">
+			</div>
+		</div>
+		<div itemscope itemtype="schema:Product">
+			<span itemprop="name">Dummy Phone</span>
+			<div itemprop="brand" itemscope itemtype="Brand"><span itemprop="name">Dummy</span></div>
+			<meta itemprop="sku" content="DP-1">
+			<meta itemprop="gtin13" content="0000000000001">
+			<div itemprop="offers" itemscope itemtype="AggregateOffer">
+				<meta itemprop="lowPrice" content="199">
+				<meta itemprop="highPrice" content="299">
+			</div>
+			<div itemprop="review" itemscope itemtype="Review">
+				<span itemprop="author">John Smith</span>
+				<span itemprop="reviewBody">Works well.</span>
+				<div itemprop="reviewRating" itemscope itemtype="Rating">
+					<meta itemprop="ratingValue" content="5">
+				</div>
+			</div>
+		</div>`)
+
+	sd := schemaorg.NewParser(doc, nil).StructuredData()
+	assert.Equal(t, 1, len(sd.Events))
+	assert.Equal(t, 1, len(sd.Products))
+
+	// Nested items are not listed as top-level items.
+	assert.Equal(t, 0, len(sd.Reviews))
+
+	event := sd.Events[0]
+	assert.Equal(t, "Summer Concert", event.Name)
+	assert.Equal(t, "2023-07-01T19:00", event.StartDate)
+	assert.Equal(t, "City Hall", event.Location)
+	assert.Equal(t, "1 Main St, Springfield", event.Address)
+	assert.Equal(t, []data.Offer{{Price: "25.00", PriceCurrency: "USD"}}, event.Offers)
+
+	product := sd.Products[0]
+	assert.Equal(t, "Dummy Phone", product.Name)
+	assert.Equal(t, "Dummy", product.Brand)
+	assert.Equal(t, "DP-1", product.SKU)
+	assert.Equal(t, "0000000000001", product.GTIN)
+	assert.Equal(t, []data.Offer{{Price: "199", HighPrice: "299"}}, product.Offers)
+	assert.Equal(t, 1, len(product.Reviews))
+	assert.Equal(t, "John Smith", product.Reviews[0].Author)
+	assert.Equal(t, "Works well.", product.Reviews[0].Body)
+	assert.Equal(t, "5", product.Reviews[0].Rating.Value)
+}
+
+func Test_SchemaOrg_StructuredData_FAQAndHowTo(t *testing.T) {
+	doc := createDocWithMicrodata(`
+		<div itemscope itemtype="https://schema.org/FAQPage">
+			<div itemprop="mainEntity" itemscope itemtype="https://schema.org/Question">
+				<h3 itemprop="name">What is it?</h3>
+				<div itemprop="acceptedAnswer" itemscope itemtype="https://schema.org/Answer">
+					<p itemprop="text">A dummy.</p>
+				</div>
+			</div>
+			<div itemprop="mainEntity" itemscope itemtype="https://schema.org/Question">
+				<h3 itemprop="name">Is it free?</h3>
+				<div itemprop="acceptedAnswer" itemscope itemtype="https://schema.org/Answer">
+					<p itemprop="text">Yes.</p>
+				</div>
+			</div>
+		</div>
+		<div itemscope itemtype="https://schema.org/HowTo">
+			<h2 itemprop="name">Change a tire</h2>
+			<meta itemprop="totalTime" content="PT30M">
+			<span itemprop="tool" itemscope itemtype="https://schema.org/HowToTool">
+				<span itemprop="name">Jack</span>
+			</span>
+			<span itemprop="supply">Spare tire</span>
+			<div itemprop="step" itemscope itemtype="https://schema.org/HowToStep">
+				<span itemprop="name">Loosen</span>
+				<span itemprop="text">Loosen the nuts.</span>
+			</div>
+		</div>
+		<div itemscope itemtype="https://schema.org/VideoObject">
+			<span itemprop="name">Tire video</span>
+			<meta itemprop="duration" content="PT2M">
+			<link itemprop="embedUrl" href="https://video.dummy/embed/1">
+		</div>`)
+
+	sd := schemaorg.NewParser(doc, nil).StructuredData()
+	assert.Equal(t, []data.FAQPage{{Questions: []data.Question{
+		{Question: "What is it?", Answer: "A dummy."},
+		{Question: "Is it free?", Answer: "Yes."},
+	}}}, sd.FAQs)
+
+	assert.Equal(t, 1, len(sd.HowTos))
+	howTo := sd.HowTos[0]
+	assert.Equal(t, "Change a tire", howTo.Name)
+	assert.Equal(t, "PT30M", howTo.TotalTime)
+	assert.Equal(t, []string{"Jack"}, howTo.Tools)
+	assert.Equal(t, []string{"Spare tire"}, howTo.Supplies)
+	assert.Equal(t, []data.HowToStep{{Name: "Loosen", Text: "Loosen the nuts."}}, howTo.Steps)
+
+	assert.Equal(t, []data.VideoObject{{
+		Name:     "Tire video",
+		EmbedURL: "https://video.dummy/embed/1",
+		Duration: "PT2M",
+	}}, sd.Videos)
+}
+
+func Test_SchemaOrg_TypeWithoutPrefix(t *testing.T) {
+	doc := createDocWithMicrodata(`
+		<div itemscope itemtype="https://schema.org/NewsArticle">
+			<h1 itemprop="headline">HTTPS article</h1>
+		</div>`)
+
+	parser := schemaorg.NewParser(doc, nil)
+	assert.Equal(t, "Article", parser.Type())
+	assert.Equal(t, "HTTPS article", parser.Title())
+
+	doc = createDocWithMicrodata(`
+		<div itemscope itemtype="BlogPosting">
+			<h1 itemprop="headline">Bare article</h1>
+		</div>`)
+
+	parser = schemaorg.NewParser(doc, nil)
+	assert.Equal(t, "Article", parser.Type())
+	assert.Equal(t, "Bare article", parser.Title())
+}
+
+func createDocWithMicrodata(htmlStr string) *html.Node {
+	doc := testutil.CreateHTML()
+	body := dom.QuerySelector(doc, "body")
+	dom.SetInnerHTML(body, htmlStr)
+	return doc
+}
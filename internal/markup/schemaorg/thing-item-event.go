@@ -0,0 +1,126 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package schemaorg
+
+import (
+	"strings"
+
+	"github.com/markusmobius/go-domdistiller/data"
+	"golang.org/x/net/html"
+)
+
+type EventItem struct {
+	BaseThingItem
+}
+
+func NewEventItem(element *html.Node) *EventItem {
+	item := &EventItem{}
+	item.init(Event, element)
+	item.addStringPropertyName(StartDateProp)
+	item.addStringPropertyName(EndDateProp)
+	item.addStringPropertyName(EventStatusProp)
+	item.addStringPropertyName(EventAttendanceModeProp)
+	item.addStringPropertyName(LocationProp)
+	item.addStringPropertyName(OrganizerProp)
+	item.addStringListPropertyName(PerformerProp)
+
+	item.addItemPropertyName(ImageProp)
+	item.addItemPropertyName(LocationProp)
+	item.addItemPropertyName(OrganizerProp)
+	item.addItemListPropertyName(PerformerProp)
+	item.addItemListPropertyName(OffersProp)
+	return item
+}
+
+func (ei *EventItem) getEvent() data.Event {
+	var address string
+	if placeItem, ok := ei.getItemProperty(LocationProp).(*PlaceItem); ok {
+		address = placeItem.getAddress()
+	}
+
+	return data.Event{
+		Name:           ei.getStringProperty(NameProp),
+		Description:    ei.getStringProperty(DescriptionProp),
+		Image:          ei.getImageProperty(ImageProp),
+		URL:            ei.getStringProperty(URLProp),
+		StartDate:      ei.getStringProperty(StartDateProp),
+		EndDate:        ei.getStringProperty(EndDateProp),
+		Status:         ei.getStringProperty(EventStatusProp),
+		AttendanceMode: ei.getStringProperty(EventAttendanceModeProp),
+		Location:       ei.getNameProperty(LocationProp),
+		Address:        address,
+		Organizer:      ei.getNameProperty(OrganizerProp),
+		Performers:     ei.getNameListProperty(PerformerProp),
+		Offers:         ei.getOffersProperty(OffersProp),
+	}
+}
+
+// PlaceItem is the item for the location of an event.
+type PlaceItem struct {
+	BaseThingItem
+}
+
+func NewPlaceItem(element *html.Node) *PlaceItem {
+	item := &PlaceItem{}
+	item.init(Place, element)
+	item.addStringPropertyName(AddressProp)
+	item.addItemPropertyName(AddressProp)
+	return item
+}
+
+func (pi *PlaceItem) getName() string {
+	// Returns either the value of NameProp, or the URL for virtual location.
+	if name := pi.getStringProperty(NameProp); name != "" {
+		return name
+	}
+	return pi.getStringProperty(URLProp)
+}
+
+func (pi *PlaceItem) getAddress() string {
+	return pi.getNameProperty(AddressProp)
+}
+
+type PostalAddressItem struct {
+	BaseThingItem
+}
+
+func NewPostalAddressItem(element *html.Node) *PostalAddressItem {
+	item := &PostalAddressItem{}
+	item.init(PostalAddress, element)
+	item.addStringPropertyName(StreetAddressProp)
+	item.addStringPropertyName(AddressLocalityProp)
+	item.addStringPropertyName(AddressRegionProp)
+	item.addStringPropertyName(PostalCodeProp)
+	item.addStringPropertyName(AddressCountryProp)
+	return item
+}
+
+// getAddress returns the parts of the address joined by comma.
+func (pai *PostalAddressItem) getAddress() string {
+	var parts []string
+	for _, prop := range []string{StreetAddressProp, AddressLocalityProp,
+		AddressRegionProp, PostalCodeProp, AddressCountryProp} {
+		if part := pai.getStringProperty(prop); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
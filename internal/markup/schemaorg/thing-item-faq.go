@@ -0,0 +1,100 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package schemaorg
+
+import (
+	"github.com/markusmobius/go-domdistiller/data"
+	"golang.org/x/net/html"
+)
+
+type FAQPageItem struct {
+	BaseThingItem
+}
+
+func NewFAQPageItem(element *html.Node) *FAQPageItem {
+	item := &FAQPageItem{}
+	item.init(FAQPage, element)
+	item.addItemListPropertyName(MainEntityProp)
+	return item
+}
+
+func (fi *FAQPageItem) getFAQPage() data.FAQPage {
+	var questions []data.Question
+	for _, item := range fi.getItemListProperty(MainEntityProp) {
+		if questionItem, ok := item.(*QuestionItem); ok {
+			if question := questionItem.getQuestion(); question.Question != "" {
+				questions = append(questions, question)
+			}
+		}
+	}
+
+	return data.FAQPage{
+		Name:      fi.getStringProperty(NameProp),
+		Questions: questions,
+	}
+}
+
+type QuestionItem struct {
+	BaseThingItem
+}
+
+func NewQuestionItem(element *html.Node) *QuestionItem {
+	item := &QuestionItem{}
+	item.init(Question, element)
+	item.addStringPropertyName(TextProp)
+	item.addItemPropertyName(AcceptedAnswerProp)
+	item.addItemPropertyName(SuggestedAnswerProp)
+	return item
+}
+
+func (qi *QuestionItem) getQuestion() data.Question {
+	// The question is usually put in "name", while "text" is used for the longer
+	// version. The accepted answer is preferred over the suggested one.
+	question := qi.getStringProperty(NameProp)
+	if question == "" {
+		question = qi.getStringProperty(TextProp)
+	}
+
+	var answer string
+	for _, prop := range []string{AcceptedAnswerProp, SuggestedAnswerProp} {
+		if answerItem, ok := qi.getItemProperty(prop).(*AnswerItem); ok {
+			if answer = answerItem.getStringProperty(TextProp); answer != "" {
+				break
+			}
+		}
+	}
+
+	return data.Question{
+		Question: question,
+		Answer:   answer,
+	}
+}
+
+type AnswerItem struct {
+	BaseThingItem
+}
+
+func NewAnswerItem(element *html.Node) *AnswerItem {
+	item := &AnswerItem{}
+	item.init(Answer, element)
+	item.addStringPropertyName(TextProp)
+	return item
+}
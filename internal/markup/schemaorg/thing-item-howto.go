@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package schemaorg
+
+import (
+	"github.com/markusmobius/go-domdistiller/data"
+	"golang.org/x/net/html"
+)
+
+type HowToItem struct {
+	BaseThingItem
+}
+
+func NewHowToItem(element *html.Node) *HowToItem {
+	item := &HowToItem{}
+	item.init(HowTo, element)
+	item.addStringPropertyName(TotalTimeProp)
+	item.addStringListPropertyName(SupplyProp)
+	item.addStringListPropertyName(ToolProp)
+	item.addStringListPropertyName(StepProp)
+
+	item.addItemPropertyName(ImageProp)
+	item.addItemListPropertyName(SupplyProp)
+	item.addItemListPropertyName(ToolProp)
+	item.addItemListPropertyName(StepProp)
+	return item
+}
+
+func (hi *HowToItem) getHowTo() data.HowTo {
+	// The steps might be a plain text, or a list of HowToStep.
+	var steps []data.HowToStep
+	for _, text := range hi.getStringListProperty(StepProp) {
+		steps = append(steps, data.HowToStep{Text: text})
+	}
+
+	for _, item := range hi.getItemListProperty(StepProp) {
+		if stepItem, ok := item.(*HowToStepItem); ok {
+			steps = append(steps, stepItem.getStep())
+		}
+	}
+
+	return data.HowTo{
+		Name:        hi.getStringProperty(NameProp),
+		Description: hi.getStringProperty(DescriptionProp),
+		Image:       hi.getImageProperty(ImageProp),
+		TotalTime:   hi.getStringProperty(TotalTimeProp),
+		Supplies:    hi.getNameListProperty(SupplyProp),
+		Tools:       hi.getNameListProperty(ToolProp),
+		Steps:       steps,
+	}
+}
+
+// HowToStepItem is the item for HowToStep and HowToDirection.
+type HowToStepItem struct {
+	BaseThingItem
+}
+
+func NewHowToStepItem(element *html.Node) *HowToStepItem {
+	item := &HowToStepItem{}
+	item.init(HowToStep, element)
+	item.addStringPropertyName(TextProp)
+	item.addItemPropertyName(ImageProp)
+	return item
+}
+
+func (hsi *HowToStepItem) getStep() data.HowToStep {
+	return data.HowToStep{
+		Name:  hsi.getStringProperty(NameProp),
+		Text:  hsi.getStringProperty(TextProp),
+		URL:   hsi.getStringProperty(URLProp),
+		Image: hsi.getImageProperty(ImageProp),
+	}
+}
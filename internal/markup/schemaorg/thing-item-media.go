@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package schemaorg
+
+import (
+	"github.com/markusmobius/go-domdistiller/data"
+	"golang.org/x/net/html"
+)
+
+// MediaItem is the item for VideoObject and AudioObject.
+type MediaItem struct {
+	BaseThingItem
+}
+
+func NewMediaItem(schemaType SchemaType, element *html.Node) *MediaItem {
+	item := &MediaItem{}
+	item.init(schemaType, element)
+	item.addStringPropertyName(ThumbnailURLProp)
+	item.addStringPropertyName(ContentURLProp)
+	item.addStringPropertyName(EmbedURLProp)
+	item.addStringPropertyName(EncodingFormatProp)
+	item.addStringPropertyName(UploadDateProp)
+	item.addStringPropertyName(DurationProp)
+	item.addItemPropertyName(ThumbnailURLProp)
+	return item
+}
+
+func (mi *MediaItem) getVideo() data.VideoObject {
+	return data.VideoObject{
+		Name:         mi.getStringProperty(NameProp),
+		Description:  mi.getStringProperty(DescriptionProp),
+		ThumbnailURL: mi.getImageProperty(ThumbnailURLProp),
+		ContentURL:   mi.getStringProperty(ContentURLProp),
+		EmbedURL:     mi.getStringProperty(EmbedURLProp),
+		UploadDate:   mi.getStringProperty(UploadDateProp),
+		Duration:     mi.getStringProperty(DurationProp),
+	}
+}
+
+func (mi *MediaItem) getAudio() data.AudioObject {
+	return data.AudioObject{
+		Name:           mi.getStringProperty(NameProp),
+		Description:    mi.getStringProperty(DescriptionProp),
+		ContentURL:     mi.getStringProperty(ContentURLProp),
+		EmbedURL:       mi.getStringProperty(EmbedURLProp),
+		EncodingFormat: mi.getStringProperty(EncodingFormatProp),
+		UploadDate:     mi.getStringProperty(UploadDateProp),
+		Duration:       mi.getStringProperty(DurationProp),
+	}
+}
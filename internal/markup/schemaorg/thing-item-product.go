@@ -0,0 +1,170 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package schemaorg
+
+import (
+	"github.com/markusmobius/go-domdistiller/data"
+	"golang.org/x/net/html"
+)
+
+type ProductItem struct {
+	BaseThingItem
+}
+
+func NewProductItem(element *html.Node) *ProductItem {
+	item := &ProductItem{}
+	item.init(Product, element)
+	item.addStringPropertyName(BrandProp)
+	item.addStringPropertyName(SKUProp)
+	item.addStringPropertyName(GTINProp)
+	item.addStringPropertyName(GTIN8Prop)
+	item.addStringPropertyName(GTIN12Prop)
+	item.addStringPropertyName(GTIN13Prop)
+	item.addStringPropertyName(GTIN14Prop)
+	item.addStringPropertyName(MPNProp)
+
+	item.addItemPropertyName(ImageProp)
+	item.addItemPropertyName(BrandProp)
+	item.addItemPropertyName(AggregateRatingProp)
+	item.addItemListPropertyName(OffersProp)
+	item.addItemListPropertyName(ReviewProp)
+	return item
+}
+
+func (pi *ProductItem) getProduct() data.Product {
+	var gtin string
+	for _, prop := range []string{GTINProp, GTIN13Prop, GTIN12Prop, GTIN14Prop, GTIN8Prop} {
+		if gtin = pi.getStringProperty(prop); gtin != "" {
+			break
+		}
+	}
+
+	var reviews []data.Review
+	for _, item := range pi.getItemListProperty(ReviewProp) {
+		if reviewItem, ok := item.(*ReviewItem); ok {
+			reviews = append(reviews, reviewItem.getReview())
+		}
+	}
+
+	return data.Product{
+		Name:        pi.getStringProperty(NameProp),
+		Description: pi.getStringProperty(DescriptionProp),
+		Image:       pi.getImageProperty(ImageProp),
+		URL:         pi.getStringProperty(URLProp),
+		Brand:       pi.getNameProperty(BrandProp),
+		SKU:         pi.getStringProperty(SKUProp),
+		GTIN:        gtin,
+		MPN:         pi.getStringProperty(MPNProp),
+		Offers:      pi.getOffersProperty(OffersProp),
+		Rating:      pi.getRatingProperty(AggregateRatingProp),
+		Reviews:     reviews,
+	}
+}
+
+// OfferItem is the item for Offer and AggregateOffer.
+type OfferItem struct {
+	BaseThingItem
+}
+
+func NewOfferItem(element *html.Node) *OfferItem {
+	item := &OfferItem{}
+	item.init(Offer, element)
+	item.addStringPropertyName(PriceProp)
+	item.addStringPropertyName(LowPriceProp)
+	item.addStringPropertyName(HighPriceProp)
+	item.addStringPropertyName(PriceCurrencyProp)
+	item.addStringPropertyName(AvailabilityProp)
+	item.addStringPropertyName(SellerProp)
+	item.addStringPropertyName(ValidFromProp)
+	item.addItemPropertyName(SellerProp)
+	return item
+}
+
+func (oi *OfferItem) getOffer() data.Offer {
+	price := oi.getStringProperty(PriceProp)
+	if price == "" {
+		price = oi.getStringProperty(LowPriceProp)
+	}
+
+	return data.Offer{
+		Price:         price,
+		HighPrice:     oi.getStringProperty(HighPriceProp),
+		PriceCurrency: oi.getStringProperty(PriceCurrencyProp),
+		Availability:  oi.getStringProperty(AvailabilityProp),
+		URL:           oi.getStringProperty(URLProp),
+		Seller:        oi.getNameProperty(SellerProp),
+		ValidFrom:     oi.getStringProperty(ValidFromProp),
+	}
+}
+
+type ReviewItem struct {
+	BaseThingItem
+}
+
+func NewReviewItem(element *html.Node) *ReviewItem {
+	item := &ReviewItem{}
+	item.init(Review, element)
+	item.addStringPropertyName(AuthorProp)
+	item.addStringPropertyName(DatePublishedProp)
+	item.addStringPropertyName(ReviewBodyProp)
+	item.addStringPropertyName(ItemReviewedProp)
+	item.addItemPropertyName(AuthorProp)
+	item.addItemPropertyName(ItemReviewedProp)
+	item.addItemPropertyName(ReviewRatingProp)
+	return item
+}
+
+func (ri *ReviewItem) getReview() data.Review {
+	return data.Review{
+		Name:         ri.getStringProperty(NameProp),
+		Author:       ri.getNameProperty(AuthorProp),
+		PublishedAt:  ri.getStringProperty(DatePublishedProp),
+		Body:         ri.getStringProperty(ReviewBodyProp),
+		ItemReviewed: ri.getNameProperty(ItemReviewedProp),
+		Rating:       ri.getRatingProperty(ReviewRatingProp),
+	}
+}
+
+// RatingItem is the item for Rating and AggregateRating.
+type RatingItem struct {
+	BaseThingItem
+}
+
+func NewRatingItem(element *html.Node) *RatingItem {
+	item := &RatingItem{}
+	item.init(Rating, element)
+	item.addStringPropertyName(RatingValueProp)
+	item.addStringPropertyName(BestRatingProp)
+	item.addStringPropertyName(WorstRatingProp)
+	item.addStringPropertyName(RatingCountProp)
+	item.addStringPropertyName(ReviewCountProp)
+	return item
+}
+
+func (ri *RatingItem) getRating() data.Rating {
+	return data.Rating{
+		Value:       ri.getStringProperty(RatingValueProp),
+		Best:        ri.getStringProperty(BestRatingProp),
+		Worst:       ri.getStringProperty(WorstRatingProp),
+		RatingCount: ri.getStringProperty(RatingCountProp),
+		ReviewCount: ri.getStringProperty(ReviewCountProp),
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package schemaorg
+
+import (
+	"github.com/markusmobius/go-domdistiller/data"
+	"golang.org/x/net/html"
+)
+
+type RecipeItem struct {
+	BaseThingItem
+}
+
+func NewRecipeItem(element *html.Node) *RecipeItem {
+	item := &RecipeItem{}
+	item.init(Recipe, element)
+	item.addStringPropertyName(AuthorProp)
+	item.addStringPropertyName(DatePublishedProp)
+	item.addStringPropertyName(PrepTimeProp)
+	item.addStringPropertyName(CookTimeProp)
+	item.addStringPropertyName(TotalTimeProp)
+	item.addStringPropertyName(RecipeYieldProp)
+	item.addStringPropertyName(RecipeCategoryProp)
+	item.addStringPropertyName(RecipeCuisineProp)
+	item.addStringListPropertyName(RecipeIngredientProp)
+	item.addStringListPropertyName(IngredientsProp)
+	item.addStringListPropertyName(RecipeInstructionsProp)
+
+	item.addItemPropertyName(ImageProp)
+	item.addItemPropertyName(AuthorProp)
+	item.addItemPropertyName(NutritionProp)
+	item.addItemPropertyName(AggregateRatingProp)
+	item.addItemListPropertyName(RecipeInstructionsProp)
+	return item
+}
+
+func (ri *RecipeItem) getRecipe() data.Recipe {
+	// Old recipes use "ingredients" property, which is superseded by "recipeIngredient".
+	ingredients := ri.getStringListProperty(RecipeIngredientProp)
+	if len(ingredients) == 0 {
+		ingredients = ri.getStringListProperty(IngredientsProp)
+	}
+
+	// The instructions might be a block of text, or a list of HowToStep.
+	instructions := append([]string{}, ri.getStringListProperty(RecipeInstructionsProp)...)
+	for _, item := range ri.getItemListProperty(RecipeInstructionsProp) {
+		if stepItem, ok := item.(*HowToStepItem); ok {
+			if step := stepItem.getStep(); step.Text != "" {
+				instructions = append(instructions, step.Text)
+			} else if step.Name != "" {
+				instructions = append(instructions, step.Name)
+			}
+		}
+	}
+
+	var calories string
+	if nutritionItem, ok := ri.getItemProperty(NutritionProp).(*NutritionItem); ok {
+		calories = nutritionItem.getStringProperty(CaloriesProp)
+	}
+
+	return data.Recipe{
+		Name:         ri.getStringProperty(NameProp),
+		Description:  ri.getStringProperty(DescriptionProp),
+		Image:        ri.getImageProperty(ImageProp),
+		Author:       ri.getNameProperty(AuthorProp),
+		PublishedAt:  ri.getStringProperty(DatePublishedProp),
+		PrepTime:     ri.getStringProperty(PrepTimeProp),
+		CookTime:     ri.getStringProperty(CookTimeProp),
+		TotalTime:    ri.getStringProperty(TotalTimeProp),
+		Yield:        ri.getStringProperty(RecipeYieldProp),
+		Category:     ri.getStringProperty(RecipeCategoryProp),
+		Cuisine:      ri.getStringProperty(RecipeCuisineProp),
+		Calories:     calories,
+		Ingredients:  ingredients,
+		Instructions: instructions,
+		Rating:       ri.getRatingProperty(AggregateRatingProp),
+	}
+}
+
+// NutritionItem is the item for NutritionInformation of a recipe.
+type NutritionItem struct {
+	BaseThingItem
+}
+
+func NewNutritionItem(element *html.Node) *NutritionItem {
+	item := &NutritionItem{}
+	item.init(Nutrition, element)
+	item.addStringPropertyName(CaloriesProp)
+	return item
+}
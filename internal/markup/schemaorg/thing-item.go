@@ -29,6 +29,7 @@ package schemaorg
 import (
 	"strings"
 
+	"github.com/markusmobius/go-domdistiller/data"
 	"golang.org/x/net/html"
 )
 
@@ -57,6 +58,11 @@ type BaseThingItem struct {
 	schemaType       SchemaType
 	stringProperties map[string]string
 	itemProperties   map[string]ThingItem
+
+	// The properties that might have several values, e.g. the ingredients of a
+	// recipe. Unlike the properties above, every value is kept.
+	stringListProperties map[string][]string
+	itemListProperties   map[string][]ThingItem
 }
 
 func (ti *BaseThingItem) init(schemaType SchemaType, element *html.Node) {
@@ -64,6 +70,8 @@ func (ti *BaseThingItem) init(schemaType SchemaType, element *html.Node) {
 	ti.schemaType = schemaType
 	ti.stringProperties = make(map[string]string)
 	ti.itemProperties = make(map[string]ThingItem)
+	ti.stringListProperties = make(map[string][]string)
+	ti.itemListProperties = make(map[string][]ThingItem)
 
 	ti.addStringPropertyName(NameProp)
 	ti.addStringPropertyName(URLProp)
@@ -79,6 +87,14 @@ func (ti *BaseThingItem) addItemPropertyName(name string) {
 	ti.itemProperties[name] = nil
 }
 
+func (ti *BaseThingItem) addStringListPropertyName(name string) {
+	ti.stringListProperties[name] = nil
+}
+
+func (ti *BaseThingItem) addItemListPropertyName(name string) {
+	ti.itemListProperties[name] = nil
+}
+
 func (ti *BaseThingItem) getStringProperty(name string) string {
 	return ti.stringProperties[name]
 }
@@ -87,6 +103,14 @@ func (ti *BaseThingItem) getItemProperty(name string) ThingItem {
 	return ti.itemProperties[name]
 }
 
+func (ti *BaseThingItem) getStringListProperty(name string) []string {
+	return ti.stringListProperties[name]
+}
+
+func (ti *BaseThingItem) getItemListProperty(name string) []ThingItem {
+	return ti.itemListProperties[name]
+}
+
 func (ti *BaseThingItem) getType() SchemaType {
 	return ti.schemaType
 }
@@ -100,6 +124,12 @@ func (ti *BaseThingItem) putStringValue(name string, value string) {
 	if exist && currentValue == "" {
 		ti.stringProperties[name] = strings.TrimSpace(value)
 	}
+
+	if _, exist := ti.stringListProperties[name]; exist {
+		if value = strings.TrimSpace(value); value != "" {
+			ti.stringListProperties[name] = append(ti.stringListProperties[name], value)
+		}
+	}
 }
 
 func (ti *BaseThingItem) putItemValue(name string, value ThingItem) {
@@ -107,6 +137,83 @@ func (ti *BaseThingItem) putItemValue(name string, value ThingItem) {
 	if exist && currentValue == nil {
 		ti.itemProperties[name] = value
 	}
+
+	if _, exist := ti.itemListProperties[name]; exist && value != nil {
+		ti.itemListProperties[name] = append(ti.itemListProperties[name], value)
+	}
+}
+
+// getNameProperty returns either the string value of the property, or
+// the name of the item in the property.
+func (ti *BaseThingItem) getNameProperty(name string) string {
+	if value := ti.getStringProperty(name); value != "" {
+		return value
+	}
+	return itemName(ti.getItemProperty(name))
+}
+
+// getNameListProperty returns the string values and the name of items
+// in the property, which registered as list property.
+func (ti *BaseThingItem) getNameListProperty(name string) []string {
+	names := append([]string{}, ti.getStringListProperty(name)...)
+	for _, item := range ti.getItemListProperty(name) {
+		if itemName := itemName(item); itemName != "" {
+			names = append(names, itemName)
+		}
+	}
+	return names
+}
+
+// getImageProperty returns either the string value of the property, or
+// the URL of the ImageObject in the property.
+func (ti *BaseThingItem) getImageProperty(name string) string {
+	if value := ti.getStringProperty(name); value != "" {
+		return value
+	}
+
+	if imageItem, ok := ti.getItemProperty(name).(*ImageItem); ok {
+		return imageItem.getImage().URL
+	}
+	return ""
+}
+
+// getRatingProperty returns the Rating or AggregateRating in the property.
+func (ti *BaseThingItem) getRatingProperty(name string) *data.Rating {
+	if ratingItem, ok := ti.getItemProperty(name).(*RatingItem); ok {
+		rating := ratingItem.getRating()
+		return &rating
+	}
+	return nil
+}
+
+// getOffersProperty returns the Offer and AggregateOffer in the property.
+func (ti *BaseThingItem) getOffersProperty(name string) []data.Offer {
+	var offers []data.Offer
+	for _, item := range ti.getItemListProperty(name) {
+		if offerItem, ok := item.(*OfferItem); ok {
+			offers = append(offers, offerItem.getOffer())
+		}
+	}
+	return offers
+}
+
+// itemName returns the name of the item. For Person and Organization, the
+// name might be built from their other properties.
+func itemName(item ThingItem) string {
+	switch v := item.(type) {
+	case nil:
+		return ""
+	case *PersonItem:
+		return v.getName()
+	case *OrganizationItem:
+		return v.getName()
+	case *PlaceItem:
+		return v.getName()
+	case *PostalAddressItem:
+		return v.getAddress()
+	default:
+		return item.getStringProperty(NameProp)
+	}
 }
 
 func (ti *BaseThingItem) getElement() *html.Node {
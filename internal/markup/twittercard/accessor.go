@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package twittercard
+
+import (
+	"strconv"
+
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"golang.org/x/net/html"
+)
+
+// Parser recognizes and parses the Twitter Card meta tags, i.e. <meta name="twitter:*">,
+// according to https://developer.twitter.com/en/docs/twitter-for-websites/cards. Twitter
+// only knows the handle of the site and the author (e.g. "@example"), so they are only
+// available in Card and not returned as publisher or author. It implements markup.Accessor.
+type Parser struct {
+	properties map[string][]string
+}
+
+func NewParser(root *html.Node) *Parser {
+	return &Parser{
+		properties: domutil.GetMetaContents(root, "twitter:"),
+	}
+}
+
+// Card returns all properties of the Twitter Card.
+func (ps *Parser) Card() data.MarkupTwitterCard {
+	image := ps.get(ImageProp)
+	if image == "" {
+		image = ps.get(ImageSrcProp)
+	}
+
+	return data.MarkupTwitterCard{
+		Card:        ps.get(CardProp),
+		Site:        ps.get(SiteProp),
+		Creator:     ps.get(CreatorProp),
+		Title:       ps.get(TitleProp),
+		Description: ps.get(DescriptionProp),
+		Image:       image,
+		ImageAlt:    ps.get(ImageAltProp),
+	}
+}
+
+func (ps *Parser) Title() string {
+	return ps.get(TitleProp)
+}
+
+func (ps *Parser) Type() string {
+	return ""
+}
+
+func (ps *Parser) URL() string {
+	return ps.get(URLProp)
+}
+
+func (ps *Parser) Images() []data.MarkupImage {
+	card := ps.Card()
+	if card.Image == "" {
+		return nil
+	}
+
+	width, _ := strconv.Atoi(ps.get(ImageWidthProp))
+	height, _ := strconv.Atoi(ps.get(ImageHeightProp))
+	return []data.MarkupImage{{
+		URL:     card.Image,
+		Caption: card.ImageAlt,
+		Width:   width,
+		Height:  height,
+	}}
+}
+
+func (ps *Parser) Description() string {
+	return ps.get(DescriptionProp)
+}
+
+func (ps *Parser) Publisher() string {
+	return ""
+}
+
+func (ps *Parser) Copyright() string {
+	return ""
+}
+
+func (ps *Parser) Author() string {
+	return ""
+}
+
+func (ps *Parser) Article() *data.MarkupArticle {
+	return nil
+}
+
+func (ps *Parser) OptOut() bool {
+	return false
+}
+
+func (ps *Parser) get(prop string) string {
+	if values := ps.properties[prop]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
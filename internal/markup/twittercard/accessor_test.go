@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package twittercard_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/markup/twittercard"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func Test_TwitterCard_Properties(t *testing.T) {
+	doc := createDocWithMeta(map[string]string{
+		"twitter:card":        "summary_large_image",
+		"twitter:site":        "@dummy",
+		"twitter:creator":     "@author",
+		"twitter:title":       "Card title",
+		"twitter:description": "Card description",
+		"twitter:image":       "http://dummy/image.jpeg",
+		"twitter:image:alt":   "Image alt",
+		"twitter:url":         "http://dummy/page.html",
+	})
+
+	parser := twittercard.NewParser(doc)
+	assert.Equal(t, "Card title", parser.Title())
+	assert.Equal(t, "Card description", parser.Description())
+	assert.Equal(t, "http://dummy/page.html", parser.URL())
+	assert.Equal(t, "", parser.Author())
+	assert.Equal(t, "", parser.Publisher())
+	assert.Nil(t, parser.Article())
+
+	card := parser.Card()
+	assert.Equal(t, "summary_large_image", card.Card)
+	assert.Equal(t, "@dummy", card.Site)
+	assert.Equal(t, "@author", card.Creator)
+	assert.Equal(t, "http://dummy/image.jpeg", card.Image)
+
+	images := parser.Images()
+	assert.Equal(t, 1, len(images))
+	assert.Equal(t, "http://dummy/image.jpeg", images[0].URL)
+	assert.Equal(t, "Image alt", images[0].Caption)
+}
+
+func Test_TwitterCard_ImageSrc(t *testing.T) {
+	doc := createDocWithMeta(map[string]string{
+		"twitter:image:src": "http://dummy/legacy.jpeg",
+	})
+
+	parser := twittercard.NewParser(doc)
+	assert.Equal(t, "http://dummy/legacy.jpeg", parser.Card().Image)
+	assert.Equal(t, "", parser.Title())
+}
+
+func createDocWithMeta(properties map[string]string) *html.Node {
+	doc := testutil.CreateHTML()
+	head := dom.QuerySelector(doc, "head")
+	for name, content := range properties {
+		meta := dom.CreateElement("meta")
+		dom.SetAttribute(meta, "name", name)
+		dom.SetAttribute(meta, "content", content)
+		dom.AppendChild(head, meta)
+	}
+	return doc
+}
@@ -45,12 +45,8 @@ var (
 	rxSurroundingDigits    = regexp.MustCompile(`(?i)^[\W_]*(\d+)[\W_]*$`)
 
 	// Regex for prev next finder
-	rxNextLink       = regexp.MustCompile(`(?i)(next|weiter|continue|>([^\|]|$)|»([^\|]|$))`)
-	rxPrevLink       = regexp.MustCompile(`(?i)(prev|early|old|new|<|«)`)
 	rxPositive       = regexp.MustCompile(`(?i)article|body|content|entry|hentry|main|page|pagination|post|text|blog|story`)
 	rxNegative       = regexp.MustCompile(`(?i)combx|comment|com-|contact|foot|footer|footnote|masthead|media|meta|outbrain|promo|related|shoutbox|sidebar|sponsor|shopping|tags|tool|widget`)
-	rxExtraneous     = regexp.MustCompile(`(?i)print|archive|comment|discuss|e[\-]?mail|share|reply|all|login|sign|single|as one|article|post|篇`)
 	rxPagination     = regexp.MustCompile(`(?i)pag(e|ing|inat)`)
 	rxLinkPagination = regexp.MustCompile(`(?i)p(a|g|ag)?(e|ing|ination)?(=|\/)[0-9]{1,2}$`)
-	rxFirstLast      = regexp.MustCompile(`(?i)(first|last)`)
 )
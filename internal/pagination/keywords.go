@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package pagination
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/markusmobius/go-domdistiller/internal/langutil"
+)
+
+// Keywords is the vocabulary that used by PrevNextFinder to recognize the pagination
+// links. All terms are matched case-insensitively against the link text, class name
+// and ID, so they don't need to be whole words.
+type Keywords struct {
+	// Next is the terms in link to the next page, e.g. "next".
+	Next []string
+
+	// Prev is the terms in link to the previous page, e.g. "prev".
+	Prev []string
+
+	// Extraneous is the terms in link that unlikely to be a pagination, e.g. "print".
+	Extraneous []string
+
+	// FirstLast is the terms in link to the first or last page, e.g. "first".
+	FirstLast []string
+}
+
+// builtinKeywords is the pagination keywords for each language.
+var builtinKeywords = map[string]Keywords{
+	// English keywords are the original ones from DOM Distiller, including "weiter"
+	// and "篇" which have been there since before the localized keywords.
+	"en": {
+		Next: []string{"next", "weiter", "continue"},
+		Prev: []string{"prev", "early", "old", "new"},
+		Extraneous: []string{"print", "archive", "comment", "discuss", "email", "e-mail", "share",
+			"reply", "all", "login", "sign", "single", "as one", "article", "post", "篇"},
+		FirstLast: []string{"first", "last"},
+	},
+	"de": {
+		Next:       []string{"nächste"},
+		Prev:       []string{"zurück", "vorherige"},
+		Extraneous: []string{"drucken", "kommentar", "teilen", "antworten", "anmelden", "artikel"},
+		FirstLast:  []string{"erste", "letzte"},
+	},
+	"fr": {
+		Next:       []string{"suivant", "suivante"},
+		Prev:       []string{"précédent", "précédente"},
+		Extraneous: []string{"imprimer", "commentaire", "partager", "répondre", "connexion", "tous"},
+		FirstLast:  []string{"premier", "première", "dernier", "dernière"},
+	},
+	"es": {
+		Next:       []string{"siguiente", "próxima", "próximo"},
+		Prev:       []string{"anterior"},
+		Extraneous: []string{"imprimir", "comentario", "compartir", "responder", "todos", "artículo"},
+		FirstLast:  []string{"primera", "primero", "última", "último"},
+	},
+	"pt": {
+		Next:       []string{"próxima", "próximo", "seguinte"},
+		Prev:       []string{"anterior"},
+		Extraneous: []string{"imprimir", "comentário", "compartilhar", "partilhar", "responder", "todos", "artigo"},
+		FirstLast:  []string{"primeira", "primeiro", "última", "último"},
+	},
+	"it": {
+		Next:       []string{"successiv", "prossim", "avanti"},
+		Prev:       []string{"precedente", "indietro"},
+		Extraneous: []string{"stampa", "commento", "condividi", "rispondi", "tutti", "articolo"},
+		FirstLast:  []string{"prima pagina", "ultima"},
+	},
+	"ru": {
+		Next:       []string{"далее", "следующ", "вперёд", "вперед"},
+		Prev:       []string{"назад", "предыдущ"},
+		Extraneous: []string{"печать", "комментари", "поделиться", "ответить", "войти", "статья"},
+		FirstLast:  []string{"первая", "последняя"},
+	},
+	"ja": {
+		Next:       []string{"次へ", "次のページ"},
+		Prev:       []string{"前へ", "前のページ"},
+		Extraneous: []string{"印刷", "コメント", "シェア", "記事"},
+		FirstLast:  []string{"最初", "最後"},
+	},
+	"zh": {
+		Next:       []string{"下一页", "下一頁", "下页", "下頁"},
+		Prev:       []string{"上一页", "上一頁", "上页", "上頁"},
+		Extraneous: []string{"打印", "评论", "評論", "分享", "全文"},
+		FirstLast:  []string{"首页", "首頁", "尾页", "尾頁", "末页", "末頁"},
+	},
+	"id": {
+		Next:       []string{"berikutnya", "selanjutnya", "lanjut"},
+		Prev:       []string{"sebelumnya"},
+		Extraneous: []string{"cetak", "komentar", "bagikan", "balas", "semua", "artikel"},
+		FirstLast:  []string{"pertama", "terakhir"},
+	},
+}
+
+// KeywordsForLanguage returns the built-in keywords for the language, merged with the
+// English keywords since they are common in sites of any language. If the language is
+// empty or unknown, only the English keywords are returned.
+func KeywordsForLanguage(language string) Keywords {
+	keywords := builtinKeywords["en"].Merge(Keywords{})
+	if base := langutil.Base(language); base != "en" {
+		if languageKeywords, exist := builtinKeywords[base]; exist {
+			keywords = keywords.Merge(languageKeywords)
+		}
+	}
+	return keywords
+}
+
+// Merge returns new keywords that contains the terms of both keywords.
+func (k Keywords) Merge(other Keywords) Keywords {
+	return Keywords{
+		Next:       mergeTerms(k.Next, other.Next),
+		Prev:       mergeTerms(k.Prev, other.Prev),
+		Extraneous: mergeTerms(k.Extraneous, other.Extraneous),
+		FirstLast:  mergeTerms(k.FirstLast, other.FirstLast),
+	}
+}
+
+func mergeTerms(a, b []string) []string {
+	merged := make([]string, 0, len(a)+len(b))
+	merged = append(merged, a...)
+	merged = append(merged, b...)
+	return merged
+}
+
+// compileTerms compiles the terms into a case-insensitive regex that matches any of the terms
+// or the extra patterns. Returns regex that never matches if there are nothing to match.
+func compileTerms(terms []string, extraPatterns ...string) *regexp.Regexp {
+	alternatives := append([]string{}, extraPatterns...)
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			alternatives = append(alternatives, regexp.QuoteMeta(term))
+		}
+	}
+
+	if len(alternatives) == 0 {
+		return regexp.MustCompile(`[^\s\S]`)
+	}
+
+	return regexp.MustCompile(`(?i)(` + strings.Join(alternatives, "|") + `)`)
+}
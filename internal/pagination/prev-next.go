@@ -35,6 +35,7 @@ import (
 	"fmt"
 	nurl "net/url"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -66,13 +67,25 @@ type PrevNextFinder struct {
 	linkDebugInfo     map[*html.Node]string
 	linkDebugMessages map[*html.Node]map[string]struct{}
 	logger            logutil.Logger
+
+	rxNextLink   *regexp.Regexp
+	rxPrevLink   *regexp.Regexp
+	rxExtraneous *regexp.Regexp
+	rxFirstLast  *regexp.Regexp
 }
 
-func NewPrevNextFinder(logger logutil.Logger) *PrevNextFinder {
+// NewPrevNextFinder creates PrevNextFinder that recognizes the pagination links
+// using the specified keywords, e.g. the one from KeywordsForLanguage.
+func NewPrevNextFinder(logger logutil.Logger, keywords Keywords) *PrevNextFinder {
 	return &PrevNextFinder{
 		linkDebugInfo:     make(map[*html.Node]string),
 		linkDebugMessages: make(map[*html.Node]map[string]struct{}),
 		logger:            logger,
+
+		rxNextLink:   compileTerms(keywords.Next, `>([^\|]|$)`, `»([^\|]|$)`),
+		rxPrevLink:   compileTerms(keywords.Prev, `<`, `«`),
+		rxExtraneous: compileTerms(keywords.Extraneous),
+		rxFirstLast:  compileTerms(keywords.FirstLast),
 	}
 }
 
@@ -186,7 +199,7 @@ func (pnf *PrevNextFinder) FindOutlink(root *html.Node, pageURL *nurl.URL, findN
 
 		// If the linkText contains banned text, skip it, and also ban other anchors with the
 		// same link URL.
-		if rxExtraneous.MatchString(linkText) {
+		if pnf.rxExtraneous.MatchString(linkText) {
 			pnf.appendDebugStrForLink(link, "ignored: one of extra")
 			bannedURLs[linkHref] = struct{}{}
 			continue
@@ -233,8 +246,8 @@ func (pnf *PrevNextFinder) FindOutlink(root *html.Node, pageURL *nurl.URL, findN
 		// existence of various paging-related words.
 		linkData := linkText + " " + dom.GetAttribute(link, "class") + " " + dom.GetAttribute(link, "id")
 
-		if (findNext && rxNextLink.MatchString(linkData)) ||
-			(!findNext && rxPrevLink.MatchString(linkData)) {
+		if (findNext && pnf.rxNextLink.MatchString(linkData)) ||
+			(!findNext && pnf.rxPrevLink.MatchString(linkData)) {
 			linkObj.score += 50
 
 			pnf.appendDebugStrForLink(link, fmt.Sprintf(
@@ -250,13 +263,13 @@ func (pnf *PrevNextFinder) FindOutlink(root *html.Node, pageURL *nurl.URL, findN
 				linkObj.score))
 		}
 
-		if rxFirstLast.MatchString(linkData) {
+		if pnf.rxFirstLast.MatchString(linkData) {
 			// -65 is enough to negate any bonuses gotten from a > or » in the text.
 			// If we already matched on "next", last is probably fine.
 			// If we didn't, then it's bad.  Penalize.
 			// Same for "prev".
-			if (findNext && !rxNextLink.MatchString(linkText)) ||
-				(!findNext && !rxPrevLink.MatchString(linkText)) {
+			if (findNext && !pnf.rxNextLink.MatchString(linkText)) ||
+				(!findNext && !pnf.rxPrevLink.MatchString(linkText)) {
 				linkObj.score -= 65
 
 				pnf.appendDebugStrForLink(link, fmt.Sprintf(
@@ -265,14 +278,14 @@ func (pnf *PrevNextFinder) FindOutlink(root *html.Node, pageURL *nurl.URL, findN
 			}
 		}
 
-		if rxNegative.MatchString(linkData) || rxExtraneous.MatchString(linkData) {
+		if rxNegative.MatchString(linkData) || pnf.rxExtraneous.MatchString(linkData) {
 			linkObj.score -= 50
 			pnf.appendDebugStrForLink(link,
 				fmt.Sprintf("score %d, has negative or extra regex", linkObj.score))
 		}
 
-		if (findNext && rxPrevLink.MatchString(linkData)) ||
-			(!findNext && rxNextLink.MatchString(linkData)) {
+		if (findNext && pnf.rxPrevLink.MatchString(linkData)) ||
+			(!findNext && pnf.rxNextLink.MatchString(linkData)) {
 			linkObj.score -= 200
 
 			pnf.appendDebugStrForLink(link, fmt.Sprintf(
@@ -321,7 +334,7 @@ func (pnf *PrevNextFinder) FindOutlink(root *html.Node, pageURL *nurl.URL, findN
 		}
 
 		// If the URL contains negative values, give a slight decrease.
-		if rxExtraneous.MatchString(linkHref) {
+		if pnf.rxExtraneous.MatchString(linkHref) {
 			linkObj.score -= 15
 			pnf.appendDebugStrForLink(link, fmt.Sprintf(
 				"score %d, has extra regex", linkObj.score))
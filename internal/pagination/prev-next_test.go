@@ -312,6 +312,86 @@ func Test_Pagination_PrevNext_NextChineseArticleLinks(t *testing.T) {
 	assertDefaultDocumenOutlink(t, doc, anchor, anchor)
 }
 
+func Test_Pagination_PrevNext_NextChineseLinksWithKeywords(t *testing.T) {
+	doc := testutil.CreateHTML()
+	body := dom.QuerySelector(doc, "body")
+
+	root := testutil.CreateDiv(0)
+	dom.SetAttribute(root, "class", "page")
+	dom.AppendChild(body, root)
+
+	anchor := testutil.CreateAnchor("page2", "下一頁")
+	dom.AppendChild(root, anchor)
+
+	// With Chinese keywords, "下一頁" is recognized as next page, so it's not used as previous page.
+	pageURL, _ := nurl.ParseRequestURI(ExampleURL)
+	finder := pagination.NewPrevNextFinder(nil, pagination.KeywordsForLanguage("zh"))
+	assert.Equal(t, "", finder.FindOutlink(doc, pageURL, false))
+	assert.Equal(t, "http://example.com/path/toward/page2", finder.FindOutlink(doc, pageURL, true))
+}
+
+func Test_Pagination_PrevNext_LocalizedLinks(t *testing.T) {
+	texts := map[string][2]string{
+		"fr": {"Précédent", "Suivant"},
+		"es": {"Anterior", "Siguiente"},
+		"ja": {"前へ", "次へ"},
+		"zh": {"上一页", "下一页"},
+		"ru": {"Назад", "Далее"},
+		"id": {"Sebelumnya", "Berikutnya"},
+	}
+
+	for language, text := range texts {
+		doc := testutil.CreateHTML()
+		body := dom.QuerySelector(doc, "body")
+
+		prevAnchor := testutil.CreateAnchor("http://example.com/story/1", text[0])
+		nextAnchor := testutil.CreateAnchor("http://example.com/story/3", text[1])
+		dom.AppendChild(body, prevAnchor)
+		dom.AppendChild(body, nextAnchor)
+
+		pageURL, _ := nurl.ParseRequestURI("http://example.com/story/2")
+		finder := pagination.NewPrevNextFinder(nil, pagination.KeywordsForLanguage(language))
+		assert.Equal(t, "http://example.com/story/1", finder.FindOutlink(doc, pageURL, false), language)
+		assert.Equal(t, "http://example.com/story/3", finder.FindOutlink(doc, pageURL, true), language)
+
+		// Without the language keywords, the links are not recognized
+		finder = pagination.NewPrevNextFinder(nil, pagination.Keywords{})
+		assert.Equal(t, "", finder.FindOutlink(doc, pageURL, true), language)
+	}
+}
+
+func Test_Pagination_PrevNext_DefaultKeywords(t *testing.T) {
+	assert.Equal(t, pagination.KeywordsForLanguage("en"), pagination.KeywordsForLanguage(""))
+	assert.Equal(t, pagination.KeywordsForLanguage("en"), pagination.KeywordsForLanguage("xx-unknown"))
+
+	// Terms of other languages don't affect page without language.
+	doc := testutil.CreateHTML()
+	body := dom.QuerySelector(doc, "body")
+	anchor := testutil.CreateAnchor("http://example.com/story/3", "next: tutti i dettagli")
+	dom.AppendChild(body, anchor)
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com/story/2")
+	finder := pagination.NewPrevNextFinder(nil, pagination.KeywordsForLanguage(""))
+	assert.Equal(t, "http://example.com/story/3", finder.FindOutlink(doc, pageURL, true))
+
+	finder = pagination.NewPrevNextFinder(nil, pagination.KeywordsForLanguage("it"))
+	assert.Equal(t, "", finder.FindOutlink(doc, pageURL, true))
+}
+
+func Test_Pagination_PrevNext_ExtraKeywords(t *testing.T) {
+	doc := testutil.CreateHTML()
+	body := dom.QuerySelector(doc, "body")
+	anchor := testutil.CreateAnchor("http://example.com/story/3", "Volgende")
+	dom.AppendChild(body, anchor)
+
+	pageURL, _ := nurl.ParseRequestURI("http://example.com/story/2")
+	keywords := pagination.KeywordsForLanguage("nl")
+	assert.Equal(t, "", pagination.NewPrevNextFinder(nil, keywords).FindOutlink(doc, pageURL, true))
+
+	keywords = keywords.Merge(pagination.Keywords{Next: []string{"volgende"}})
+	assert.Equal(t, "http://example.com/story/3", pagination.NewPrevNextFinder(nil, keywords).FindOutlink(doc, pageURL, true))
+}
+
 func Test_Pagination_PrevNext_NextPostLinks(t *testing.T) {
 	doc := testutil.CreateHTML()
 	body := dom.QuerySelector(doc, "body")
@@ -372,7 +452,7 @@ func assertDocumentOutlink(t *testing.T, pageURL string, doc *html.Node, prevAnc
 	assert.NoError(t, err)
 	assert.NotNil(t, url)
 
-	prevHref := pagination.NewPrevNextFinder(nil).FindOutlink(doc, url, false)
+	prevHref := pagination.NewPrevNextFinder(nil, pagination.KeywordsForLanguage("")).FindOutlink(doc, url, false)
 	if prevAnchor == nil {
 		assert.Equal(t, "", prevHref)
 	} else {
@@ -381,7 +461,7 @@ func assertDocumentOutlink(t *testing.T, pageURL string, doc *html.Node, prevAnc
 		assert.Equal(t, linkHref, prevHref)
 	}
 
-	nextHref := pagination.NewPrevNextFinder(nil).FindOutlink(doc, url, true)
+	nextHref := pagination.NewPrevNextFinder(nil, pagination.KeywordsForLanguage("")).FindOutlink(doc, url, true)
 	if nextAnchor == nil {
 		assert.Equal(t, "", nextHref)
 	} else {
@@ -400,7 +480,7 @@ func assertDocumentNextLink(t *testing.T, pageURL string, doc *html.Node, anchor
 	assert.NoError(t, err)
 	assert.NotNil(t, url)
 
-	nextHref := pagination.NewPrevNextFinder(nil).FindOutlink(doc, url, true)
+	nextHref := pagination.NewPrevNextFinder(nil, pagination.KeywordsForLanguage("")).FindOutlink(doc, url, true)
 	if anchor == nil {
 		assert.Equal(t, "", nextHref)
 	} else {
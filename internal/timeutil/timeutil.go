@@ -0,0 +1,236 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package timeutil contains functions for parsing the date and time
+// that commonly found in web pages.
+package timeutil
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var layouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"20060102",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"Monday, January 2, 2006",
+	"Monday, 2 January 2006",
+	"January 2, 2006 at 3:04 PM",
+	"Jan 2, 2006 3:04 PM",
+	"Jan 2, 2006 15:04",
+	"2 January 2006 15:04",
+	"2 Jan 2006 15:04",
+	time.RubyDate,
+	time.UnixDate,
+
+	// Local formats, e.g. in Europe and East Asia. For slashed date the US order
+	// (month first) is tried before the day first order.
+	"2.1.2006 15:04:05",
+	"2.1.2006 15:04",
+	"2.1.2006, 15:04",
+	"2.1.2006",
+	"1/2/2006 15:04:05",
+	"1/2/2006 15:04",
+	"1/2/2006 3:04 PM",
+	"1/2/2006",
+	"2/1/2006 15:04",
+	"2/1/2006",
+	"2006年1月2日 15:04",
+	"2006年1月2日",
+	"2006년 1월 2일 15:04",
+	"2006년 1월 2일",
+}
+
+// zoneOffsets is the UTC offset in minutes of common time zone abbreviations. They are
+// needed because time.Parse only knows the abbreviation of the machine's local zone.
+var zoneOffsets = map[string]int{
+	"UTC": 0, "GMT": 0, "Z": 0,
+	"EST": -5 * 60, "EDT": -4 * 60, "CST": -6 * 60, "CDT": -5 * 60,
+	"MST": -7 * 60, "MDT": -6 * 60, "PST": -8 * 60, "PDT": -7 * 60,
+	"AKST": -9 * 60, "AKDT": -8 * 60, "HST": -10 * 60,
+	"BST": 60, "IST": 5*60 + 30, "CET": 60, "CEST": 2 * 60,
+	"EET": 2 * 60, "EEST": 3 * 60, "MSK": 3 * 60,
+	"WIB": 7 * 60, "WITA": 8 * 60, "WIT": 9 * 60,
+	"SGT": 8 * 60, "HKT": 8 * 60, "JST": 9 * 60, "KST": 9 * 60,
+	"AEST": 10 * 60, "AEDT": 11 * 60, "NZST": 12 * 60, "NZDT": 13 * 60,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March,
+	"apr": time.April, "may": time.May, "jun": time.June,
+	"jul": time.July, "aug": time.August, "sep": time.September,
+	"oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+const rxMonthName = `(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|` +
+	`aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?`
+
+var (
+	rxISODate      = regexp.MustCompile(`\b(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})\b`)
+	rxMonthDayYear = regexp.MustCompile(`(?i)\b` + rxMonthName + `\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(\d{4})\b`)
+	rxDayMonthYear = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?` + rxMonthName + `,?\s+(\d{4})\b`)
+	rxUnixEpoch    = regexp.MustCompile(`^\d{9,13}$`)
+)
+
+// Parse parses the date and time in commonly used formats, e.g. RFC 3339, RFC 1123,
+// "January 2, 2006", local formats like "2.1.2006" and "2006年1月2日", and Unix epoch
+// in seconds or milliseconds. The common time zone abbreviations (e.g. "EST") are
+// resolved into their offset. If the string doesn't contain time zone, it's assumed
+// as UTC.
+func Parse(str string) (time.Time, bool) {
+	str = strings.Join(strings.Fields(str), " ")
+	if str == "" {
+		return time.Time{}, false
+	}
+
+	if rxUnixEpoch.MatchString(str) {
+		epoch, _ := strconv.ParseInt(str, 10, 64)
+		if len(str) >= 12 {
+			return time.UnixMilli(epoch).UTC(), true
+		}
+		return time.Unix(epoch, 0).UTC(), true
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, str); err == nil {
+			return resolveZone(t), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// resolveZone fixes the time whose zone abbreviation is unknown to time.Parse,
+// which in that case is recorded with zero offset.
+func resolveZone(t time.Time) time.Time {
+	name, offset := t.Zone()
+	if offset != 0 {
+		return t
+	}
+
+	minutes, known := zoneOffsets[strings.ToUpper(name)]
+	if !known || minutes == 0 {
+		return t
+	}
+
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	zone := time.FixedZone(name, minutes*60)
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), zone)
+}
+
+// Find looks for the first date within text, e.g. "Posted on May 14, 2023 by John".
+// Since the date is usually written without time, only the date part is returned.
+func Find(text string) (time.Time, bool) {
+	type match struct {
+		index            int
+		year, month, day string
+		monthName        string
+	}
+
+	var matches []match
+	if m := rxISODate.FindStringSubmatchIndex(text); m != nil {
+		matches = append(matches, match{index: m[0],
+			year: text[m[2]:m[3]], month: text[m[4]:m[5]], day: text[m[6]:m[7]]})
+	}
+
+	if m := rxMonthDayYear.FindStringSubmatchIndex(text); m != nil {
+		matches = append(matches, match{index: m[0],
+			monthName: text[m[2]:m[3]], day: text[m[4]:m[5]], year: text[m[6]:m[7]]})
+	}
+
+	if m := rxDayMonthYear.FindStringSubmatchIndex(text); m != nil {
+		matches = append(matches, match{index: m[0],
+			day: text[m[2]:m[3]], monthName: text[m[4]:m[5]], year: text[m[6]:m[7]]})
+	}
+
+	// Use the first valid date within the text
+	var result time.Time
+	var resultIndex int
+	for _, m := range matches {
+		year, _ := strconv.Atoi(m.year)
+		day, _ := strconv.Atoi(m.day)
+
+		month, _ := strconv.Atoi(m.month)
+		if m.monthName != "" {
+			month = int(months[strings.ToLower(m.monthName[:3])])
+		}
+
+		t, valid := Date(year, month, day)
+		if valid && (result.IsZero() || m.index < resultIndex) {
+			result = t
+			resultIndex = m.index
+		}
+	}
+
+	return result, !result.IsZero()
+}
+
+// ParseOrFind parses the string as date and time. If it fails,
+// it will look for the date within the string instead.
+func ParseOrFind(str string) (time.Time, bool) {
+	if t, ok := Parse(str); ok {
+		return t, true
+	}
+	return Find(str)
+}
+
+// Date creates UTC date from the specified values. Returns false if the values
+// don't make a valid date, e.g. 31 February.
+func Date(year, month, day int) (time.Time, bool) {
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Day() != day {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
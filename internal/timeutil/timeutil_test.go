@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package timeutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markusmobius/go-domdistiller/internal/timeutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TimeUtil_Parse(t *testing.T) {
+	jakarta := time.FixedZone("", 7*60*60)
+	tests := map[string]time.Time{
+		"2023-05-14T10:30:00+07:00":       time.Date(2023, 5, 14, 10, 30, 0, 0, jakarta),
+		"2023-05-14T10:30:00.123Z":        time.Date(2023, 5, 14, 10, 30, 0, 123000000, time.UTC),
+		"2023-05-14T10:30:00+0700":        time.Date(2023, 5, 14, 10, 30, 0, 0, jakarta),
+		"2023-05-14 10:30:00":             time.Date(2023, 5, 14, 10, 30, 0, 0, time.UTC),
+		"2023-05-14":                      time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		"  2023/05/14 ":                   time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		"Sun, 14 May 2023 10:30:00 +0700": time.Date(2023, 5, 14, 10, 30, 0, 0, jakarta),
+		"May 14, 2023":                    time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		"14 May 2023":                     time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		"Sun, 14 May 2023 10:30:00 EST":   time.Date(2023, 5, 14, 15, 30, 0, 0, time.UTC),
+		"2023-05-14 10:30:00 WIB":         time.Date(2023, 5, 14, 10, 30, 0, 0, jakarta),
+		"1684035000":                      time.Date(2023, 5, 14, 3, 30, 0, 0, time.UTC),
+		"1684035000123":                   time.Date(2023, 5, 14, 3, 30, 0, 123000000, time.UTC),
+		"14.05.2023 10:30":                time.Date(2023, 5, 14, 10, 30, 0, 0, time.UTC),
+		"5/14/2023":                       time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		"14/5/2023":                       time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		"2023年5月14日":                      time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		"2023년 5월 14일":                    time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+	}
+
+	for str, expected := range tests {
+		result, ok := timeutil.Parse(str)
+		assert.True(t, ok, str)
+		assert.True(t, expected.Equal(result), "%s: %v", str, result)
+	}
+
+	_, ok := timeutil.Parse("yesterday")
+	assert.False(t, ok)
+}
+
+func Test_TimeUtil_Find(t *testing.T) {
+	tests := map[string]time.Time{
+		"Posted on May 14, 2023 by John":         time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC),
+		"Published: Sept. 3rd 2021 | 5 min read": time.Date(2021, 9, 3, 0, 0, 0, 0, time.UTC),
+		"Updated 2 of January, 2020":             time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		"Jakarta, 2019-12-01 - The city...":      time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC),
+		"1 March 2020 (updated March 5, 2020)":   time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for text, expected := range tests {
+		result, ok := timeutil.Find(text)
+		assert.True(t, ok, text)
+		assert.Equal(t, expected, result, text)
+	}
+
+	_, ok := timeutil.Find("Chapter 2023-02-31 is not a date")
+	assert.False(t, ok)
+
+	_, ok = timeutil.Find("No date here")
+	assert.False(t, ok)
+}
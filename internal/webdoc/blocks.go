@@ -0,0 +1,407 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package webdoc
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"github.com/markusmobius/go-domdistiller/internal/label"
+	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+	"golang.org/x/net/html"
+)
+
+// GenerateBlocks generates the structured model of the content elements in the
+// document, i.e. an ordered list of typed blocks. Like GenerateMarkdown, the blocks
+// are generated directly from the elements, so the nested tags (list, blockquote and
+// pre) are kept as nested blocks.
+func (doc *Document) GenerateBlocks() []data.ContentBlock {
+	bb := &blockBuilder{}
+	bb.frames = []*blockFrame{{tagName: "root"}}
+
+	for _, e := range doc.Elements {
+		if !e.IsContent() {
+			continue
+		}
+
+		switch element := e.(type) {
+		case *Tag:
+			bb.addTag(element)
+		case *Text:
+			bb.addText(element)
+		case *Figure:
+			bb.addFigure(element)
+		case *Image:
+			bb.addImage(element)
+		case *Table:
+			bb.addTable(element)
+		case *Embed:
+			bb.addBlock(data.ContentBlock{
+				Type: data.EmbedBlock,
+				Embed: &data.ContentEmbed{
+					Type:   element.Type,
+					ID:     element.ID,
+					Params: element.Params,
+				},
+			})
+		case *Video:
+			bb.addVideo(element)
+		}
+	}
+
+	// Close the unbalanced tags
+	for len(bb.frames) > 1 {
+		bb.closeFrame()
+	}
+
+	return bb.frames[0].blocks
+}
+
+// blockFrame is the block that currently opened by a start tag.
+type blockFrame struct {
+	tagName string
+	blocks  []data.ContentBlock
+	items   []data.ListItem
+	code    strings.Builder
+
+	// synthetic marks the list that created for list item outside of a list.
+	// There is no end tag for it, so it's closed once other block is added.
+	synthetic bool
+}
+
+type blockBuilder struct {
+	frames []*blockFrame
+}
+
+func (bb *blockBuilder) currentFrame() *blockFrame {
+	return bb.frames[len(bb.frames)-1]
+}
+
+func (bb *blockBuilder) addTag(tag *Tag) {
+	if tag.Type == TagStart {
+		// Make sure list item is always inside a list
+		if tag.Name != "li" {
+			bb.closeSyntheticList()
+		} else if !bb.isInsideList() {
+			bb.frames = append(bb.frames, &blockFrame{tagName: "ul", synthetic: true})
+		}
+		bb.frames = append(bb.frames, &blockFrame{tagName: tag.Name})
+		return
+	}
+
+	// Close every frames until the matching start tag is found.
+	for i := len(bb.frames) - 1; i > 0; i-- {
+		if bb.frames[i].tagName == tag.Name {
+			for len(bb.frames) > i {
+				bb.closeFrame()
+			}
+			return
+		}
+	}
+}
+
+// closeSyntheticList closes the list that created for stray list items, so
+// the next blocks are not put into the list.
+func (bb *blockBuilder) closeSyntheticList() {
+	if bb.currentFrame().synthetic {
+		bb.closeFrame()
+	}
+}
+
+func (bb *blockBuilder) isInsideList() bool {
+	tagName := bb.currentFrame().tagName
+	return tagName == "ul" || tagName == "ol"
+}
+
+func (bb *blockBuilder) closeFrame() {
+	frame := bb.currentFrame()
+	bb.frames = bb.frames[:len(bb.frames)-1]
+
+	switch frame.tagName {
+	case "ul", "ol":
+		if len(frame.items) > 0 {
+			bb.addBlock(data.ContentBlock{
+				Type:    data.ListBlock,
+				Ordered: frame.tagName == "ol",
+				Items:   frame.items,
+			})
+		}
+
+	case "li":
+		parent := bb.currentFrame()
+		parent.items = append(parent.items, data.ListItem{Blocks: frame.blocks})
+
+	case "blockquote":
+		if len(frame.blocks) > 0 {
+			bb.addBlock(data.ContentBlock{
+				Type:   data.QuoteBlock,
+				Blocks: frame.blocks,
+			})
+		}
+
+	case "pre":
+		bb.addCode(frame.code.String())
+	}
+}
+
+func (bb *blockBuilder) addBlock(block data.ContentBlock) {
+	bb.closeSyntheticList()
+	frame := bb.currentFrame()
+
+	// Content directly inside a list is treated as its own list item.
+	if frame.tagName == "ul" || frame.tagName == "ol" {
+		frame.items = append(frame.items, data.ListItem{Blocks: []data.ContentBlock{block}})
+		return
+	}
+
+	frame.blocks = append(frame.blocks, block)
+}
+
+func (bb *blockBuilder) addText(t *Text) {
+	if t.HasLabel(label.Title) {
+		return
+	}
+
+	root := t.cloneAndProcessNodes()
+	for i := len(bb.frames) - 1; i >= 0; i-- {
+		if bb.frames[i].tagName == "pre" {
+			bb.frames[i].code.WriteString(dom.TextContent(root))
+			return
+		}
+	}
+
+	bb.addNode(root)
+}
+
+// addNode adds the node as blocks. Inline children are merged into a
+// paragraph, while block children are added as their own blocks.
+func (bb *blockBuilder) addNode(node *html.Node) {
+	tagName := dom.TagName(node)
+	switch tagName {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(tagName[1:])
+		if text := normalizeWhitespace(domutil.InnerText(node)); text != "" {
+			bb.addBlock(data.ContentBlock{
+				Type:  data.HeadingBlock,
+				Text:  text,
+				HTML:  strings.TrimSpace(dom.InnerHTML(node)),
+				Level: level,
+			})
+		}
+		return
+
+	case "pre":
+		bb.addCode(dom.TextContent(node))
+		return
+
+	case "img", "picture":
+		if image := contentImage(node, node); image != nil {
+			bb.addBlock(data.ContentBlock{Type: data.ImageBlock, Image: image})
+		}
+		return
+	}
+
+	var inlineNodes []*html.Node
+	flush := func() {
+		bb.addParagraph(inlineNodes)
+		inlineNodes = nil
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && isBlockTag(dom.TagName(child)) {
+			flush()
+			bb.addNode(child)
+			continue
+		}
+
+		inlineNodes = append(inlineNodes, child)
+	}
+
+	flush()
+}
+
+func (bb *blockBuilder) addParagraph(nodes []*html.Node) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	var text, rawHTML strings.Builder
+	for _, node := range nodes {
+		if node.Type == html.TextNode {
+			text.WriteString(node.Data)
+		} else {
+			text.WriteString(domutil.InnerText(node))
+		}
+		rawHTML.WriteString(dom.OuterHTML(node))
+	}
+
+	plainText := normalizeWhitespace(text.String())
+	if plainText == "" {
+		return
+	}
+
+	bb.addBlock(data.ContentBlock{
+		Type: data.ParagraphBlock,
+		Text: plainText,
+		HTML: strings.TrimSpace(rawHTML.String()),
+	})
+}
+
+func (bb *blockBuilder) addCode(code string) {
+	code = strings.Trim(code, "\n")
+	if strings.TrimSpace(code) == "" {
+		return
+	}
+
+	bb.addBlock(data.ContentBlock{Type: data.CodeBlock, Text: code})
+}
+
+func (bb *blockBuilder) addImage(img *Image) {
+	image := img.ContentImage()
+	if image == nil {
+		return
+	}
+
+	bb.addBlock(data.ContentBlock{Type: data.ImageBlock, Image: image})
+}
+
+func (bb *blockBuilder) addFigure(f *Figure) {
+	image := f.ContentImage()
+	if image == nil {
+		return
+	}
+
+	bb.addBlock(data.ContentBlock{
+		Type:    data.FigureBlock,
+		Image:   image,
+		Caption: f.caption(),
+	})
+}
+
+func (bb *blockBuilder) addTable(t *Table) {
+	if t.cloned == nil {
+		t.cloned = domutil.CloneAndProcessTree(t.Element, t.PageURL)
+	}
+
+	var rows []data.TableRow
+	for _, tr := range dom.QuerySelectorAll(t.cloned, "tr") {
+		var cells []data.TableCell
+		for _, cell := range dom.Children(tr) {
+			tagName := dom.TagName(cell)
+			if tagName != "td" && tagName != "th" {
+				continue
+			}
+
+			cells = append(cells, data.TableCell{
+				Text:   normalizeWhitespace(domutil.InnerText(cell)),
+				Header: tagName == "th",
+			})
+		}
+
+		if len(cells) > 0 {
+			rows = append(rows, data.TableRow{Cells: cells})
+		}
+	}
+
+	if len(rows) > 0 {
+		bb.addBlock(data.ContentBlock{Type: data.TableBlock, Rows: rows})
+	}
+}
+
+func (bb *blockBuilder) addVideo(v *Video) {
+	video := &data.ContentVideo{
+		Width:  v.Width,
+		Height: v.Height,
+	}
+
+	if src := dom.GetAttribute(v.Element, "src"); src != "" {
+		video.Sources = append(video.Sources, data.VideoSource{
+			URL:  stringutil.CreateAbsoluteURL(src, v.PageURL),
+			Type: dom.GetAttribute(v.Element, "type"),
+		})
+	}
+
+	for _, child := range dom.Children(v.Element) {
+		if dom.TagName(child) != "source" {
+			continue
+		}
+
+		if src := dom.GetAttribute(child, "src"); src != "" {
+			video.Sources = append(video.Sources, data.VideoSource{
+				URL:  stringutil.CreateAbsoluteURL(src, v.PageURL),
+				Type: dom.GetAttribute(child, "type"),
+			})
+		}
+	}
+
+	if poster := dom.GetAttribute(v.Element, "poster"); poster != "" {
+		video.Poster = stringutil.CreateAbsoluteURL(poster, v.PageURL)
+	}
+
+	if len(video.Sources) > 0 {
+		bb.addBlock(data.ContentBlock{Type: data.VideoBlock, Video: video})
+	}
+}
+
+// contentImage creates ContentImage from the first image inside node.
+// The node is expected to be already processed, i.e. its URLs are absolute.
+// Since processing strips the size attributes, the dimensions are read from
+// the original node instead.
+func contentImage(node, original *html.Node) *data.ContentImage {
+	img := domutil.GetFirstElementByTagNameInc(node, "img")
+	if img == nil {
+		return nil
+	}
+
+	image := &data.ContentImage{
+		URL: dom.GetAttribute(img, "src"),
+		Alt: normalizeWhitespace(dom.GetAttribute(img, "alt")),
+	}
+
+	// Use srcset from <img>, or from the first <source> in <picture>.
+	image.SrcSet = dom.GetAttribute(img, "srcset")
+	if image.SrcSet == "" {
+		for _, source := range dom.GetElementsByTagName(node, "source") {
+			if image.SrcSet = dom.GetAttribute(source, "srcset"); image.SrcSet != "" {
+				break
+			}
+		}
+	}
+
+	if image.URL == "" {
+		if srcSetURLs := domutil.GetAllSrcSetURLs(node); len(srcSetURLs) > 0 {
+			image.URL = srcSetURLs[0]
+		}
+	}
+
+	if image.URL == "" {
+		return nil
+	}
+
+	if originalImg := domutil.GetFirstElementByTagNameInc(original, "img"); originalImg != nil {
+		image.Width, _ = strconv.Atoi(dom.GetAttribute(originalImg, "width"))
+		image.Height, _ = strconv.Atoi(dom.GetAttribute(originalImg, "height"))
+	}
+	return image
+}
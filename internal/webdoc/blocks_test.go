@@ -0,0 +1,178 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package webdoc_test
+
+import (
+	"encoding/json"
+	nurl "net/url"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/converter"
+	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+	"github.com/markusmobius/go-domdistiller/internal/testutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WebDoc_Blocks_TextAndNestedTags(t *testing.T) {
+	blocks := createContentDocument(`<h2>Sub <em>heading</em></h2>` +
+		`<p>Some <b>bold</b> text.</p>` +
+		`<ul><li>First item</li><li>Second item<ol><li>Nested one</li></ol></li></ul>` +
+		`<blockquote><p>Quoted paragraph.</p></blockquote>` +
+		"<pre><code>func main() {\n\tfmt.Println(\"hi\")\n}</code></pre>").GenerateBlocks()
+
+	assert.Equal(t, []data.ContentBlock{{
+		Type:  data.HeadingBlock,
+		Text:  "Sub heading",
+		HTML:  "Sub <em>heading</em>",
+		Level: 2,
+	}, {
+		Type: data.ParagraphBlock,
+		Text: "Some bold text.",
+		HTML: "Some <b>bold</b> text.",
+	}, {
+		Type: data.ListBlock,
+		Items: []data.ListItem{{
+			Blocks: []data.ContentBlock{{Type: data.ParagraphBlock, Text: "First item", HTML: "First item"}},
+		}, {
+			Blocks: []data.ContentBlock{
+				{Type: data.ParagraphBlock, Text: "Second item", HTML: "Second item"},
+				{Type: data.ListBlock, Ordered: true, Items: []data.ListItem{{
+					Blocks: []data.ContentBlock{{Type: data.ParagraphBlock, Text: "Nested one", HTML: "Nested one"}},
+				}}},
+			},
+		}},
+	}, {
+		Type:   data.QuoteBlock,
+		Blocks: []data.ContentBlock{{Type: data.ParagraphBlock, Text: "Quoted paragraph.", HTML: "Quoted paragraph."}},
+	}, {
+		Type: data.CodeBlock,
+		Text: "func main() {\n\tfmt.Println(\"hi\")\n}",
+	}}, blocks)
+}
+
+func Test_WebDoc_Blocks_TableAndMedia(t *testing.T) {
+	blocks := createContentDocument(`<table>` +
+		`<tr><th>Name</th><th>Value</th></tr>` +
+		`<tr><td>A</td><td>1</td></tr>` +
+		`</table>` +
+		`<figure><img src="/image.jpg" srcset="/image-2x.jpg 2x" width="600" height="400">` +
+		`<figcaption>The caption</figcaption></figure>` +
+		`<iframe src="https://www.youtube.com/embed/abc123"></iframe>` +
+		`<video poster="/poster.jpg"><source src="/video.webm" type="video/webm"></video>`).GenerateBlocks()
+
+	assert.Equal(t, 4, len(blocks))
+	assert.Equal(t, data.ContentBlock{
+		Type: data.TableBlock,
+		Rows: []data.TableRow{
+			{Cells: []data.TableCell{{Text: "Name", Header: true}, {Text: "Value", Header: true}}},
+			{Cells: []data.TableCell{{Text: "A"}, {Text: "1"}}},
+		},
+	}, blocks[0])
+
+	assert.Equal(t, data.ContentBlock{
+		Type: data.FigureBlock,
+		Image: &data.ContentImage{
+			URL:    "http://example.com/image.jpg",
+			SrcSet: "http://example.com/image-2x.jpg 2x",
+			Width:  600,
+			Height: 400,
+		},
+		Caption: "The caption",
+	}, blocks[1])
+
+	assert.Equal(t, data.EmbedBlock, blocks[2].Type)
+	assert.Equal(t, "youtube", blocks[2].Embed.Type)
+	assert.Equal(t, "abc123", blocks[2].Embed.ID)
+
+	assert.Equal(t, data.ContentBlock{
+		Type: data.VideoBlock,
+		Video: &data.ContentVideo{
+			Sources: []data.VideoSource{{URL: "http://example.com/video.webm", Type: "video/webm"}},
+			Poster:  "http://example.com/poster.jpg",
+		},
+	}, blocks[3])
+
+	// Make sure the JSON uses the documented keys
+	jsonBytes, err := json.Marshal(blocks[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "figure",
+		"image": {
+			"url": "http://example.com/image.jpg",
+			"srcset": "http://example.com/image-2x.jpg 2x",
+			"width": 600,
+			"height": 400
+		},
+		"caption": "The caption"
+	}`, string(jsonBytes))
+}
+
+func Test_WebDoc_Blocks_StrayListItem(t *testing.T) {
+	blocks := createContentDocument(`<li>First item</li><li>Second item</li>` +
+		`<p>Paragraph after the list</p>`).GenerateBlocks()
+
+	// Stray list items are put in the same list, while the
+	// paragraph after them is not included in the list.
+	assert.Equal(t, []data.ContentBlock{{
+		Type: data.ListBlock,
+		Items: []data.ListItem{
+			{Blocks: []data.ContentBlock{{Type: data.ParagraphBlock, Text: "First item", HTML: "First item"}}},
+			{Blocks: []data.ContentBlock{{Type: data.ParagraphBlock, Text: "Second item", HTML: "Second item"}}},
+		},
+	}, {
+		Type: data.ParagraphBlock,
+		Text: "Paragraph after the list",
+		HTML: "Paragraph after the list",
+	}}, blocks)
+}
+
+func Test_WebDoc_Blocks_ImageWithoutImg(t *testing.T) {
+	// Picture without <img> has nothing to report, so it's skipped
+	picture := dom.CreateElement("picture")
+	dom.SetInnerHTML(picture, `<source srcset="/image.webp" type="image/webp">`)
+
+	image := &webdoc.Image{Element: picture}
+	image.SetIsContent(true)
+
+	doc := webdoc.NewDocument()
+	doc.AddElements(image)
+	assert.Empty(t, doc.GenerateBlocks())
+}
+
+func createContentDocument(rawHTML string) *webdoc.Document {
+	doc := testutil.CreateHTML()
+	body := dom.QuerySelector(doc, "body")
+	dom.SetInnerHTML(body, rawHTML)
+
+	pageURL, _ := nurl.Parse("http://example.com/article/")
+	builder := webdoc.NewWebDocumentBuilder(stringutil.FastWordCounter{}, pageURL)
+	converter.NewDomConverter(converter.Default, builder, pageURL, nil).Convert(doc)
+
+	webDocument := builder.Build()
+	for _, element := range webDocument.Elements {
+		element.SetIsContent(true)
+	}
+
+	return webDocument
+}
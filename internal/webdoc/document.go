@@ -28,12 +28,19 @@ package webdoc
 
 import (
 	"bytes"
+
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
 )
 
 // Document is a simplified view of the underlying webpage. It contains the
 // logical elements (blocks of text, image + caption, video, etc).
 type Document struct {
 	Elements []Element
+
+	// LeadImage is the main image of the document, which set by the filter
+	// that looks for lead image. Nil if it's not found.
+	LeadImage *data.LeadImage
 }
 
 func NewDocument() *Document {
@@ -117,6 +124,44 @@ func (doc *Document) GetImageURLs() []string {
 	return imageURLs
 }
 
+// GetImages returns the metadata of all images inside the document, in the
+// same order as they appear in the content.
+func (doc *Document) GetImages() []data.ArticleImage {
+	images := []data.ArticleImage{}
+	addImage := func(image *data.ContentImage, caption string, originalSrc string) {
+		if image == nil {
+			return
+		}
+
+		images = append(images, data.ArticleImage{
+			ContentImage: *image,
+			Caption:      caption,
+			Candidates:   domutil.ParseSrcSet(image.SrcSet),
+			OriginalURL:  originalSrc,
+			Position:     len(images),
+		})
+	}
+
+	for _, e := range doc.Elements {
+		if !e.IsContent() {
+			continue
+		}
+
+		switch element := e.(type) {
+		case *Image:
+			addImage(element.ContentImage(), "", element.originalURL())
+		case *Figure:
+			addImage(element.ContentImage(), element.caption(), element.originalURL())
+		case *Table:
+			for _, image := range element.GetImages() {
+				addImage(image, "", "")
+			}
+		}
+	}
+
+	return images
+}
+
 func (doc *Document) getNextTextIndex(startIndex int) int {
 	for i := startIndex; i < len(doc.Elements); i++ {
 		if _, isText := doc.Elements[i].(*Text); isText {
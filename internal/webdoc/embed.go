@@ -65,16 +65,39 @@ func (e *Embed) GenerateOutput(textOnly bool) string {
 	// render some embed  as well citing security concerns. In my opinion since dom-
 	// distiller usually only used in page that we already visit, the embedded iframe
 	// should automatically be trustworthy enough.
-	// TODO: Maybe just to be save we should sanitize it.
-	tagName := dom.TagName(e.Element)
-	if tagName == "blockquote" || tagName == "iframe" {
+	// Update: the final output is sanitized by distiller, so only iframe from the allowed
+	// hosts is kept. See package sanitize.
+	switch tagName := dom.TagName(e.Element); tagName {
+	case "blockquote", "iframe":
 		domutil.StripAttributes(e.Element)
 		dom.AppendChild(embed, e.Element)
+
+	default:
+		// Other embeds, e.g. script of GitHub Gist or the container of Facebook post
+		// and CodePen pen, are never rendered in distilled page. Keep their fallback
+		// blockquote if any, otherwise link to the original content instead.
+		if blockquote := dom.QuerySelector(e.Element, "blockquote"); blockquote != nil {
+			domutil.StripAttributes(blockquote)
+			dom.AppendChild(embed, blockquote)
+		} else if url := e.Params["url"]; url != "" {
+			link := dom.CreateElement("a")
+			dom.SetAttribute(link, "href", url)
+			dom.SetTextContent(link, embedLabel(e.Type))
+			dom.AppendChild(embed, link)
+		}
 	}
 
 	return dom.OuterHTML(embed)
 }
 
+// embedLabel returns the human readable name of the embed type.
+func embedLabel(embedType string) string {
+	if label := embedLabels[embedType]; label != "" {
+		return label
+	}
+	return "Embedded " + embedType
+}
+
 func (e *Embed) String() string {
 	return fmt.Sprintf("ELEMENT %q: type=%q id=%q, is_content=%v",
 		e.ElementType(), e.Type, e.ID, e.isContent)
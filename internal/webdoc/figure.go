@@ -56,3 +56,13 @@ func (f *Figure) GenerateOutput(textOnly bool) string {
 	domutil.StripAttributes(figure)
 	return dom.OuterHTML(figure)
 }
+
+// caption returns the plain text of the figure caption.
+func (f *Figure) caption() string {
+	if f.Caption == nil {
+		return ""
+	}
+
+	figCaption := domutil.CloneAndProcessTree(f.Caption, f.PageURL)
+	return normalizeWhitespace(domutil.InnerText(figCaption))
+}
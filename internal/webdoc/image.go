@@ -31,6 +31,7 @@ import (
 	nurl "net/url"
 
 	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
 	"github.com/markusmobius/go-domdistiller/internal/domutil"
 	"github.com/markusmobius/go-domdistiller/internal/stringutil"
 	"golang.org/x/net/html"
@@ -41,6 +42,10 @@ type Image struct {
 	Element *html.Node // node for the image
 	PageURL *nurl.URL  // url of page where image is placed
 
+	// OriginalSrc is the src of the image before it's replaced
+	// by the responsive image resolver. Empty if not replaced.
+	OriginalSrc string
+
 	cloned *html.Node
 }
 
@@ -76,6 +81,20 @@ func (i *Image) GetURLs() []string {
 	return urls
 }
 
+// ContentImage returns the metadata of this image, e.g. its absolute URL, alt
+// text and dimensions. Returns nil if the image doesn't have any source URL.
+func (i *Image) ContentImage() *data.ContentImage {
+	return contentImage(i.getProcessedNode(), i.Element)
+}
+
+// originalURL returns the absolute URL of the original src.
+func (i *Image) originalURL() string {
+	if i.OriginalSrc == "" {
+		return ""
+	}
+	return stringutil.CreateAbsoluteURL(i.OriginalSrc, i.PageURL)
+}
+
 func (i *Image) getProcessedNode() *html.Node {
 	if i.cloned == nil {
 		i.cloned = i.cloneAndProcessNode()
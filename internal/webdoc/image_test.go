@@ -31,6 +31,7 @@ import (
 	"testing"
 
 	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
 	"github.com/markusmobius/go-domdistiller/internal/webdoc"
 	"github.com/stretchr/testify/assert"
 )
@@ -104,3 +105,42 @@ func Test_WebDoc_Image_PictureWithoutImg(t *testing.T) {
 	expected := `<picture><source srcset="http://example.com/image"/></picture>`
 	assert.Equal(t, expected, webImage.GenerateOutput(false))
 }
+
+func Test_WebDoc_Document_GetImages(t *testing.T) {
+	div := dom.CreateElement("div")
+	dom.SetInnerHTML(div, `<img id="lead" src="lead.jpg" alt=" Lead  image " width="800" height="600"
+		srcset="lead-400.jpg 400w, lead-800.jpg 800w">`+
+		`<img id="ad" src="ad.gif">`+
+		`<figure><img id="chart" src="chart.png"><figcaption>Monthly <b>sales</b></figcaption></figure>`)
+
+	baseURL, _ := nurl.ParseRequestURI("http://example.com/article/")
+	lead := &webdoc.Image{Element: dom.QuerySelector(div, "#lead"), PageURL: baseURL}
+	ad := &webdoc.Image{Element: dom.QuerySelector(div, "#ad"), PageURL: baseURL}
+	chart := &webdoc.Figure{
+		Image:   webdoc.Image{Element: dom.QuerySelector(div, "#chart"), PageURL: baseURL},
+		Caption: dom.QuerySelector(div, "figcaption"),
+	}
+
+	lead.SetIsContent(true)
+	chart.SetIsContent(true)
+
+	doc := webdoc.NewDocument()
+	doc.AddElements(lead, ad, chart)
+
+	images := doc.GetImages()
+	assert.Equal(t, 2, len(images))
+
+	assert.Equal(t, "http://example.com/article/lead.jpg", images[0].URL)
+	assert.Equal(t, "Lead image", images[0].Alt)
+	assert.Equal(t, 800, images[0].Width)
+	assert.Equal(t, 600, images[0].Height)
+	assert.Equal(t, 0, images[0].Position)
+	assert.Equal(t, []data.ImageCandidate{
+		{URL: "http://example.com/article/lead-400.jpg", Width: 400},
+		{URL: "http://example.com/article/lead-800.jpg", Width: 800},
+	}, images[0].Candidates)
+
+	assert.Equal(t, "http://example.com/article/chart.png", images[1].URL)
+	assert.Equal(t, "Monthly sales", images[1].Caption)
+	assert.Equal(t, 1, images[1].Position)
+}
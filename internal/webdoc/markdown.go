@@ -0,0 +1,573 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package webdoc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"github.com/markusmobius/go-domdistiller/internal/label"
+	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+	"golang.org/x/net/html"
+)
+
+var (
+	rxMarkdownWhitespace = regexp.MustCompile(`\s+`)
+	rxMarkdownBlockStart = regexp.MustCompile(`(?m)^(\s*)([#>+\-=])`)
+	rxMarkdownListStart  = regexp.MustCompile(`(?m)^(\s*\d+)([.)])`)
+
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`)
+
+	// embedURLFormats is the URL format of each embed type. The
+	// "{id}" placeholder will be replaced by the ID of the embed.
+	embedURLFormats = map[string]string{
+		"youtube": "https://www.youtube.com/watch?v={id}",
+		"vimeo":   "https://vimeo.com/{id}",
+		"twitter": "https://twitter.com/i/status/{id}",
+	}
+
+	embedLabels = map[string]string{
+		"youtube":       "YouTube video",
+		"vimeo":         "Vimeo video",
+		"twitter":       "Tweet",
+		"instagram":     "Instagram post",
+		"facebook":      "Facebook post",
+		"tiktok":        "TikTok video",
+		"mastodon":      "Mastodon post",
+		"bluesky":       "Bluesky post",
+		"spotify":       "Spotify",
+		"soundcloud":    "SoundCloud",
+		"applepodcasts": "Apple Podcasts",
+		"gist":          "GitHub Gist",
+		"codepen":       "CodePen",
+		"googlemaps":    "Google Maps",
+	}
+)
+
+// GenerateMarkdown generates CommonMark output of the content elements in the
+// document. The output is generated directly from the elements, so nested tags
+// (list, blockquote and pre) are kept as Markdown structures, tables are rendered
+// as GitHub Flavored Markdown tables, while images, figures, embeds and videos are
+// rendered as images or links. Links and images whose URL is rejected by isAllowedURL
+// are dropped, so it must not be nil.
+func (doc *Document) GenerateMarkdown(isAllowedURL func(url string, isImage bool) bool) string {
+	mw := &markdownWriter{isAllowedURL: isAllowedURL}
+	for _, e := range doc.Elements {
+		if !e.IsContent() {
+			continue
+		}
+
+		switch element := e.(type) {
+		case *Tag:
+			mw.writeTag(element)
+		case *Text:
+			mw.writeText(element)
+		case *Figure:
+			mw.writeFigure(element)
+		case *Image:
+			mw.writeImage(element)
+		case *Table:
+			mw.writeTable(element)
+		case *Embed:
+			mw.writeEmbed(element)
+		case *Video:
+			mw.writeVideo(element)
+		}
+	}
+
+	return mw.String()
+}
+
+type markdownList struct {
+	ordered bool
+	counter int
+	marker  string
+}
+
+// markdownWriter keeps the state of nested structures (lists, blockquotes and
+// preformatted text) while the document elements are written one by one.
+type markdownWriter struct {
+	isAllowedURL func(url string, isImage bool) bool
+
+	buffer     strings.Builder
+	lists      []*markdownList
+	quoteDepth int
+	preDepth   int
+	preBuffer  strings.Builder
+
+	pendingMarker  bool
+	lastListDepth  int
+	lastQuoteDepth int
+}
+
+func (mw *markdownWriter) String() string {
+	return strings.TrimSpace(mw.buffer.String())
+}
+
+func (mw *markdownWriter) writeTag(tag *Tag) {
+	isStart := tag.Type == TagStart
+	switch tag.Name {
+	case "ul", "ol":
+		if isStart {
+			mw.lists = append(mw.lists, &markdownList{ordered: tag.Name == "ol"})
+		} else if len(mw.lists) > 0 {
+			mw.lists = mw.lists[:len(mw.lists)-1]
+		}
+
+	case "li":
+		if isStart && len(mw.lists) > 0 {
+			list := mw.lists[len(mw.lists)-1]
+			list.counter++
+			list.marker = "- "
+			if list.ordered {
+				list.marker = strconv.Itoa(list.counter) + ". "
+			}
+			mw.pendingMarker = true
+		} else {
+			mw.pendingMarker = false
+		}
+
+	case "blockquote":
+		if isStart {
+			mw.quoteDepth++
+		} else if mw.quoteDepth > 0 {
+			mw.quoteDepth--
+		}
+
+	case "pre":
+		if isStart {
+			mw.preDepth++
+		} else if mw.preDepth > 0 {
+			mw.preDepth--
+			if mw.preDepth == 0 {
+				mw.writeCodeBlock(mw.preBuffer.String())
+				mw.preBuffer.Reset()
+			}
+		}
+	}
+}
+
+func (mw *markdownWriter) writeText(t *Text) {
+	if t.HasLabel(label.Title) {
+		return
+	}
+
+	root := t.cloneAndProcessNodes()
+	if mw.preDepth > 0 {
+		mw.preBuffer.WriteString(dom.TextContent(root))
+		return
+	}
+
+	mw.writeNode(root)
+}
+
+func (mw *markdownWriter) writeImage(img *Image) {
+	mw.writeBlock(mw.renderImage(img.getProcessedNode(), ""))
+}
+
+func (mw *markdownWriter) writeFigure(f *Figure) {
+	caption := ""
+	if f.Caption != nil {
+		figCaption := domutil.CloneAndProcessTree(f.Caption, f.PageURL)
+		caption = normalizeWhitespace(domutil.InnerText(figCaption))
+	}
+
+	image := mw.renderImage(f.getProcessedNode(), caption)
+	if image != "" && caption != "" {
+		image += "\n*" + markdownEscaper.Replace(caption) + "*"
+	}
+
+	mw.writeBlock(image)
+}
+
+func (mw *markdownWriter) writeTable(t *Table) {
+	if t.cloned == nil {
+		t.cloned = domutil.CloneAndProcessTree(t.Element, t.PageURL)
+	}
+
+	var rows [][]string
+	nColumns := 0
+	for _, tr := range dom.QuerySelectorAll(t.cloned, "tr") {
+		var cells []string
+		for _, cell := range dom.Children(tr) {
+			if tagName := dom.TagName(cell); tagName != "td" && tagName != "th" {
+				continue
+			}
+
+			content := mw.renderInline(cell, false)
+			content = normalizeWhitespace(content)
+			content = strings.ReplaceAll(content, "|", `\|`)
+			cells = append(cells, content)
+		}
+
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+			if len(cells) > nColumns {
+				nColumns = len(cells)
+			}
+		}
+	}
+
+	if len(rows) == 0 {
+		return
+	}
+
+	// GFM table requires header row, so the first row is used as header.
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < nColumns {
+			row = append(row, "")
+		}
+
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", nColumns))
+		}
+	}
+
+	mw.writeBlock(strings.Join(lines, "\n"))
+}
+
+func (mw *markdownWriter) writeEmbed(e *Embed) {
+	url := ""
+	if format, exist := embedURLFormats[e.Type]; exist && e.ID != "" {
+		url = strings.ReplaceAll(format, "{id}", e.ID)
+	} else if e.Params["url"] != "" {
+		url = e.Params["url"]
+	} else if e.Element != nil {
+		url = dom.GetAttribute(e.Element, "src")
+		if url == "" {
+			url = dom.GetAttribute(e.Element, "href")
+		}
+	}
+
+	if url == "" || !mw.isAllowedURL(url, false) {
+		return
+	}
+
+	text := embedLabel(e.Type)
+	mw.writeBlock("[" + markdownEscaper.Replace(text) + "](" + markdownURL(url) + ")")
+}
+
+func (mw *markdownWriter) writeVideo(v *Video) {
+	src := dom.GetAttribute(v.Element, "src")
+	if src == "" {
+		for _, child := range dom.Children(v.Element) {
+			if dom.TagName(child) == "source" {
+				if src = dom.GetAttribute(child, "src"); src != "" {
+					break
+				}
+			}
+		}
+	}
+
+	src = stringutil.CreateAbsoluteURL(src, v.PageURL)
+	if src == "" || !mw.isAllowedURL(src, false) {
+		return
+	}
+
+	text := "Video"
+	poster := dom.GetAttribute(v.Element, "poster")
+	if poster = stringutil.CreateAbsoluteURL(poster, v.PageURL); poster != "" && mw.isAllowedURL(poster, true) {
+		text = "![Video](" + markdownURL(poster) + ")"
+	}
+
+	mw.writeBlock("[" + text + "](" + markdownURL(src) + ")")
+}
+
+// writeNode writes the node as Markdown blocks. Inline children are merged into
+// a paragraph, while block children are written as their own blocks.
+func (mw *markdownWriter) writeNode(node *html.Node) {
+	tagName := dom.TagName(node)
+	switch tagName {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(tagName[1:])
+		content := normalizeWhitespace(mw.renderInline(node, false))
+		if content != "" {
+			mw.writeBlock(strings.Repeat("#", level) + " " + content)
+		}
+		return
+
+	case "pre":
+		mw.writeCodeBlock(dom.TextContent(node))
+		return
+
+	case "hr":
+		mw.writeBlock("---")
+		return
+
+	case "img", "picture":
+		mw.writeBlock(mw.renderImage(node, ""))
+		return
+	}
+
+	paragraph := ""
+	flush := func() {
+		mw.writeParagraph(paragraph)
+		paragraph = ""
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && isBlockTag(dom.TagName(child)) {
+			flush()
+			mw.writeNode(child)
+			continue
+		}
+
+		paragraph += mw.renderInline(child, true)
+	}
+
+	flush()
+}
+
+func (mw *markdownWriter) writeParagraph(paragraph string) {
+	var lines []string
+	for _, line := range strings.Split(paragraph, "\n") {
+		if line = normalizeWhitespace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	// Line breaks are written as backslash at the end of line,
+	// which is the hard line break in CommonMark.
+	paragraph = strings.Join(lines, "\\\n")
+
+	// Make sure the paragraph is not accidentally treated as
+	// heading, quote, list item or thematic break.
+	paragraph = rxMarkdownBlockStart.ReplaceAllString(paragraph, `$1\$2`)
+	paragraph = rxMarkdownListStart.ReplaceAllString(paragraph, `$1\$2`)
+	mw.writeBlock(paragraph)
+}
+
+func (mw *markdownWriter) writeCodeBlock(code string) {
+	code = strings.Trim(code, "\n")
+	if strings.TrimSpace(code) == "" {
+		return
+	}
+
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	mw.writeBlock(fence + "\n" + code + "\n" + fence)
+}
+
+// writeBlock writes a block into the buffer, prefixed by the
+// blockquote markers and the indentation of the list items.
+func (mw *markdownWriter) writeBlock(block string) {
+	block = strings.Trim(block, "\n")
+	if strings.TrimSpace(block) == "" {
+		return
+	}
+
+	// Write separator from the previous block. Blocks inside the same list
+	// are separated by a single newline to keep the list tight.
+	quotePrefix := strings.Repeat("> ", mw.quoteDepth)
+	if mw.buffer.Len() > 0 {
+		switch {
+		case len(mw.lists) > 0 && mw.lastListDepth > 0:
+			mw.buffer.WriteString("\n")
+		case mw.quoteDepth > 0 && mw.lastQuoteDepth == mw.quoteDepth:
+			mw.buffer.WriteString("\n" + strings.TrimSpace(quotePrefix) + "\n")
+		default:
+			mw.buffer.WriteString("\n\n")
+		}
+	}
+
+	// Prepare indentation for list items
+	indent := ""
+	firstIndent := ""
+	for i, list := range mw.lists {
+		width := len(list.marker)
+		if width == 0 {
+			width = 2
+		}
+
+		if i == len(mw.lists)-1 && mw.pendingMarker {
+			firstIndent = indent + list.marker
+		}
+		indent += strings.Repeat(" ", width)
+	}
+
+	if firstIndent == "" {
+		firstIndent = indent
+	}
+
+	for i, line := range strings.Split(block, "\n") {
+		if i > 0 {
+			mw.buffer.WriteString("\n")
+		}
+
+		prefix := indent
+		if i == 0 {
+			prefix = firstIndent
+		}
+
+		mw.buffer.WriteString(strings.TrimRight(quotePrefix+prefix+line, " "))
+	}
+
+	mw.pendingMarker = false
+	mw.lastListDepth = len(mw.lists)
+	mw.lastQuoteDepth = mw.quoteDepth
+}
+
+// renderInline renders the node and its children as inline Markdown.
+// If allowLineBreak is false, the <br> elements are rendered as whitespace.
+func (mw *markdownWriter) renderInline(node *html.Node, allowLineBreak bool) string {
+	switch node.Type {
+	case html.TextNode:
+		return markdownEscaper.Replace(rxMarkdownWhitespace.ReplaceAllString(node.Data, " "))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	childContent := func() string {
+		var sb strings.Builder
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			sb.WriteString(mw.renderInline(child, allowLineBreak))
+		}
+		return sb.String()
+	}
+
+	switch tagName := dom.TagName(node); tagName {
+	case "br":
+		if allowLineBreak {
+			return "\n"
+		}
+		return " "
+
+	case "img", "picture":
+		return mw.renderImage(node, "")
+
+	case "a":
+		content := childContent()
+		href := dom.GetAttribute(node, "href")
+		if href == "" || !mw.isAllowedURL(href, false) || strings.TrimSpace(content) == "" {
+			return content
+		}
+		return "[" + strings.TrimSpace(content) + "](" + markdownURL(href) + ")"
+
+	case "b", "strong":
+		return wrapMarkdownInline(childContent(), "**")
+
+	case "i", "em", "cite", "dfn":
+		return wrapMarkdownInline(childContent(), "*")
+
+	case "del", "s", "strike":
+		return wrapMarkdownInline(childContent(), "~~")
+
+	case "code", "kbd", "samp", "tt":
+		code := rxMarkdownWhitespace.ReplaceAllString(dom.TextContent(node), " ")
+		if strings.TrimSpace(code) == "" {
+			return code
+		}
+
+		fence := "`"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+
+		if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+			code = " " + code + " "
+		}
+		return fence + code + fence
+
+	default:
+		content := childContent()
+		if isBlockTag(tagName) {
+			content = " " + content + " "
+		}
+		return content
+	}
+}
+
+// wrapMarkdownInline wraps the content with the marker, while keeping the
+// surrounding whitespaces outside of the marker as required by CommonMark.
+func wrapMarkdownInline(content, marker string) string {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return content
+	}
+
+	start := strings.Index(content, trimmed)
+	return content[:start] + marker + trimmed + marker + content[start+len(trimmed):]
+}
+
+// renderImage renders the first image inside node as Markdown image.
+func (mw *markdownWriter) renderImage(node *html.Node, fallbackAlt string) string {
+	img := domutil.GetFirstElementByTagNameInc(node, "img")
+	if img == nil {
+		return ""
+	}
+
+	src := dom.GetAttribute(img, "src")
+	if src == "" {
+		if srcSetURLs := domutil.GetAllSrcSetURLs(node); len(srcSetURLs) > 0 {
+			src = srcSetURLs[0]
+		}
+	}
+
+	if src == "" || !mw.isAllowedURL(src, true) {
+		return ""
+	}
+
+	alt := normalizeWhitespace(dom.GetAttribute(img, "alt"))
+	if alt == "" {
+		alt = fallbackAlt
+	}
+
+	return "![" + markdownEscaper.Replace(alt) + "](" + markdownURL(src) + ")"
+}
+
+// markdownURL makes sure URL doesn't break the Markdown link syntax.
+func markdownURL(url string) string {
+	url = strings.TrimSpace(url)
+	url = strings.ReplaceAll(url, " ", "%20")
+	url = strings.ReplaceAll(url, "(", "%28")
+	url = strings.ReplaceAll(url, ")", "%29")
+	return url
+}
+
+func normalizeWhitespace(str string) string {
+	return strings.TrimSpace(rxMarkdownWhitespace.ReplaceAllString(str, " "))
+}
+
+func isBlockTag(tagName string) bool {
+	switch tagName {
+	case "address", "article", "aside", "blockquote", "dd", "div", "dl", "dt",
+		"fieldset", "figcaption", "figure", "footer", "form", "h1", "h2", "h3",
+		"h4", "h5", "h6", "header", "hr", "li", "main", "nav", "ol", "p", "pre",
+		"section", "table", "ul":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package webdoc_test
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/sanitize"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WebDoc_Markdown_InlineFormatting(t *testing.T) {
+	markdown := generateMarkdown(`<h2>Sub <em>heading</em></h2>` +
+		`<p>Some <b>bold</b> and <i>italic</i> text with <a href="http://example.com/link">a link</a> ` +
+		`and <code>x*y</code>.<br>Next line</p>` +
+		`<p>1. Not a list</p>`)
+
+	assert.Equal(t, "## Sub *heading*\n\n"+
+		"Some **bold** and *italic* text with [a link](http://example.com/link) and `x*y`.\\\n"+
+		"Next line\n\n"+
+		"1\\. Not a list", markdown)
+}
+
+func Test_WebDoc_Markdown_NestedTags(t *testing.T) {
+	markdown := generateMarkdown(`<ul><li>First item</li>` +
+		`<li>Second <b>item</b><ol><li>Nested one</li><li>Nested two</li></ol></li></ul>` +
+		`<blockquote><p>Quoted paragraph one.</p><p>Quoted paragraph two.</p></blockquote>` +
+		"<pre><code>func main() {\n\tfmt.Println(\"hi\")\n}</code></pre>")
+
+	assert.Equal(t, "- First item\n"+
+		"- Second **item**\n"+
+		"  1. Nested one\n"+
+		"  2. Nested two\n\n"+
+		"> Quoted paragraph one.\n"+
+		">\n"+
+		"> Quoted paragraph two.\n\n"+
+		"```\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```", markdown)
+}
+
+func Test_WebDoc_Markdown_Table(t *testing.T) {
+	markdown := generateMarkdown(`<table>` +
+		`<tr><th>Name</th><th>Value</th></tr>` +
+		`<tr><td>A|B</td><td>1</td></tr>` +
+		`<tr><td>C</td></tr>` +
+		`</table>`)
+
+	assert.Equal(t, "| Name | Value |\n"+
+		"| --- | --- |\n"+
+		"| A\\|B | 1 |\n"+
+		"| C |  |", markdown)
+}
+
+func Test_WebDoc_Markdown_Media(t *testing.T) {
+	markdown := generateMarkdown(`<figure><img src="/image.jpg"><figcaption>The caption</figcaption></figure>` +
+		`<img src="/other.jpg" alt="Other image">` +
+		`<iframe src="https://www.youtube.com/embed/abc123"></iframe>` +
+		`<video src="/video.mp4" poster="/poster.jpg"></video>`)
+
+	assert.Equal(t, "![The caption](http://example.com/image.jpg)\n"+
+		"*The caption*\n\n"+
+		"![Other image](http://example.com/other.jpg)\n\n"+
+		"[YouTube video](https://www.youtube.com/watch?v=abc123)\n\n"+
+		"[![Video](http://example.com/poster.jpg)](http://example.com/video.mp4)", markdown)
+}
+
+func Test_WebDoc_Markdown_Embeds(t *testing.T) {
+	markdown := generateMarkdown(`<p>Before the embeds.</p>` +
+		`<iframe src="https://open.spotify.com/embed/episode/0abc"></iframe>` +
+		`<blockquote class="mastodon-embed" data-embed-url="https://mastodon.social/@someone/42/embed">` +
+		`<p>Hello world</p></blockquote>` +
+		`<p>After the embeds.</p>`)
+
+	assert.Equal(t, "Before the embeds.\n\n"+
+		"[Spotify](https://open.spotify.com/episode/0abc)\n\n"+
+		"[Mastodon post](https://mastodon.social/@someone/42)\n\n"+
+		"After the embeds.", markdown)
+}
+
+func Test_WebDoc_Markdown_UnsafeURLs(t *testing.T) {
+	markdown := generateMarkdown(`<p><a href="JavaScript:alert(1)">Script</a> ` +
+		`<a href=" vbscript:msgbox(1)">VBScript</a> ` +
+		`<a href="data:text/html;base64,PHNjcmlwdD4=">Data</a> ` +
+		`<a href="/page">Page</a></p>` +
+		`<img src="JavaScript:alert(1)" alt="Bad">` +
+		`<img src="/good.jpg" alt="Good">`)
+
+	assert.Equal(t, "Script VBScript Data [Page](/page)\n\n"+
+		"![Good](http://example.com/good.jpg)", markdown)
+}
+
+func generateMarkdown(rawHTML string) string {
+	return createContentDocument(rawHTML).GenerateMarkdown(sanitize.Strict().URLFilter())
+}
@@ -31,6 +31,7 @@ import (
 	nurl "net/url"
 
 	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
 	"github.com/markusmobius/go-domdistiller/internal/domutil"
 	"golang.org/x/net/html"
 )
@@ -83,3 +84,31 @@ func (t *Table) String() string {
 	return fmt.Sprintf("ELEMENT %q: html=%q, is_content=%v",
 		t.ElementType(), dom.OuterHTML(t.Element), t.isContent)
 }
+
+// GetImages returns the metadata of all images inside the table.
+func (t *Table) GetImages() []*data.ContentImage {
+	if t.cloned == nil {
+		t.cloned = domutil.CloneAndProcessTree(t.Element, t.PageURL)
+	}
+
+	// The cloned tree keeps the structure of the original table, so the
+	// original image can be found using the same index.
+	images := []*data.ContentImage{}
+	originals := dom.GetElementsByTagName(t.Element, "img")
+	for i, img := range dom.GetElementsByTagName(t.cloned, "img") {
+		if i >= len(originals) {
+			break
+		}
+
+		node, original := img, originals[i]
+		if parent := img.Parent; parent != nil && dom.TagName(parent) == "picture" {
+			node, original = parent, original.Parent
+		}
+
+		if image := contentImage(node, original); image != nil {
+			images = append(images, image)
+		}
+	}
+
+	return images
+}
@@ -64,6 +64,23 @@ func (t *Text) GenerateOutput(textOnly bool) string {
 		return ""
 	}
 
+	// Since there are tag elements that are being wrapped by a pair of Tags,
+	// we only need to get the innerHTML, otherwise these tags would be duplicated.
+	clonedRoot := t.cloneAndProcessNodes()
+	if textOnly {
+		return domutil.InnerText(clonedRoot)
+	}
+
+	if CanBeNested(dom.TagName(clonedRoot)) {
+		return dom.InnerHTML(clonedRoot)
+	}
+
+	return dom.OuterHTML(clonedRoot)
+}
+
+// cloneAndProcessNodes clones the text nodes along with their parent elements
+// that needed to keep the formatting and structure of the text.
+func (t *Text) cloneAndProcessNodes() *html.Node {
 	// TODO: Instead of doing this next part, in the future track font size weight
 	// and etc. and wrap the nodes in a "p" tag.
 	clonedRoot := domutil.TreeClone(t.GetTextNodes())
@@ -113,18 +130,7 @@ func (t *Text) GenerateOutput(textOnly bool) string {
 	domutil.MakeAllLinksAbsolute(clonedRoot, t.PageURL)
 	domutil.StripAttributes(clonedRoot)
 	// TODO: if we allow images in WebText later, add StripImageElements().
-
-	// Since there are tag elements that are being wrapped by a pair of Tags,
-	// we only need to get the innerHTML, otherwise these tags would be duplicated.
-	if textOnly {
-		return domutil.InnerText(clonedRoot)
-	}
-
-	if CanBeNested(dom.TagName(clonedRoot)) {
-		return dom.InnerHTML(clonedRoot)
-	}
-
-	return dom.OuterHTML(clonedRoot)
+	return clonedRoot
 }
 
 func (t *Text) AddLabel(s string) {
@@ -0,0 +1,227 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package distiller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nurl "net/url"
+	"strings"
+	"time"
+
+	"github.com/go-shiori/dom"
+)
+
+// DefaultMaxPages is the maximum number of pages that followed by
+// ApplyForURLMultiPage when Options.MaxPages is not specified.
+const DefaultMaxPages = 10
+
+// MultiPageResult is the output of distiller for article that splitted into several
+// partial pages. The embedded Result contains the merged content of all pages:
+//   - URL, Title, Authors, PublishedTime, ModifiedTime, MarkupInfo, StructuredData,
+//     LeadImage, Language and Encoding are taken from the first page;
+//   - PaginationInfo contains the previous page of the first page and the next page of
+//     the last page, so the next page is not empty if the page limit is reached or the
+//     next page is failed;
+//   - Node, Text, Markdown and ContentBlocks contain the content of each page in order;
+//   - WordCount is the sum of word count in all pages;
+//   - ContentImages and Images are the list of unique images in all pages;
+//   - TimingInfo is the sum of timing in all pages.
+type MultiPageResult struct {
+	Result
+
+	// Pages is the distillation result of each page in order.
+	Pages []*Result
+
+	// PageError is the error that stops following the next pages, e.g. the next page
+	// returns 404. The pages before the failed one are still merged into the result.
+	PageError error
+}
+
+// ApplyForURLMultiPage runs distiller for the specified URL, then follows the next page
+// link which found by the pagination finder until there are no more pages, the page has
+// been visited before or the page limit in Options.MaxPages is reached. The timeout is
+// used for each page and only used when Options.Fetcher is nil. Error is only returned
+// if the first page is failed, while failure in the next pages is recorded in the
+// MultiPageResult.PageError.
+func ApplyForURLMultiPage(url string, timeout time.Duration, opts *Options) (*MultiPageResult, error) {
+	return applyForURLMultiPage(context.Background(), url, opts, func(ctx context.Context, url string, opts *Options) (*Result, error) {
+		return ApplyForURL(url, timeout, opts)
+	})
+}
+
+// ApplyForURLMultiPageContext is like ApplyForURLMultiPage, but the context is used
+// to cancel the download and distillation of all pages.
+func ApplyForURLMultiPageContext(ctx context.Context, url string, opts *Options) (*MultiPageResult, error) {
+	return applyForURLMultiPage(ctx, url, opts, ApplyForURLContext)
+}
+
+type applyForURLFunc func(ctx context.Context, url string, opts *Options) (*Result, error)
+
+func applyForURLMultiPage(ctx context.Context, url string, opts *Options, apply applyForURLFunc) (*MultiPageResult, error) {
+	// Make sure URL absolute, otherwise no page will be distilled
+	if _, err := nurl.ParseRequestURI(url); err != nil {
+		return nil, err
+	}
+
+	// Create default options
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	// Distill each page
+	var pages []*Result
+	var pageErr error
+	seenURLs := make(map[string]struct{})
+	for nextURL := url; nextURL != "" && len(pages) < maxPages; {
+		if _, seen := seenURLs[normalizePageURL(nextURL)]; seen {
+			break
+		}
+		seenURLs[normalizePageURL(nextURL)] = struct{}{}
+
+		// Each page uses its own options since ApplyForURL modifies the original URL.
+		pageOpts := *opts
+		page, err := apply(ctx, nextURL, &pageOpts)
+		if err != nil {
+			// Keep the pages that already distilled, unless it's the first
+			// page or the whole process is cancelled.
+			var cancelledErr *CancelledError
+			if len(pages) == 0 || errors.As(err, &cancelledErr) {
+				return nil, err
+			}
+
+			pageErr = fmt.Errorf("failed to distill page %s: %w", nextURL, err)
+			break
+		}
+
+		// The page might be redirected, so mark the final URL as seen as well.
+		seenURLs[normalizePageURL(page.URL)] = struct{}{}
+		pages = append(pages, page)
+		nextURL = page.PaginationInfo.NextPage
+	}
+
+	result, err := mergePages(pages)
+	if err != nil {
+		return nil, err
+	}
+
+	result.PageError = pageErr
+	return result, nil
+}
+
+func mergePages(pages []*Result) (*MultiPageResult, error) {
+	if len(pages) == 0 {
+		return nil, errors.New("no page to merge")
+	}
+
+	first, last := pages[0], pages[len(pages)-1]
+
+	result := &MultiPageResult{Pages: pages}
+	result.URL = first.URL
+	result.Title = first.Title
+	result.Authors = first.Authors
+	result.PublishedTime = first.PublishedTime
+	result.ModifiedTime = first.ModifiedTime
+	result.MarkupInfo = first.MarkupInfo
+	result.StructuredData = first.StructuredData
+	result.LeadImage = first.LeadImage
+	result.Language = first.Language
+	result.Encoding = first.Encoding
+	result.PaginationInfo.PrevPage = first.PaginationInfo.PrevPage
+	result.PaginationInfo.NextPage = last.PaginationInfo.NextPage
+	result.Node = dom.CreateElement("div")
+
+	// Make sure the next page of the last page is not the one we've
+	// visited before, e.g. when the pages are cyclic.
+	for _, page := range pages {
+		if normalizePageURL(page.URL) == normalizePageURL(result.PaginationInfo.NextPage) {
+			result.PaginationInfo.NextPage = ""
+			break
+		}
+	}
+
+	var texts, markdowns []string
+	seenImages := make(map[string]struct{})
+	seenArticleImages := make(map[string]struct{})
+	for _, page := range pages {
+		result.WordCount += page.WordCount
+
+		if page.Text != "" {
+			texts = append(texts, page.Text)
+		}
+
+		if page.Markdown != "" {
+			markdowns = append(markdowns, page.Markdown)
+		}
+
+		result.ContentBlocks = append(result.ContentBlocks, page.ContentBlocks...)
+
+		if page.Node != nil {
+			for _, child := range dom.ChildNodes(page.Node) {
+				dom.AppendChild(result.Node, dom.Clone(child, true))
+			}
+		}
+
+		for _, image := range page.ContentImages {
+			if _, seen := seenImages[image]; !seen {
+				seenImages[image] = struct{}{}
+				result.ContentImages = append(result.ContentImages, image)
+			}
+		}
+
+		for _, image := range page.Images {
+			if _, seen := seenArticleImages[image.URL]; !seen {
+				seenArticleImages[image.URL] = struct{}{}
+				image.Position = len(result.Images)
+				result.Images = append(result.Images, image)
+			}
+		}
+
+		timing := page.TimingInfo
+		result.TimingInfo.MarkupParsingTime += timing.MarkupParsingTime
+		result.TimingInfo.DocumentConstructionTime += timing.DocumentConstructionTime
+		result.TimingInfo.ArticleProcessingTime += timing.ArticleProcessingTime
+		result.TimingInfo.FormattingTime += timing.FormattingTime
+		result.TimingInfo.TotalTime += timing.TotalTime
+	}
+
+	result.Text = strings.Join(texts, "\n")
+	result.Markdown = strings.Join(markdowns, "\n\n")
+	return result, nil
+}
+
+// normalizePageURL removes the fragment from URL, since
+// it doesn't change the page that will be downloaded.
+func normalizePageURL(url string) string {
+	parsedURL, err := nurl.Parse(url)
+	if err != nil {
+		return url
+	}
+
+	parsedURL.Fragment = ""
+	return parsedURL.String()
+}
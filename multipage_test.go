@@ -0,0 +1,147 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package distiller_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	distiller "github.com/markusmobius/go-domdistiller"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MultiPage_FollowNextPages(t *testing.T) {
+	server := newMultiPageServer(3, false)
+	defer server.Close()
+
+	result, err := distiller.ApplyForURLMultiPage(server.URL+"/article/1", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(result.Pages))
+	assert.Equal(t, server.URL+"/article/1", result.URL)
+	assert.Equal(t, "", result.PaginationInfo.NextPage)
+
+	wordCount := 0
+	for i, page := range result.Pages {
+		assert.Equal(t, fmt.Sprintf("%s/article/%d", server.URL, i+1), page.URL)
+		assert.True(t, strings.Contains(result.Text, page.Text))
+		wordCount += page.WordCount
+	}
+	assert.Equal(t, wordCount, result.WordCount)
+
+	// Image that used in every page only appears once.
+	assert.Equal(t, 4, len(result.ContentImages))
+	assert.Equal(t, 4, len(result.Images))
+	for i, image := range result.Images {
+		assert.Equal(t, i, image.Position)
+	}
+}
+
+func Test_MultiPage_CyclicPages(t *testing.T) {
+	server := newMultiPageServer(3, true)
+	defer server.Close()
+
+	result, err := distiller.ApplyForURLMultiPage(server.URL+"/article/1", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(result.Pages))
+	assert.Equal(t, "", result.PaginationInfo.NextPage)
+}
+
+func Test_MultiPage_MaxPages(t *testing.T) {
+	server := newMultiPageServer(5, false)
+	defer server.Close()
+
+	opts := &distiller.Options{MaxPages: 2}
+	result, err := distiller.ApplyForURLMultiPage(server.URL+"/article/1", 0, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.Pages))
+	assert.Equal(t, server.URL+"/article/3", result.PaginationInfo.NextPage)
+}
+
+func Test_MultiPage_InvalidURL(t *testing.T) {
+	for _, url := range []string{"", "article/1"} {
+		result, err := distiller.ApplyForURLMultiPage(url, 0, nil)
+		assert.Error(t, err, url)
+		assert.Nil(t, result, url)
+
+		result, err = distiller.ApplyForURLMultiPageContext(context.Background(), url, nil)
+		assert.Error(t, err, url)
+		assert.Nil(t, result, url)
+	}
+}
+
+func Test_MultiPage_FailedNextPage(t *testing.T) {
+	server := newMultiPageServer(3, false)
+	defer server.Close()
+
+	// Second page returns 404, so only the first page is used
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article/2", http.NotFound)
+	mux.Handle("/", server.Config.Handler)
+	brokenServer := httptest.NewServer(mux)
+	defer brokenServer.Close()
+
+	result, err := distiller.ApplyForURLMultiPage(brokenServer.URL+"/article/1", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Pages))
+	assert.Error(t, result.PageError)
+	assert.Contains(t, result.PageError.Error(), brokenServer.URL+"/article/2")
+	assert.Equal(t, brokenServer.URL+"/article/2", result.PaginationInfo.NextPage)
+	assert.Contains(t, result.Text, "page number 1")
+	assert.Equal(t, result.Pages[0].WordCount, result.WordCount)
+
+	// Failure in the first page is still returned as error
+	_, err = distiller.ApplyForURLMultiPage(brokenServer.URL+"/article/2", 0, nil)
+	assert.Error(t, err)
+}
+
+// newMultiPageServer creates server for an article that splitted into several pages.
+// If cyclic is true, the last page will have link to the first page as its next page.
+func newMultiPageServer(nPages int, cyclic bool) *httptest.Server {
+	mux := http.NewServeMux()
+	for i := 1; i <= nPages; i++ {
+		nextPage := ""
+		if i < nPages {
+			nextPage = fmt.Sprintf(`<a href="/article/%d">Next page</a>`, i+1)
+		} else if cyclic {
+			nextPage = `<a href="/article/1">Next page</a>`
+		}
+
+		paragraphs := strings.Repeat(fmt.Sprintf(`<p>This is the content of page number %d. Lorem `+
+			`ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut `+
+			`labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation.</p>`, i), 3)
+
+		page := `<html><head><title>Multi page article</title></head><body>` +
+			`<img src="/shared.jpg" width="600" height="400"/>` + paragraphs +
+			fmt.Sprintf(`<img src="/image-%d.jpg" width="600" height="400"/>`, i) + paragraphs +
+			nextPage + `</body></html>`
+
+		mux.HandleFunc(fmt.Sprintf("/article/%d", i), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(page))
+		})
+	}
+
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package pipeline
+
+import (
+	"github.com/markusmobius/go-domdistiller/internal/filter/heuristic"
+	"github.com/markusmobius/go-domdistiller/internal/filter/localized"
+	"github.com/markusmobius/go-domdistiller/internal/filter/simple"
+	"github.com/markusmobius/go-domdistiller/internal/label"
+)
+
+// Name of stages in the default pipeline.
+const (
+	TerminatingBlocks             = "TerminatingBlocksFinder"
+	DocumentTitleMatch            = "DocumentTitleMatch"
+	NumWordsRules                 = "NumWordsRulesClassifier"
+	IgnoreStrictlyNotContent      = "IgnoreStrictlyNotContent"
+	CrossHeadingsSiblingExpansion = "CrossHeadingsSimilarSiblingContentExpansion"
+	MixedTagsSiblingExpansion     = "MixedTagsSimilarSiblingContentExpansion"
+	HeadingFusion                 = "HeadingFusion"
+	BlockProximityFusionPre       = "BlockProximityFusionPre"
+	KeepTitle                     = "BoilerplateBlockKeepTitle"
+	BlockProximityFusionPost      = "BlockProximityFusionPost"
+	KeepLargestBlock              = "KeepLargestBlock"
+	ExpandTitleToContent          = "ExpandTitleToContent"
+	LargeBlockAroundTagLevel      = "LargeBlockAroundTagLevelToContent"
+	ListAtEnd                     = "ListAtEnd"
+)
+
+// Labels that put into TextBlock by the built-in filters.
+const (
+	LabelTitle                = label.Title
+	LabelArticleMetadata      = label.ArticleMetadata
+	LabelMightBeContent       = label.MightBeContent
+	LabelVeryLikelyContent    = label.VeryLikelyContent
+	LabelHeading              = label.Heading
+	LabelStrictlyNotContent   = label.StrictlyNotContent
+	LabelSiblingOfMainContent = label.SiblingOfMainContent
+)
+
+// SimilarSiblingContentExpansion marks siblings of content as content if they are
+// similar enough. The similarity test is configurable using its fields.
+type SimilarSiblingContentExpansion = heuristic.SimilarSiblingContent
+
+// PhrasePack is the list of phrases in a language that usually found in the block that
+// marks the end of an article, e.g. the heading of comment section or a share button.
+type PhrasePack = localized.PhrasePack
+
+// RegisterPhrasePack registers the phrase pack that used by TerminatingBlocksFinder for
+// the specified language. Built-in packs are available for German, French, Spanish,
+// Portuguese, Italian, Russian, Japanese, Chinese, Indonesian and Swedish, while the
+// original English phrases are always used. If the language already has a pack, the
+// new pack is used in addition to the old one.
+func RegisterPhrasePack(language string, pack PhrasePack) error {
+	return localized.RegisterPhrasePack(language, pack)
+}
+
+// Default returns the pipeline that used by distiller when none is specified in
+// the options. It's the boilerpipe chain which is tuned towards news articles.
+// Every call returns a new pipeline, so it's safe to modify.
+func Default() *Pipeline {
+	return New(
+		Stage{
+			Name: TerminatingBlocks,
+			NewFilter: func(info DocumentInfo) Filter {
+				return NewTerminatingBlocksFinder(info.Language)
+			},
+			SkipLog: true,
+		},
+		Stage{
+			Name: DocumentTitleMatch,
+			NewFilter: func(info DocumentInfo) Filter {
+				return NewDocumentTitleMatch(info.WordCounter, info.CandidateTitles...)
+			},
+			SkipLog: true,
+		},
+		NewStage(NumWordsRules, NewNumWordsRulesClassifier()),
+		NewStage(IgnoreStrictlyNotContent, NewLabelToBoilerplate(LabelStrictlyNotContent)),
+		NewStage(CrossHeadingsSiblingExpansion, &SimilarSiblingContentExpansion{
+			AllowCrossHeadings: true,
+			MaxLinkDensity:     0.5,
+			MaxBlockDistance:   10,
+		}),
+		NewStage(MixedTagsSiblingExpansion, &SimilarSiblingContentExpansion{
+			AllowCrossHeadings: true,
+			AllowMixedTags:     true,
+			MaxBlockDistance:   10,
+		}),
+		NewStage(HeadingFusion, NewHeadingFusion()),
+		NewStage(BlockProximityFusionPre, NewBlockProximityFusion(false)),
+		NewStage(KeepTitle, NewBoilerplateBlock(LabelTitle)),
+		NewStage(BlockProximityFusionPost, NewBlockProximityFusion(true)),
+		NewStage(KeepLargestBlock, NewKeepLargestBlock(true)),
+		NewStage(ExpandTitleToContent, NewExpandTitleToContent()),
+		NewStage(LargeBlockAroundTagLevel, NewLargeBlockAroundTagLevelToContent()),
+		NewStage(ListAtEnd, NewListAtEnd()),
+	)
+}
+
+// NewTerminatingBlocksFinder creates filter that finds blocks which are potentially
+// indicating the end of an article text, e.g. "Comments" or "Related articles". The
+// phrase packs are chosen by the language, or only English is used if language is empty.
+func NewTerminatingBlocksFinder(language string) Filter {
+	return localized.NewTerminatingBlocksFinder(language)
+}
+
+// NewDocumentTitleMatch creates filter that labels blocks which contain
+// parts of the document title.
+func NewDocumentTitleMatch(wc WordCounter, titles ...string) Filter {
+	return heuristic.NewDocumentTitleMatch(wc, titles...)
+}
+
+// NewNumWordsRulesClassifier creates filter that classifies blocks as content
+// based on the number of words and link density of the block and its neighbours.
+func NewNumWordsRulesClassifier() Filter {
+	return heuristic.NewNumWordsRulesClassifier()
+}
+
+// NewLabelToBoilerplate creates filter that marks blocks
+// with any of the specified labels as boilerplate.
+func NewLabelToBoilerplate(labels ...string) Filter {
+	return simple.NewLabelToBoilerplate(labels...)
+}
+
+// NewSimilarSiblingContentExpansion creates SimilarSiblingContentExpansion with the
+// most strict configuration. Set its fields to loosen the similarity test.
+func NewSimilarSiblingContentExpansion() *SimilarSiblingContentExpansion {
+	return heuristic.NewSimilarSiblingContentExpansion()
+}
+
+// NewHeadingFusion creates filter that fuses headings with the content after it.
+func NewHeadingFusion() Filter {
+	return heuristic.NewHeadingFusion()
+}
+
+// NewBlockProximityFusion creates filter that fuses adjacent blocks. If postFiltering
+// is true, only content blocks within the same tag level are fused.
+func NewBlockProximityFusion(postFiltering bool) Filter {
+	return heuristic.NewBlockProximityFusion(postFiltering)
+}
+
+// NewBoilerplateBlock creates filter that removes blocks which are not content,
+// except the ones with the specified label.
+func NewBoilerplateBlock(labelToKeep string) Filter {
+	return simple.NewBoilerplateBlock(labelToKeep)
+}
+
+// NewKeepLargestBlock creates filter that keeps only the largest content block.
+// If expandToSiblings is true, siblings of the largest block are kept as well.
+func NewKeepLargestBlock(expandToSiblings bool) Filter {
+	return heuristic.NewKeepLargestBlock(expandToSiblings)
+}
+
+// NewExpandTitleToContent creates filter that marks all blocks between
+// the title and the content as content.
+func NewExpandTitleToContent() Filter {
+	return heuristic.NewExpandTitleToContent()
+}
+
+// NewLargeBlockAroundTagLevelToContent creates filter that marks large blocks around
+// the main content that have the same tag level as content.
+func NewLargeBlockAroundTagLevelToContent() Filter {
+	return heuristic.NewLargeBlockAroundTagLevelToContent()
+}
+
+// NewListAtEnd creates filter that marks lists at the end of content as content.
+func NewListAtEnd() Filter {
+	return heuristic.NewListAtEnd()
+}
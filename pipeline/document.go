@@ -0,0 +1,138 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package pipeline
+
+import (
+	"github.com/markusmobius/go-domdistiller/internal/filter"
+	"github.com/markusmobius/go-domdistiller/internal/filter/docfilter"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+)
+
+// Document is the document that processed by document filters, after the text blocks
+// are marked as content. It consists of list of elements (text, image, figure, embed,
+// etc) in the order they appear in the page.
+type Document = webdoc.Document
+
+// DocumentFilter is a single step in the document pipeline. Its Process
+// method returns true if it made changes to the document.
+type DocumentFilter = filter.DocumentFilter
+
+// DocumentFilterFunc is an adapter to allow the use of ordinary function as DocumentFilter.
+type DocumentFilterFunc func(doc *Document) bool
+
+// Process calls f(doc).
+func (f DocumentFilterFunc) Process(doc *Document) bool {
+	return f(doc)
+}
+
+// Name of stages in the default document pipeline.
+const (
+	JunkImages            = "JunkImageFilter"
+	ResolveImages         = "ImageResolver"
+	RelevantElements      = "RelevantElements"
+	LeadImage             = "LeadImageFinder"
+	NestedElementRetainer = "NestedElementRetainer"
+)
+
+// DocumentStage is a named step in the document pipeline.
+type DocumentStage struct {
+	// Name is used to identify the stage within pipeline.
+	Name string
+
+	// NewFilter creates the filter for the specified document.
+	NewFilter func(info DocumentInfo) DocumentFilter
+}
+
+func (s DocumentStage) stageName() string {
+	return s.Name
+}
+
+// NewDocumentStage creates document stage which uses the same filter for all documents.
+func NewDocumentStage(name string, f DocumentFilter) DocumentStage {
+	return DocumentStage{
+		Name:      name,
+		NewFilter: func(DocumentInfo) DocumentFilter { return f },
+	}
+}
+
+// DocumentPipeline is an ordered list of document stages, which run after the text
+// blocks are marked as content by Pipeline. The zero value is an empty pipeline
+// which keeps the document as it is.
+type DocumentPipeline struct {
+	stageList[DocumentStage]
+}
+
+// NewDocument creates document pipeline from the specified stages.
+func NewDocument(stages ...DocumentStage) *DocumentPipeline {
+	return &DocumentPipeline{stageList: newStageList(stages)}
+}
+
+// DefaultDocument returns the document pipeline that used by distiller when none is
+// specified in the options. It removes junk images, resolves responsive images, marks
+// the elements around content as content, then looks for the lead image. Every call
+// returns a new pipeline, so it's safe to modify.
+func DefaultDocument() *DocumentPipeline {
+	return NewDocument(
+		DocumentStage{
+			Name: JunkImages,
+			NewFilter: func(info DocumentInfo) DocumentFilter {
+				return docfilter.NewJunkImageFilter(info.ImageBlocklist, info.Logger)
+			},
+		},
+		DocumentStage{
+			Name: ResolveImages,
+			NewFilter: func(info DocumentInfo) DocumentFilter {
+				return docfilter.NewImageResolver(info.ImageResolver)
+			},
+		},
+		NewDocumentStage(RelevantElements, docfilter.NewRelevantElements()),
+		DocumentStage{
+			Name: LeadImage,
+			NewFilter: func(info DocumentInfo) DocumentFilter {
+				return docfilter.NewLeadImageFinder(info.Logger)
+			},
+		},
+		NewDocumentStage(NestedElementRetainer, docfilter.NewNestedElementRetainer()),
+	)
+}
+
+// Clone returns copy of the pipeline, so it can be modified
+// without changing the original one.
+func (p *DocumentPipeline) Clone() *DocumentPipeline {
+	return NewDocument(p.stages...)
+}
+
+// Run processes the document using every stage in order.
+// Returns true if any stage made changes to the document.
+func (p *DocumentPipeline) Run(doc *Document, info DocumentInfo) bool {
+	changes := false
+	for _, stage := range p.stages {
+		if stage.NewFilter == nil {
+			continue
+		}
+
+		if f := stage.NewFilter(info); f != nil {
+			changes = f.Process(doc) || changes
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/markusmobius/go-domdistiller/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DocumentPipeline_DefaultStages(t *testing.T) {
+	assert.Equal(t, []string{
+		pipeline.JunkImages,
+		pipeline.ResolveImages,
+		pipeline.RelevantElements,
+		pipeline.LeadImage,
+		pipeline.NestedElementRetainer,
+	}, documentStageNames(pipeline.DefaultDocument()))
+}
+
+func Test_DocumentPipeline_ModifyStages(t *testing.T) {
+	p := pipeline.NewDocument(noopDocumentStage("a"), noopDocumentStage("b"), noopDocumentStage("c"))
+
+	assert.True(t, p.InsertBefore("a", noopDocumentStage("x")))
+	assert.True(t, p.InsertAfter("c", noopDocumentStage("y")))
+	assert.True(t, p.Remove("b"))
+	assert.True(t, p.Replace("c", noopDocumentStage("w")))
+	assert.Equal(t, []string{"x", "a", "w", "y"}, documentStageNames(p))
+	assert.False(t, p.Remove("unknown"))
+
+	// Clone must not share stages with the original pipeline
+	clone := p.Clone()
+	clone.Append(noopDocumentStage("v"))
+	assert.Equal(t, []string{"x", "a", "w", "y"}, documentStageNames(p))
+	assert.Equal(t, []string{"x", "a", "w", "y", "v"}, documentStageNames(clone))
+}
+
+func Test_DocumentPipeline_Run(t *testing.T) {
+	doc := webdoc.NewDocument()
+	doc.AddElements(webdoc.NewTag("div", webdoc.TagStart), webdoc.NewTag("div", webdoc.TagEnd))
+
+	var blocklist []string
+	p := pipeline.NewDocument(
+		pipeline.NewDocumentStage("all-content", pipeline.DocumentFilterFunc(func(doc *pipeline.Document) bool {
+			for _, e := range doc.Elements {
+				e.SetIsContent(true)
+			}
+			return true
+		})),
+		pipeline.DocumentStage{
+			Name: "blocklist",
+			NewFilter: func(info pipeline.DocumentInfo) pipeline.DocumentFilter {
+				blocklist = info.ImageBlocklist
+				return nil
+			},
+		},
+	)
+
+	assert.True(t, p.Run(doc, pipeline.DocumentInfo{ImageBlocklist: []string{"/ads/"}}))
+	assert.True(t, doc.Elements[0].IsContent())
+	assert.True(t, doc.Elements[1].IsContent())
+	assert.Equal(t, []string{"/ads/"}, blocklist)
+}
+
+func noopDocumentStage(name string) pipeline.DocumentStage {
+	return pipeline.NewDocumentStage(name, pipeline.DocumentFilterFunc(func(*pipeline.Document) bool {
+		return false
+	}))
+}
+
+func documentStageNames(p *pipeline.DocumentPipeline) []string {
+	var names []string
+	for _, stage := range p.Stages() {
+		names = append(names, stage.Name)
+	}
+	return names
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package pipeline contains the chain of filters that used by distiller to decide
+// which text blocks in the page are part of the main content. By default distiller
+// uses the chain from Default, which is tuned towards news articles. The chain can
+// be modified by adding, removing, replacing or reordering its stages, then used
+// by setting it as Pipeline in distiller.Options.
+//
+// Once the content is decided, the document with its images and embeds is processed
+// by the second chain from DefaultDocument, e.g. to remove junk images and to find the
+// lead image. It can be modified the same way, then used by setting it as
+// DocumentPipeline in distiller.Options.
+package pipeline
+
+import (
+	"github.com/markusmobius/go-domdistiller/internal/filter"
+	"github.com/markusmobius/go-domdistiller/internal/logutil"
+	"github.com/markusmobius/go-domdistiller/internal/stringutil"
+	"github.com/markusmobius/go-domdistiller/internal/webdoc"
+	"github.com/markusmobius/go-domdistiller/srcset"
+)
+
+// TextDocument is the document that processed by filters. It consists of list
+// of TextBlock in the order they appear in the page.
+type TextDocument = webdoc.TextDocument
+
+// TextBlock is a block of text within TextDocument. Filters mark the block as
+// content using its SetIsContent method, and may use its labels to pass
+// information between filters.
+type TextBlock = webdoc.TextBlock
+
+// WordCounter is used for counting the number of words in a text.
+type WordCounter = stringutil.WordCounter
+
+// Logger is used by the filters to print the details of their process.
+type Logger = logutil.Logger
+
+// Filter is a single step in the pipeline. Its Process method returns true
+// if it made changes to the document.
+type Filter = filter.TextDocumentFilter
+
+// FilterFunc is an adapter to allow the use of ordinary function as Filter.
+type FilterFunc func(doc *TextDocument) bool
+
+// Process calls f(doc).
+func (f FilterFunc) Process(doc *TextDocument) bool {
+	return f(doc)
+}
+
+// DocumentInfo is information about the document that currently processed by
+// the pipeline, which needed by some filters.
+type DocumentInfo struct {
+	// WordCounter is the word counter that suitable for the document's language.
+	WordCounter WordCounter
+
+	// CandidateTitles is list of possible titles of the document,
+	// in descending priority order.
+	CandidateTitles []string
+
+	// Language is the BCP-47 tag of the document's language, e.g. "en" or "pt-BR".
+	// Empty if the language is unknown.
+	Language string
+
+	// ImageBlocklist is the list of strings that, when found in the image URL,
+	// makes the image removed from the document.
+	ImageBlocklist []string
+
+	// ImageResolver is used to pick the best image from srcset and <picture>
+	// sources. If nil, images are kept as they are.
+	ImageResolver *srcset.Resolver
+
+	// Logger is the logger of the distiller. Might be nil.
+	Logger Logger
+}
+
+// Stage is a named step in the pipeline.
+type Stage struct {
+	// Name is used to identify the stage within pipeline and in the log.
+	Name string
+
+	// NewFilter creates the filter for the specified document.
+	NewFilter func(info DocumentInfo) Filter
+
+	// SkipLog marks the stage whose changes are not printed in the log, e.g. because
+	// it only adds labels to the blocks without marking them as content.
+	SkipLog bool
+}
+
+func (s Stage) stageName() string {
+	return s.Name
+}
+
+// NewStage creates stage which uses the same filter for all documents.
+func NewStage(name string, f Filter) Stage {
+	return Stage{
+		Name:      name,
+		NewFilter: func(DocumentInfo) Filter { return f },
+	}
+}
+
+// Pipeline is an ordered list of stages. The zero value is an empty
+// pipeline which doesn't mark anything as content.
+type Pipeline struct {
+	stageList[Stage]
+}
+
+// New creates pipeline from the specified stages.
+func New(stages ...Stage) *Pipeline {
+	return &Pipeline{stageList: newStageList(stages)}
+}
+
+// Clone returns copy of the pipeline, so it can be modified
+// without changing the original one.
+func (p *Pipeline) Clone() *Pipeline {
+	return New(p.stages...)
+}
+
+// Run processes the document using every stage in order. The callback, if
+// not nil, will be called after each stage is finished, e.g. for logging.
+// Returns true if any stage made changes to the document.
+func (p *Pipeline) Run(doc *TextDocument, info DocumentInfo, callback func(stage Stage, changed bool)) bool {
+	changes := false
+	for _, stage := range p.stages {
+		if stage.NewFilter == nil {
+			continue
+		}
+
+		f := stage.NewFilter(info)
+		if f == nil {
+			continue
+		}
+
+		changed := f.Process(doc)
+		changes = changes || changed
+		if callback != nil {
+			callback(stage, changed)
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pipeline_DefaultStages(t *testing.T) {
+	assert.Equal(t, []string{
+		pipeline.TerminatingBlocks,
+		pipeline.DocumentTitleMatch,
+		pipeline.NumWordsRules,
+		pipeline.IgnoreStrictlyNotContent,
+		pipeline.CrossHeadingsSiblingExpansion,
+		pipeline.MixedTagsSiblingExpansion,
+		pipeline.HeadingFusion,
+		pipeline.BlockProximityFusionPre,
+		pipeline.KeepTitle,
+		pipeline.BlockProximityFusionPost,
+		pipeline.KeepLargestBlock,
+		pipeline.ExpandTitleToContent,
+		pipeline.LargeBlockAroundTagLevel,
+		pipeline.ListAtEnd,
+	}, stageNames(pipeline.Default()))
+
+	// Only the labelling stages are excluded from the log
+	for _, stage := range pipeline.Default().Stages() {
+		skipLog := stage.Name == pipeline.TerminatingBlocks || stage.Name == pipeline.DocumentTitleMatch
+		assert.Equal(t, skipLog, stage.SkipLog, stage.Name)
+	}
+}
+
+func Test_Pipeline_ModifyStages(t *testing.T) {
+	p := pipeline.New(noopStage("a"), noopStage("b"), noopStage("c"))
+
+	assert.True(t, p.InsertBefore("a", noopStage("x")))
+	assert.True(t, p.InsertAfter("c", noopStage("y")))
+	assert.True(t, p.InsertAfter("a", noopStage("z")))
+	assert.Equal(t, []string{"x", "a", "z", "b", "c", "y"}, stageNames(p))
+
+	assert.True(t, p.Remove("z"))
+	assert.True(t, p.Replace("b", noopStage("w")))
+	assert.Equal(t, []string{"x", "a", "w", "c", "y"}, stageNames(p))
+	assert.Equal(t, 2, p.Index("w"))
+
+	assert.False(t, p.InsertBefore("unknown", noopStage("q")))
+	assert.False(t, p.InsertAfter("unknown", noopStage("q")))
+	assert.False(t, p.Replace("unknown", noopStage("q")))
+	assert.False(t, p.Remove("unknown"))
+	assert.Equal(t, -1, p.Index("unknown"))
+
+	// Clone must not share stages with the original pipeline
+	clone := p.Clone()
+	clone.Append(noopStage("v"))
+	assert.Equal(t, []string{"x", "a", "w", "c", "y"}, stageNames(p))
+	assert.Equal(t, []string{"x", "a", "w", "c", "y", "v"}, stageNames(clone))
+}
+
+func Test_Pipeline_Run(t *testing.T) {
+	doc := &pipeline.TextDocument{TextBlocks: []*pipeline.TextBlock{
+		{Text: "Lorem ipsum dolor sit amet.", NumWords: 5},
+		{Text: "Short", NumWords: 1},
+	}}
+
+	var order []string
+	var titles []string
+	p := pipeline.New(
+		pipeline.NewStage("long-blocks", pipeline.FilterFunc(func(doc *pipeline.TextDocument) bool {
+			changes := false
+			for _, tb := range doc.TextBlocks {
+				if tb.NumWords >= 5 {
+					changes = tb.SetIsContent(true) || changes
+				}
+			}
+			return changes
+		})),
+		pipeline.Stage{
+			Name: "titles",
+			NewFilter: func(info pipeline.DocumentInfo) pipeline.Filter {
+				titles = info.CandidateTitles
+				return pipeline.FilterFunc(func(*pipeline.TextDocument) bool { return false })
+			},
+		},
+	)
+
+	changed := p.Run(doc, pipeline.DocumentInfo{CandidateTitles: []string{"Title"}},
+		func(stage pipeline.Stage, changed bool) {
+			order = append(order, stage.Name)
+		})
+
+	assert.True(t, changed)
+	assert.True(t, doc.TextBlocks[0].IsContent())
+	assert.False(t, doc.TextBlocks[1].IsContent())
+	assert.Equal(t, []string{"long-blocks", "titles"}, order)
+	assert.Equal(t, []string{"Title"}, titles)
+}
+
+func noopStage(name string) pipeline.Stage {
+	return pipeline.NewStage(name, pipeline.FilterFunc(func(*pipeline.TextDocument) bool {
+		return false
+	}))
+}
+
+func stageNames(p *pipeline.Pipeline) []string {
+	var names []string
+	for _, stage := range p.Stages() {
+		names = append(names, stage.Name)
+	}
+	return names
+}
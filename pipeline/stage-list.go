@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package pipeline
+
+// namedStage is the stage that can be put into stageList, i.e. Stage and DocumentStage.
+type namedStage interface {
+	Stage | DocumentStage
+	stageName() string
+}
+
+// stageList is an ordered list of stages, which shared by Pipeline and DocumentPipeline.
+type stageList[S namedStage] struct {
+	stages []S
+}
+
+func newStageList[S namedStage](stages []S) stageList[S] {
+	return stageList[S]{stages: append([]S{}, stages...)}
+}
+
+// Stages returns copy of the stages in the pipeline.
+func (l *stageList[S]) Stages() []S {
+	return append([]S{}, l.stages...)
+}
+
+// Index returns the index of the first stage with the specified name,
+// or -1 if there are no such stage.
+func (l *stageList[S]) Index(name string) int {
+	for i, stage := range l.stages {
+		if stage.stageName() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Append adds the stages at the end of pipeline.
+func (l *stageList[S]) Append(stages ...S) {
+	l.stages = append(l.stages, stages...)
+}
+
+// InsertBefore adds the stages before the stage with the specified name.
+// Returns false if there are no such stage.
+func (l *stageList[S]) InsertBefore(name string, stages ...S) bool {
+	idx := l.Index(name)
+	if idx < 0 {
+		return false
+	}
+
+	l.insert(idx, stages...)
+	return true
+}
+
+// InsertAfter adds the stages after the stage with the specified name.
+// Returns false if there are no such stage.
+func (l *stageList[S]) InsertAfter(name string, stages ...S) bool {
+	idx := l.Index(name)
+	if idx < 0 {
+		return false
+	}
+
+	l.insert(idx+1, stages...)
+	return true
+}
+
+// Replace replaces the stage with the specified name.
+// Returns false if there are no such stage.
+func (l *stageList[S]) Replace(name string, stage S) bool {
+	idx := l.Index(name)
+	if idx < 0 {
+		return false
+	}
+
+	l.stages[idx] = stage
+	return true
+}
+
+// Remove removes the stage with the specified name.
+// Returns false if there are no such stage.
+func (l *stageList[S]) Remove(name string) bool {
+	idx := l.Index(name)
+	if idx < 0 {
+		return false
+	}
+
+	l.stages = append(l.stages[:idx], l.stages[idx+1:]...)
+	return true
+}
+
+func (l *stageList[S]) insert(idx int, stages ...S) {
+	newStages := make([]S, 0, len(l.stages)+len(stages))
+	newStages = append(newStages, l.stages[:idx]...)
+	newStages = append(newStages, stages...)
+	newStages = append(newStages, l.stages[idx:]...)
+	l.stages = newStages
+}
@@ -0,0 +1,394 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package sanitize removes the unsafe elements and attributes from the distilled
+// HTML, so it can be rendered directly in a web page.
+package sanitize
+
+import (
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"golang.org/x/net/html"
+)
+
+// Policy is the allowlist that decides which elements, attributes
+// and URLs are kept in the sanitized HTML.
+type Policy struct {
+	// Elements is the allowed elements, keyed by tag name, along with the attributes
+	// that allowed for each element. Element that not listed here is removed but its
+	// content is kept, except for elements in DropElements.
+	Elements map[string][]string
+
+	// GlobalAttributes is the attributes that allowed in all allowed elements.
+	GlobalAttributes []string
+
+	// DropElements is the elements that removed along with its content when they are
+	// not allowed, e.g. <script> and <style>.
+	DropElements []string
+
+	// URLSchemes is the allowed schemes for URL in attributes like href and src.
+	// Relative URLs are always allowed.
+	URLSchemes []string
+
+	// AllowDataImages allows URL with "data:image/" prefix in the source of images.
+	AllowDataImages bool
+
+	// IframeHosts is the hosts that allowed as the source of <iframe>. The host also
+	// matches its subdomains, e.g. "youtube.com" matches "www.youtube.com". The host
+	// might be followed by a path prefix, e.g. "google.com/maps" only allows the maps
+	// in google.com. Iframe with other source is removed.
+	IframeHosts []string
+
+	// LinkRel is the value of rel attribute that set to every link, replacing the
+	// original value. If empty, the rel attribute is left as it is.
+	LinkRel string
+}
+
+// urlAttributes is the attributes that contain URL.
+var urlAttributes = map[string]struct{}{
+	"action":     {},
+	"background": {},
+	"cite":       {},
+	"formaction": {},
+	"href":       {},
+	"longdesc":   {},
+	"poster":     {},
+	"src":        {},
+	"xlink:href": {},
+}
+
+// imageElements is the elements whose source is an image.
+var imageElements = map[string]struct{}{
+	"img":    {},
+	"source": {},
+}
+
+// Strict returns the default policy, which only allows the elements that might be
+// produced by the distiller, http(s) and mailto URLs, iframes from the video sites that
+// recognized by the distiller, and force links to use rel="noopener noreferrer". Every
+// call returns a new policy, so it's safe to modify.
+func Strict() *Policy {
+	return &Policy{
+		Elements: map[string][]string{
+			"a":          {"href"},
+			"abbr":       {},
+			"b":          {},
+			"blockquote": {"cite"},
+			"br":         {},
+			"caption":    {},
+			"cite":       {},
+			"code":       {},
+			"col":        {"span"},
+			"colgroup":   {"span"},
+			"dd":         {},
+			"del":        {"cite", "datetime"},
+			"details":    {},
+			"dfn":        {},
+			"div":        {"class", "data-type", "data-id"},
+			"dl":         {},
+			"dt":         {},
+			"em":         {},
+			"figcaption": {},
+			"figure":     {},
+			"h1":         {},
+			"h2":         {},
+			"h3":         {},
+			"h4":         {},
+			"h5":         {},
+			"h6":         {},
+			"hr":         {},
+			"i":          {},
+			"iframe":     {"src", "width", "height", "allowfullscreen"},
+			"img":        {"src", "srcset", "sizes", "alt", "width", "height"},
+			"ins":        {"cite", "datetime"},
+			"kbd":        {},
+			"li":         {"value"},
+			"mark":       {},
+			"ol":         {"start", "reversed", "type"},
+			"p":          {},
+			"picture":    {},
+			"pre":        {},
+			"q":          {"cite"},
+			"s":          {},
+			"samp":       {},
+			"small":      {},
+			"source":     {"src", "srcset", "sizes", "type", "media"},
+			"span":       {},
+			"strike":     {},
+			"strong":     {},
+			"sub":        {},
+			"summary":    {},
+			"sup":        {},
+			"table":      {},
+			"tbody":      {},
+			"td":         {"colspan", "rowspan"},
+			"tfoot":      {},
+			"th":         {"colspan", "rowspan", "scope"},
+			"thead":      {},
+			"time":       {"datetime"},
+			"tr":         {},
+			"track":      {"src", "kind", "srclang", "label"},
+			"u":          {},
+			"ul":         {},
+			"var":        {},
+			"video":      {"src", "poster", "controls", "width", "height"},
+		},
+		GlobalAttributes: []string{"dir", "lang", "title"},
+		DropElements: []string{"script", "style", "noscript", "template", "iframe", "object",
+			"embed", "applet", "frame", "frameset", "form", "input", "button", "select",
+			"textarea", "svg", "math", "link", "meta", "base", "head", "title"},
+		URLSchemes:      []string{"http", "https", "mailto"},
+		AllowDataImages: true,
+		IframeHosts: []string{"youtube.com", "youtube-nocookie.com", "player.vimeo.com",
+			"open.spotify.com", "w.soundcloud.com", "embed.podcasts.apple.com", "codepen.io",
+			"google.com/maps"},
+		LinkRel: "noopener noreferrer",
+	}
+}
+
+// SanitizeHTML parses the HTML fragment, sanitizes it and returns the sanitized HTML.
+func (p *Policy) SanitizeHTML(rawHTML string) string {
+	if rawHTML == "" {
+		return ""
+	}
+
+	container := dom.CreateElement("div")
+	dom.SetInnerHTML(container, rawHTML)
+	p.SanitizeNode(container)
+	return dom.InnerHTML(container)
+}
+
+// SanitizeNode sanitizes the children of the node in place. The node itself is
+// not checked, since it's usually a container which created by the caller.
+func (p *Policy) SanitizeNode(node *html.Node) {
+	rules := p.compile()
+
+	var child, next *html.Node
+	for child = node.FirstChild; child != nil; child = next {
+		next = child.NextSibling
+		rules.sanitize(child)
+	}
+}
+
+// SanitizeBlocks sanitizes the HTML of content blocks and their nested blocks in place.
+func (p *Policy) SanitizeBlocks(blocks []data.ContentBlock) {
+	for i := range blocks {
+		blocks[i].HTML = p.SanitizeHTML(blocks[i].HTML)
+		p.SanitizeBlocks(blocks[i].Blocks)
+		for _, item := range blocks[i].Items {
+			p.SanitizeBlocks(item.Blocks)
+		}
+	}
+}
+
+// URLFilter returns function that checks if the URL is allowed by the policy, which
+// useful for output that isn't HTML (e.g. Markdown). The URL is checked the same way
+// as the URL attributes, so "data:image/" URL only allowed for image.
+func (p *Policy) URLFilter() func(url string, isImage bool) bool {
+	return p.compile().isAllowedURL
+}
+
+// compiledPolicy is Policy in form of sets for quick look up.
+type compiledPolicy struct {
+	policy           *Policy
+	elements         map[string]map[string]struct{}
+	globalAttributes map[string]struct{}
+	dropElements     map[string]struct{}
+	urlSchemes       map[string]struct{}
+}
+
+func (p *Policy) compile() *compiledPolicy {
+	cp := &compiledPolicy{
+		policy:           p,
+		elements:         make(map[string]map[string]struct{}),
+		globalAttributes: toSet(p.GlobalAttributes),
+		dropElements:     toSet(p.DropElements),
+		urlSchemes:       toSet(p.URLSchemes),
+	}
+
+	for tagName, attributes := range p.Elements {
+		cp.elements[strings.ToLower(tagName)] = toSet(attributes)
+	}
+
+	return cp
+}
+
+// sanitize sanitizes the node and its descendants. Node that not allowed is either
+// removed or replaced by its children.
+func (cp *compiledPolicy) sanitize(node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		return
+	case html.ElementNode:
+	default:
+		// Comments, doctype, etc.
+		node.Parent.RemoveChild(node)
+		return
+	}
+
+	tagName := strings.ToLower(node.Data)
+	allowedAttributes, allowed := cp.elements[tagName]
+	if allowed && tagName == "iframe" && !cp.isAllowedIframe(node) {
+		allowed = false
+	}
+
+	// Sanitize the children first, so the unwrapped children are already clean.
+	var child, next *html.Node
+	for child = node.FirstChild; child != nil; child = next {
+		next = child.NextSibling
+		cp.sanitize(child)
+	}
+
+	if !allowed {
+		if _, drop := cp.dropElements[tagName]; !drop {
+			for node.FirstChild != nil {
+				child := node.FirstChild
+				node.RemoveChild(child)
+				node.Parent.InsertBefore(child, node)
+			}
+		}
+		node.Parent.RemoveChild(node)
+		return
+	}
+
+	cp.sanitizeAttributes(node, tagName, allowedAttributes)
+}
+
+func (cp *compiledPolicy) sanitizeAttributes(node *html.Node, tagName string, allowedAttributes map[string]struct{}) {
+	_, isImage := imageElements[tagName]
+	finalAttrs := make([]html.Attribute, 0, len(node.Attr))
+	for _, attr := range node.Attr {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" {
+			key = attr.Namespace + ":" + key
+		}
+
+		_, allowed := allowedAttributes[key]
+		_, isGlobal := cp.globalAttributes[key]
+		if !allowed && !isGlobal {
+			continue
+		}
+
+		if _, isURL := urlAttributes[key]; isURL && !cp.isAllowedURL(attr.Val, isImage) {
+			continue
+		}
+
+		if key == "srcset" && !cp.isAllowedSrcset(attr.Val) {
+			continue
+		}
+
+		if key == "rel" && tagName == "a" && cp.policy.LinkRel != "" {
+			continue
+		}
+
+		finalAttrs = append(finalAttrs, html.Attribute{Key: key, Val: attr.Val})
+	}
+
+	if tagName == "a" && cp.policy.LinkRel != "" {
+		finalAttrs = append(finalAttrs, html.Attribute{Key: "rel", Val: cp.policy.LinkRel})
+	}
+
+	node.Attr = finalAttrs
+}
+
+// isAllowedURL checks if the URL is relative or uses the allowed scheme.
+func (cp *compiledPolicy) isAllowedURL(rawURL string, isImage bool) bool {
+	rawURL = strings.TrimSpace(rawURL)
+	lowerURL := strings.ToLower(rawURL)
+	if cp.policy.AllowDataImages && isImage && strings.HasPrefix(lowerURL, "data:image/") {
+		return true
+	}
+
+	// Browsers ignore control characters and whitespace in the scheme,
+	// e.g. "java\tscript:", so they are removed before checking.
+	cleanURL := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, rawURL)
+
+	parsedURL, err := nurl.Parse(cleanURL)
+	if err != nil {
+		return false
+	}
+
+	if parsedURL.Scheme == "" {
+		return !strings.Contains(strings.SplitN(cleanURL, "/", 2)[0], ":")
+	}
+
+	_, allowed := cp.urlSchemes[strings.ToLower(parsedURL.Scheme)]
+	return allowed
+}
+
+// isAllowedSrcset checks if all URLs in srcset are allowed.
+func (cp *compiledPolicy) isAllowedSrcset(srcset string) bool {
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 && !cp.isAllowedURL(fields[0], true) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAllowedIframe checks if the iframe source is one of the allowed hosts.
+func (cp *compiledPolicy) isAllowedIframe(node *html.Node) bool {
+	src := strings.TrimSpace(dom.GetAttribute(node, "src"))
+	if strings.HasPrefix(src, "//") {
+		src = "https:" + src
+	}
+
+	parsedURL, err := nurl.Parse(src)
+	if err != nil || !cp.isAllowedURL(src, false) {
+		return false
+	}
+
+	host := strings.ToLower(parsedURL.Hostname())
+	for _, allowedHost := range cp.policy.IframeHosts {
+		allowedHost, allowedPath, _ := strings.Cut(strings.ToLower(allowedHost), "/")
+		if host != allowedHost && !strings.HasSuffix(host, "."+allowedHost) {
+			continue
+		}
+
+		// Path prefix must match the whole path section, so "/maps" doesn't match "/mapsfoo".
+		if allowedPath = "/" + strings.TrimSuffix(allowedPath, "/"); allowedPath == "/" {
+			return true
+		}
+
+		path := parsedURL.Path
+		if path == allowedPath || strings.HasPrefix(path, allowedPath+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[strings.ToLower(value)] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sanitize_test
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/sanitize"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sanitize_Strict(t *testing.T) {
+	tests := map[string]string{
+		// Unknown elements are unwrapped, dangerous elements are dropped
+		`<p>Hello <font color="red">world</font></p>`:             `<p>Hello world</p>`,
+		`<p>Hello</p><script>alert(1)</script><style>p{}</style>`: `<p>Hello</p>`,
+		`<form><input name="q"/><p>Search</p></form>`:             ``,
+		`<p>Hello<!-- comment --></p>`:                            `<p>Hello</p>`,
+
+		// Attributes
+		`<p onclick="alert(1)" style="color:red" dir="rtl">Hello</p>`: `<p dir="rtl">Hello</p>`,
+		`<img src="a.jpg" onerror="alert(1)" alt="A"/>`:               `<img src="a.jpg" alt="A"/>`,
+
+		// URL schemes
+		`<a href="javascript:alert(1)">Link</a>`:                                `<a rel="noopener noreferrer">Link</a>`,
+		`<a href="java&#09;script:alert(1)">Link</a>`:                           `<a rel="noopener noreferrer">Link</a>`,
+		`<a href="/page" rel="opener" target="_blank">Link</a>`:                 `<a href="/page" rel="noopener noreferrer">Link</a>`,
+		`<a href="mailto:a@example.com">Mail</a>`:                               `<a href="mailto:a@example.com" rel="noopener noreferrer">Mail</a>`,
+		`<a href="data:text/html;base64,PHNjcmlwdD4=">Link</a>`:                 `<a rel="noopener noreferrer">Link</a>`,
+		`<img src="data:image/png;base64,iVBORw0KGgo="/>`:                       `<img src="data:image/png;base64,iVBORw0KGgo="/>`,
+		`<img src="a.jpg" srcset="b.jpg 2x, javascript:x 3x"/>`:                 `<img src="a.jpg"/>`,
+		`<video src="https://example.com/a.mp4" poster="javascript:x"></video>`: `<video src="https://example.com/a.mp4"></video>`,
+
+		// Iframe hosts
+		`<iframe src="https://www.youtube.com/embed/abc" onload="x"></iframe>`: `<iframe src="https://www.youtube.com/embed/abc"></iframe>`,
+		`<iframe src="https://evil.com/embed"><p>Fallback</p></iframe>`:        ``,
+		`<iframe src="javascript:alert(1)"></iframe>`:                          ``,
+		`<iframe src="https://notyoutube.com/embed"></iframe>`:                 ``,
+		`<iframe src="https://open.spotify.com/embed/track/abc"></iframe>`:     `<iframe src="https://open.spotify.com/embed/track/abc"></iframe>`,
+		`<iframe src="https://www.google.com/maps/embed?pb=abc"></iframe>`:     `<iframe src="https://www.google.com/maps/embed?pb=abc"></iframe>`,
+		`<iframe src="https://www.google.com/search?q=abc"></iframe>`:          ``,
+		`<iframe src="https://www.google.com/mapsearch"></iframe>`:             ``,
+	}
+
+	policy := sanitize.Strict()
+	for input, expected := range tests {
+		assert.Equal(t, expected, policy.SanitizeHTML(input), input)
+	}
+}
+
+func Test_Sanitize_CustomPolicy(t *testing.T) {
+	policy := sanitize.Strict()
+	policy.Elements["a"] = append(policy.Elements["a"], "target")
+	policy.Elements["font"] = []string{"color"}
+	policy.IframeHosts = append(policy.IframeHosts, "example.com")
+	policy.URLSchemes = append(policy.URLSchemes, "tel")
+	policy.AllowDataImages = false
+	policy.LinkRel = ""
+
+	tests := map[string]string{
+		`<a href="/page" target="_blank" rel="author">Link</a>`:   `<a href="/page" target="_blank">Link</a>`,
+		`<font color="red">Hello</font>`:                          `<font color="red">Hello</font>`,
+		`<iframe src="https://maps.example.com/embed"></iframe>`:  `<iframe src="https://maps.example.com/embed"></iframe>`,
+		`<a href="tel:+123456">Call</a>`:                          `<a href="tel:+123456">Call</a>`,
+		`<img src="data:image/png;base64,iVBORw0KGgo=" alt="A"/>`: `<img alt="A"/>`,
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, policy.SanitizeHTML(input), input)
+	}
+}
+
+func Test_Sanitize_Blocks(t *testing.T) {
+	blocks := []data.ContentBlock{
+		{Type: data.ParagraphBlock, HTML: `<a href="javascript:x">Hello</a>`},
+		{Type: data.QuoteBlock, Blocks: []data.ContentBlock{
+			{Type: data.ParagraphBlock, HTML: `<b onclick="x">Quote</b>`},
+		}},
+		{Type: data.ListBlock, Items: []data.ListItem{{Blocks: []data.ContentBlock{
+			{Type: data.ParagraphBlock, HTML: `<i style="x">Item</i>`},
+		}}}},
+	}
+
+	sanitize.Strict().SanitizeBlocks(blocks)
+	assert.Equal(t, `<a rel="noopener noreferrer">Hello</a>`, blocks[0].HTML)
+	assert.Equal(t, `<b>Quote</b>`, blocks[1].Blocks[0].HTML)
+	assert.Equal(t, `<i>Item</i>`, blocks[2].Items[0].Blocks[0].HTML)
+}
+
+func Test_Sanitize_URLFilter(t *testing.T) {
+	isAllowedURL := sanitize.Strict().URLFilter()
+	assert.True(t, isAllowedURL("https://example.com/page", false))
+	assert.True(t, isAllowedURL("/page", false))
+	assert.True(t, isAllowedURL("data:image/png;base64,iVBORw0KGgo=", true))
+	assert.False(t, isAllowedURL("data:image/png;base64,iVBORw0KGgo=", false))
+	assert.False(t, isAllowedURL(" JavaScript:alert(1)", false))
+	assert.False(t, isAllowedURL("java\tscript:alert(1)", false))
+	assert.False(t, isAllowedURL("VBScript:msgbox(1)", true))
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package siterule contains declarative rules for fixing the extraction result on
+// specific sites. Each rule is keyed by host pattern, and tells the distiller which
+// element contains the content, which elements must be removed or kept, and where
+// to find the title, author and publication date. The rules are consulted before
+// the distiller runs its heuristics.
+package siterule
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is the extraction rule for a site. Every field is optional and
+// contains CSS selector.
+type Rule struct {
+	// ContentRoot is the selector for element that contains the main content. If it
+	// exists in the page, only its content will be distilled and the heuristics for
+	// deciding which blocks are content will be skipped.
+	ContentRoot string `json:"content_root,omitempty" yaml:"content_root,omitempty"`
+
+	// Strip is the list of selectors for elements that never be part of content.
+	Strip []string `json:"strip,omitempty" yaml:"strip,omitempty"`
+
+	// AlwaysContent is the list of selectors for elements that always be part
+	// of content, even if the heuristics decide otherwise.
+	AlwaysContent []string `json:"always_content,omitempty" yaml:"always_content,omitempty"`
+
+	// Title is the selector for element that contains the article title.
+	Title string `json:"title,omitempty" yaml:"title,omitempty"`
+
+	// Author is the selector for element that contains the author name.
+	Author string `json:"author,omitempty" yaml:"author,omitempty"`
+
+	// Date is the selector for element that contains the publication date. The
+	// date is taken from its "datetime" or "content" attribute, or its text.
+	Date string `json:"date,omitempty" yaml:"date,omitempty"`
+}
+
+// Validate checks if every selector in the rule is valid.
+func (r *Rule) Validate() error {
+	selectors := []string{r.ContentRoot, r.Title, r.Author, r.Date}
+	selectors = append(selectors, r.Strip...)
+	selectors = append(selectors, r.AlwaysContent...)
+
+	for _, selector := range selectors {
+		if selector == "" {
+			continue
+		}
+
+		if _, err := cascadia.ParseGroup(selector); err != nil {
+			return fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+	}
+
+	return nil
+}
+
+// Rules is collection of Rule keyed by host pattern. The pattern "example.com"
+// matches the host example.com and all of its subdomains, while "*.example.com"
+// only matches the subdomains.
+type Rules map[string]*Rule
+
+// Parse parses the rules from YAML or JSON document, then validates it.
+func Parse(data []byte) (Rules, error) {
+	var rules Rules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse site rules: %w", err)
+	}
+
+	for pattern, rule := range rules {
+		if rule == nil {
+			delete(rules, pattern)
+			continue
+		}
+
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("rule for %q: %w", pattern, err)
+		}
+	}
+
+	return rules, nil
+}
+
+// Load reads and parses the rules from the specified YAML or JSON file.
+func Load(path string) (Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+// Match returns the rule for the specified host, which must not contain port. If there are several matching
+// patterns, the most specific one is used. Returns nil if there are no match.
+func (rules Rules) Match(host string) *Rule {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" || len(rules) == 0 {
+		return nil
+	}
+
+	// Sort the patterns so the result is deterministic
+	patterns := make([]string, 0, len(rules))
+	for pattern := range rules {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	var bestRule *Rule
+	var bestLength int
+	for _, pattern := range patterns {
+		normalized := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(pattern)), ".")
+		if !matchHost(host, normalized) {
+			continue
+		}
+
+		if bestRule == nil || len(normalized) > bestLength {
+			bestRule = rules[pattern]
+			bestLength = len(normalized)
+		}
+	}
+
+	return bestRule
+}
+
+func matchHost(host, pattern string) bool {
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:])
+	}
+
+	return host == pattern || strings.HasSuffix(host, "."+pattern)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package siterule_test
+
+import (
+	"testing"
+
+	"github.com/markusmobius/go-domdistiller/siterule"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SiteRule_ParseYAML(t *testing.T) {
+	rules, err := siterule.Parse([]byte(`
+example.com:
+  content_root: article .body
+  strip:
+    - .share
+    - .related
+  always_content: [".gallery"]
+  title: h1.headline
+  author: .byline a
+  date: time.published
+"*.blogspot.com":
+  content_root: .post-body
+`))
+
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, &siterule.Rule{
+		ContentRoot:   "article .body",
+		Strip:         []string{".share", ".related"},
+		AlwaysContent: []string{".gallery"},
+		Title:         "h1.headline",
+		Author:        ".byline a",
+		Date:          "time.published",
+	}, rules["example.com"])
+}
+
+func Test_SiteRule_ParseJSON(t *testing.T) {
+	rules, err := siterule.Parse([]byte(`{
+		"example.com": {"content_root": "#main", "strip": [".ad"]}
+	}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &siterule.Rule{
+		ContentRoot: "#main",
+		Strip:       []string{".ad"},
+	}, rules["example.com"])
+}
+
+func Test_SiteRule_InvalidSelector(t *testing.T) {
+	_, err := siterule.Parse([]byte(`
+example.com:
+  strip: ["div["]
+`))
+	assert.Error(t, err)
+
+	_, err = siterule.Parse([]byte(`not: [valid`))
+	assert.Error(t, err)
+}
+
+func Test_SiteRule_Match(t *testing.T) {
+	exampleRule := &siterule.Rule{ContentRoot: "#example"}
+	newsRule := &siterule.Rule{ContentRoot: "#news"}
+	blogspotRule := &siterule.Rule{ContentRoot: "#blogspot"}
+	rules := siterule.Rules{
+		"example.com":      exampleRule,
+		"news.example.com": newsRule,
+		"*.blogspot.com":   blogspotRule,
+	}
+
+	assert.Equal(t, exampleRule, rules.Match("example.com"))
+	assert.Equal(t, exampleRule, rules.Match("WWW.Example.com."))
+	assert.Equal(t, newsRule, rules.Match("news.example.com"))
+	assert.Equal(t, newsRule, rules.Match("sport.news.example.com"))
+	assert.Equal(t, blogspotRule, rules.Match("someone.blogspot.com"))
+	assert.Nil(t, rules.Match("blogspot.com"))
+	assert.Nil(t, rules.Match("notexample.com"))
+	assert.Nil(t, rules.Match(""))
+
+	var emptyRules siterule.Rules
+	assert.Nil(t, emptyRules.Match("example.com"))
+}
@@ -0,0 +1,340 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package srcset picks a concrete image from the responsive image candidates, i.e. the
+// srcset attribute and the <source> inside <picture>, for clients that only look at
+// the src attribute of <img>.
+package srcset
+
+import (
+	"math"
+	nurl "net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/data"
+	"github.com/markusmobius/go-domdistiller/internal/domutil"
+	"golang.org/x/net/html"
+)
+
+var (
+	rxMediaFeature = regexp.MustCompile(`^\(\s*(min|max)-width\s*:\s*([\d.]+)(px|em|rem)?\s*\)$`)
+)
+
+// imageExtensions is the MIME type of the common image file extensions.
+var imageExtensions = map[string]string{
+	".avif": "image/avif",
+	".bmp":  "image/bmp",
+	".gif":  "image/gif",
+	".jpeg": "image/jpeg",
+	".jpg":  "image/jpeg",
+	".jxl":  "image/jxl",
+	".png":  "image/png",
+	".svg":  "image/svg+xml",
+	".webp": "image/webp",
+}
+
+// Resolver selects the best image candidate for a client with the specified
+// display width and supported image formats.
+type Resolver struct {
+	// TargetWidth is the width in CSS pixels that the image will be displayed in. It's
+	// also used as the viewport width when evaluating the media of <source>. The smallest
+	// candidate which is not narrower than the target is picked. If zero, the largest
+	// candidate is picked.
+	TargetWidth int
+
+	// TargetDensity is the pixel density of the client's display. Default to 1.
+	TargetDensity float64
+
+	// PreferTypes is the image MIME types in order of preference, e.g. "image/jpeg".
+	// When <picture> has several matching sources, the source with the most preferred
+	// type is used. Types that not listed are less preferred than the listed ones.
+	PreferTypes []string
+
+	// AvoidTypes is the image MIME types that not supported by the client, e.g.
+	// "image/webp" and "image/avif". Candidates with these types are never picked.
+	AvoidTypes []string
+}
+
+// candidateSet is the candidates from a <source> or <img>.
+type candidateSet struct {
+	mimeType   string
+	candidates []data.ImageCandidate
+}
+
+// Select returns the URL of the best image candidate inside the node, which can be
+// <img>, <picture> or any element that contains them. The URL is returned as it is
+// written in the document. Returns empty string if there are no usable candidate.
+func (r *Resolver) Select(node *html.Node) string {
+	img := domutil.GetFirstElementByTagNameInc(node, "img")
+	if img == nil {
+		return ""
+	}
+
+	var sets []candidateSet
+	if picture := img.Parent; picture != nil && dom.TagName(picture) == "picture" {
+		for _, source := range dom.GetElementsByTagName(picture, "source") {
+			if media := dom.GetAttribute(source, "media"); media != "" && !r.matchMedia(media) {
+				continue
+			}
+
+			sets = append(sets, r.createSet(source, dom.GetAttribute(source, "type")))
+		}
+	}
+
+	width, _ := strconv.Atoi(dom.GetAttribute(img, "width"))
+	imgSet := r.createSet(img, "")
+	if src := strings.TrimSpace(dom.GetAttribute(img, "src")); src != "" && !hasDefaultCandidate(imgSet) {
+		imgSet.candidates = append(imgSet.candidates, data.ImageCandidate{URL: src, Density: 1})
+		imgSet.candidates = r.filterCandidates(imgSet.candidates, "")
+	}
+	sets = append(sets, imgSet)
+
+	// Use the set with the most preferred type. If there are several
+	// of them, the first one is used like in the browser.
+	var best *candidateSet
+	bestRank := math.MaxInt
+	for i, set := range sets {
+		if len(set.candidates) == 0 {
+			continue
+		}
+
+		mimeType := set.mimeType
+		if mimeType == "" {
+			mimeType = guessType(set.candidates[0].URL)
+		}
+
+		if rank := r.typeRank(mimeType); rank < bestRank {
+			best, bestRank = &sets[i], rank
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+
+	return r.selectCandidate(best.candidates, width)
+}
+
+// Resolve rewrites the src of the first <img> inside the node into the URL of the
+// best image candidate. Returns true if the src is changed.
+func (r *Resolver) Resolve(node *html.Node) bool {
+	img := domutil.GetFirstElementByTagNameInc(node, "img")
+	if img == nil {
+		return false
+	}
+
+	url := r.Select(node)
+	if url == "" || url == dom.GetAttribute(img, "src") {
+		return false
+	}
+
+	dom.SetAttribute(img, "src", url)
+	return true
+}
+
+func (r *Resolver) createSet(node *html.Node, mimeType string) candidateSet {
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	if r.isAvoided(mimeType) {
+		return candidateSet{}
+	}
+
+	candidates := domutil.ParseSrcSet(dom.GetAttribute(node, "srcset"))
+	return candidateSet{
+		mimeType:   mimeType,
+		candidates: r.filterCandidates(candidates, mimeType),
+	}
+}
+
+// filterCandidates removes the candidates whose type is avoided. If the type
+// is not specified by the source, it's guessed from the file extension.
+func (r *Resolver) filterCandidates(candidates []data.ImageCandidate, mimeType string) []data.ImageCandidate {
+	if mimeType != "" {
+		return candidates
+	}
+
+	var filtered []data.ImageCandidate
+	for _, candidate := range candidates {
+		if !r.isAvoided(guessType(candidate.URL)) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
+
+// selectCandidate picks the candidate with the smallest width which is not narrower
+// than the target width. The width of candidate with density descriptor is computed
+// from the width of the image. If it's not known, the density is used instead.
+func (r *Resolver) selectCandidate(candidates []data.ImageCandidate, imgWidth int) string {
+	density := r.TargetDensity
+	if density <= 0 {
+		density = 1
+	}
+
+	// Compare the width if it's known for every candidate,
+	// otherwise compare the density instead.
+	useWidth := true
+	for _, candidate := range candidates {
+		if candidate.Width == 0 && imgWidth <= 0 {
+			useWidth = false
+			break
+		}
+	}
+
+	// Without target width, the largest candidate is picked.
+	var target float64
+	switch {
+	case r.TargetWidth <= 0:
+	case useWidth:
+		target = float64(r.TargetWidth) * density
+	default:
+		target = density
+	}
+
+	sizes := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		switch {
+		case !useWidth:
+			sizes[i] = candidate.Density
+		case candidate.Width > 0:
+			sizes[i] = float64(candidate.Width)
+		default:
+			sizes[i] = candidate.Density * float64(imgWidth)
+		}
+	}
+
+	bestIdx, largestIdx := -1, 0
+	for i, size := range sizes {
+		if size > sizes[largestIdx] {
+			largestIdx = i
+		}
+
+		if target > 0 && size >= target && (bestIdx < 0 || size < sizes[bestIdx]) {
+			bestIdx = i
+		}
+	}
+
+	if bestIdx < 0 {
+		bestIdx = largestIdx
+	}
+
+	return candidates[bestIdx].URL
+}
+
+// matchMedia evaluates the media query of <source>. Only the media type and
+// min-width or max-width are supported, other features are treated as not
+// matching. If the target width is not specified, the viewport is assumed
+// to be infinitely wide.
+func (r *Resolver) matchMedia(media string) bool {
+	for _, query := range strings.Split(media, ",") {
+		if r.matchMediaQuery(query) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Resolver) matchMediaQuery(query string) bool {
+	for _, part := range strings.Split(strings.ToLower(query), " and ") {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "", "all", "screen", "only screen":
+			continue
+		}
+
+		match := rxMediaFeature.FindStringSubmatch(part)
+		if match == nil {
+			return false
+		}
+
+		width, _ := strconv.ParseFloat(match[2], 64)
+		if match[3] == "em" || match[3] == "rem" {
+			width *= 16
+		}
+
+		if r.TargetWidth <= 0 {
+			if match[1] == "max" {
+				return false
+			}
+			continue
+		}
+
+		viewport := float64(r.TargetWidth)
+		if (match[1] == "min" && viewport < width) || (match[1] == "max" && viewport > width) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Resolver) typeRank(mimeType string) int {
+	for i, preferred := range r.PreferTypes {
+		if strings.EqualFold(preferred, mimeType) {
+			return i
+		}
+	}
+	return len(r.PreferTypes)
+}
+
+func (r *Resolver) isAvoided(mimeType string) bool {
+	if mimeType == "" {
+		return false
+	}
+
+	for _, avoided := range r.AvoidTypes {
+		if strings.EqualFold(avoided, mimeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDefaultCandidate checks if the set already has candidate that can replace
+// src, i.e. a width descriptor or 1x density.
+func hasDefaultCandidate(set candidateSet) bool {
+	for _, candidate := range set.candidates {
+		if candidate.Width > 0 || candidate.Density == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// guessType returns the MIME type of the image from its file extension.
+func guessType(url string) string {
+	if strings.HasPrefix(url, "data:") {
+		mimeType := strings.TrimPrefix(url, "data:")
+		if idx := strings.IndexAny(mimeType, ";,"); idx >= 0 {
+			mimeType = mimeType[:idx]
+		}
+		return strings.ToLower(mimeType)
+	}
+
+	parsedURL, err := nurl.Parse(url)
+	if err != nil {
+		return ""
+	}
+
+	return imageExtensions[strings.ToLower(path.Ext(parsedURL.Path))]
+}
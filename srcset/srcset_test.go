@@ -0,0 +1,125 @@
+// Copyright (c) 2020 Markus Mobius
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package srcset_test
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"github.com/markusmobius/go-domdistiller/srcset"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func createImage(rawHTML string) *html.Node {
+	div := dom.CreateElement("div")
+	dom.SetInnerHTML(div, rawHTML)
+	return dom.FirstElementChild(div)
+}
+
+func Test_SrcSet_WidthDescriptor(t *testing.T) {
+	img := createImage(`<img src="thumb.jpg" srcset="small.jpg 320w, medium.jpg 800w, large.jpg 1600w">`)
+
+	tests := map[int]string{
+		0:    "large.jpg",
+		300:  "small.jpg",
+		320:  "small.jpg",
+		600:  "medium.jpg",
+		1200: "large.jpg",
+		2000: "large.jpg",
+	}
+
+	for width, expected := range tests {
+		resolver := &srcset.Resolver{TargetWidth: width}
+		assert.Equal(t, expected, resolver.Select(img), "width %d", width)
+	}
+
+	// Density of the display is considered
+	resolver := &srcset.Resolver{TargetWidth: 600, TargetDensity: 2}
+	assert.Equal(t, "large.jpg", resolver.Select(img))
+}
+
+func Test_SrcSet_DensityDescriptor(t *testing.T) {
+	// Without width, the density is compared
+	img := createImage(`<img src="image.jpg" srcset="image-2x.jpg 2x, image-3x.jpg 3x">`)
+	assert.Equal(t, "image.jpg", (&srcset.Resolver{TargetWidth: 800}).Select(img))
+	assert.Equal(t, "image-2x.jpg", (&srcset.Resolver{TargetWidth: 800, TargetDensity: 2}).Select(img))
+	assert.Equal(t, "image-3x.jpg", (&srcset.Resolver{}).Select(img))
+
+	// With width, density is converted to width
+	img = createImage(`<img src="image.jpg" width="400" srcset="image-2x.jpg 2x, image-3x.jpg 3x">`)
+	assert.Equal(t, "image-2x.jpg", (&srcset.Resolver{TargetWidth: 800}).Select(img))
+	assert.Equal(t, "image.jpg", (&srcset.Resolver{TargetWidth: 300}).Select(img))
+}
+
+func Test_SrcSet_Picture(t *testing.T) {
+	picture := createImage(`<picture>` +
+		`<source media="(max-width: 600px)" srcset="mobile.jpg">` +
+		`<source type="image/avif" srcset="desktop.avif">` +
+		`<source type="image/webp" srcset="desktop.webp">` +
+		`<img src="desktop.jpg">` +
+		`</picture>`)
+
+	// First matching source is used like in browser
+	assert.Equal(t, "mobile.jpg", (&srcset.Resolver{TargetWidth: 400}).Select(picture))
+	assert.Equal(t, "desktop.avif", (&srcset.Resolver{TargetWidth: 1000}).Select(picture))
+
+	// Avoided types are skipped
+	resolver := &srcset.Resolver{
+		TargetWidth: 1000,
+		AvoidTypes:  []string{"image/avif"},
+	}
+	assert.Equal(t, "desktop.webp", resolver.Select(picture))
+
+	resolver.AvoidTypes = []string{"image/avif", "image/webp"}
+	assert.Equal(t, "desktop.jpg", resolver.Select(picture))
+
+	// Preferred type is used when there are several matching sources
+	resolver = &srcset.Resolver{
+		TargetWidth: 1000,
+		PreferTypes: []string{"image/jpeg", "image/webp"},
+	}
+	assert.Equal(t, "desktop.jpg", resolver.Select(picture))
+}
+
+func Test_SrcSet_AvoidTypesFromExtension(t *testing.T) {
+	img := createImage(`<img src="image.jpg" srcset="image-800.webp 800w, image-1600.webp 1600w">`)
+	resolver := &srcset.Resolver{
+		TargetWidth: 800,
+		AvoidTypes:  []string{"image/webp"},
+	}
+	assert.Equal(t, "image.jpg", resolver.Select(img))
+}
+
+func Test_SrcSet_Resolve(t *testing.T) {
+	img := createImage(`<img src="thumb.jpg" srcset="small.jpg 320w, large.jpg 1600w">`)
+	resolver := &srcset.Resolver{TargetWidth: 1000}
+	assert.True(t, resolver.Resolve(img))
+	assert.Equal(t, "large.jpg", dom.GetAttribute(img, "src"))
+
+	// Already resolved
+	assert.False(t, resolver.Resolve(img))
+
+	// Nothing to resolve
+	img = createImage(`<img src="image.jpg">`)
+	assert.False(t, resolver.Resolve(img))
+	assert.Equal(t, "image.jpg", dom.GetAttribute(img, "src"))
+}